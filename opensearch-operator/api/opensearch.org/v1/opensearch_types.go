@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	monitoring "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +33,9 @@ const (
 	PhaseUpgrading = "UPGRADING"
 )
 
+// ConditionPaused is true while the reconciliation of the cluster is paused
+const ConditionPaused = "Paused"
+
 // OpenSearchHealth is the health of the cluster as returned by the health API.
 type OpenSearchHealth string
 
@@ -50,7 +54,7 @@ type GeneralConfig struct {
 	*ImageSpec `json:",inline,omitempty"`
 	//+kubebuilder:default=9200
 	HttpPort int32 `json:"httpPort,omitempty"`
-	//+kubebuilder:validation:Enum=Opensearch;Op;OP;os;opensearch
+	// Vendor of the OpenSearch distribution, validated by the admission webhook. Defaults to opensearch
 	Vendor         string `json:"vendor,omitempty"`
 	Version        string `json:"version,omitempty"`
 	ServiceAccount string `json:"serviceAccount,omitempty"`
@@ -86,6 +90,98 @@ type GeneralConfig struct {
 	HostNetwork bool `json:"hostNetwork,omitempty"`
 	// OpenSearch installation directory inside the container. Defaults to /usr/share/opensearch if not set.
 	OpenSearchHome string `json:"opensearchHome,omitempty"`
+	// Additional client services, each selecting the pods of a subset of the nodepools
+	AdditionalServices []AdditionalServiceConfig `json:"additionalServices,omitempty"`
+	// Exposes the cluster service outside of the Kubernetes cluster
+	Ingress *IngressConfig `json:"ingress,omitempty"`
+	// Configures the rolling restarts that apply changes to the nodepools
+	RollingRestart *RollingRestartConfig `json:"rollingRestart,omitempty"`
+	// Configures how nodes are drained before they are restarted or removed
+	Drain *DrainConfig `json:"drain,omitempty"`
+}
+
+// DrainTimeoutPolicy defines what happens to a node that is not drained within the drain timeout
+// +kubebuilder:validation:Enum=Abort;ProceedIfReplicas
+type DrainTimeoutPolicy string
+
+const (
+	DrainTimeoutPolicyAbort             DrainTimeoutPolicy = "Abort"
+	DrainTimeoutPolicyProceedIfReplicas DrainTimeoutPolicy = "ProceedIfReplicas"
+)
+
+// DrainConfig configures how the shards are moved off a node before it is restarted or removed
+type DrainConfig struct {
+	// Time after which a drain that did not complete is handled according to timeoutPolicy. If not set the operator
+	// waits until the node is drained
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Abort stops the drain, removes the allocation exclusion of the node and retries after another timeout.
+	// ProceedIfReplicas continues with the node if every shard left on it has a started copy on another node,
+	// and aborts otherwise. Defaults to Abort
+	TimeoutPolicy DrainTimeoutPolicy `json:"timeoutPolicy,omitempty"`
+}
+
+// RollingRestartHealth is the cluster health required before pods are restarted
+// +kubebuilder:validation:Enum=Green;Yellow
+type RollingRestartHealth string
+
+const (
+	RollingRestartHealthGreen  RollingRestartHealth = "Green"
+	RollingRestartHealthYellow RollingRestartHealth = "Yellow"
+)
+
+// RollingRestartConfig configures how the pods of the nodepools are restarted
+type RollingRestartConfig struct {
+	// Cluster health required before pods are restarted. Yellow also restarts pods while replicas are unassigned, as
+	// long as no shards are initializing or relocating. Defaults to Green
+	HealthPrecondition RollingRestartHealth `json:"healthPrecondition,omitempty"`
+	// Node attribute used for shard allocation awareness, e.g. zone. If set, all pods pending a restart whose nodes
+	// have the same value of the attribute are restarted at once, as long as the cluster is green, every index
+	// has replicas and the attribute is in cluster.routing.allocation.awareness.attributes
+	ZoneAttribute string `json:"zoneAttribute,omitempty"`
+}
+
+// IngressConfig defines how a service is exposed using an Ingress or Gateway API routes
+type IngressConfig struct {
+	Enable bool `json:"enable,omitempty"`
+	// Kind of the generated object. HTTPRoute and TLSRoute require the Gateway API CRDs to be installed
+	// +kubebuilder:validation:Enum=Ingress;HTTPRoute;TLSRoute
+	// +kubebuilder:default=Ingress
+	Kind string `json:"kind,omitempty"`
+	// Host names the service is exposed under. If HTTP TLS certificates are generated the hosts are added to the certificate
+	Hosts []string `json:"hosts,omitempty"`
+	// Path prefix routed to the service (Ingress and HTTPRoute), defaults to /
+	Path string `json:"path,omitempty"`
+	// Name of the IngressClass (Ingress only)
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Secret with the certificate for the hosts (Ingress only, Gateway API terminates TLS at the gateway listener)
+	TlsSecret *corev1.LocalObjectReference `json:"tlsSecret,omitempty"`
+	// Gateways the routes attach to (HTTPRoute and TLSRoute only)
+	ParentRefs  []GatewayParentRef `json:"parentRefs,omitempty"`
+	Labels      map[string]string  `json:"labels,omitempty"`
+	Annotations map[string]string  `json:"annotations,omitempty"`
+}
+
+// GatewayParentRef references a Gateway API gateway and optionally one of its listeners
+type GatewayParentRef struct {
+	Name string `json:"name"`
+	// Namespace of the gateway, defaults to the namespace of the cluster
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the gateway listener
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// AdditionalServiceConfig defines a client service for a group of nodepools
+type AdditionalServiceConfig struct {
+	// Name of the service
+	Name string `json:"name"`
+	// Components of the nodepools selected by the service
+	NodePools []string `json:"nodePools,omitempty"`
+	// Selects nodepools that have any of these roles. The role "coordinating" selects nodepools without roles
+	Roles []string `json:"roles,omitempty"`
+	// +kubebuilder:default=ClusterIP
+	Type        corev1.ServiceType `json:"type,omitempty"`
+	Labels      map[string]string  `json:"labels,omitempty"`
+	Annotations map[string]string  `json:"annotations,omitempty"`
 }
 
 type PdbConfig struct {
@@ -149,8 +245,61 @@ type NodePool struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy controls whether PVCs are deleted when the statefulset of this nodepool
+	// is deleted or scaled down. Only applies to PVC based persistence.
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+	// ClientService controls whether the pods of this nodepool are selected by the cluster service (general.serviceName).
+	// Defaults to true
+	ClientService *bool `json:"clientService,omitempty"`
+	// Autoscaling scales the nodepool based on the node stats of OpenSearch, replicas is only used as the initial
+	// number of replicas. Requires confMgmt.autoScaler
+	Autoscaling *NodePoolAutoscaling `json:"autoscaling,omitempty"`
+	// ScaleTarget creates an OpenSearchNodePool with a scale subresource for this nodepool, so that autoscalers like the
+	// HorizontalPodAutoscaler or KEDA can scale it. Replicas is only used as the initial number of replicas and
+	// autoscaling is ignored
+	ScaleTarget bool `json:"scaleTarget,omitempty"`
+	// Maximum number of pods of this nodepool restarted at the same time during a rolling restart, as number or
+	// percentage of the replicas. Defaults to 1
+	RestartMaxUnavailable *intstr.IntOrString `json:"restartMaxUnavailable,omitempty"`
+	// Component name of the nodepool this nodepool replaces. The nodes of the renamed nodepool are drained and removed
+	// once the nodes of this nodepool are ready
+	RenamedFrom string `json:"renamedFrom,omitempty"`
+}
+
+// NodePoolAutoscaling configures the horizontal autoscaling of a nodepool. The nodepool is scaled up by one node when
+// any policy reaches its scaleUpThreshold and scaled down by one node when all policies are below their scaleDownThreshold.
+type NodePoolAutoscaling struct {
+	MinReplicas int32               `json:"minReplicas"`
+	MaxReplicas int32               `json:"maxReplicas"`
+	Policies    []AutoscalingPolicy `json:"policies"`
+	// Time a scale-up must be recommended by the policies before the nodepool is scaled up, defaults to 3m
+	ScaleUpStabilizationWindow *metav1.Duration `json:"scaleUpStabilizationWindow,omitempty"`
+	// Time a scale-down must be recommended by the policies before the nodepool is scaled down, defaults to 15m
+	ScaleDownStabilizationWindow *metav1.Duration `json:"scaleDownStabilizationWindow,omitempty"`
+	// Minimum time between two scaling operations of the nodepool, defaults to 10m
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// AutoscalingPolicy scales a nodepool based on the average of a metric over the nodes of the nodepool
+type AutoscalingPolicy struct {
+	Metric AutoscalingMetric `json:"metric"`
+	// Value of the metric at which the nodepool is scaled up. Percent for JvmHeap, DiskUsage and Cpu, number of
+	// search requests rejected since the last evaluation for SearchRejections
+	ScaleUpThreshold int32 `json:"scaleUpThreshold"`
+	// Value of the metric below which the nodepool may be scaled down. Without it the policy never allows a scale-down
+	ScaleDownThreshold *int32 `json:"scaleDownThreshold,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=JvmHeap;DiskUsage;SearchRejections;Cpu
+type AutoscalingMetric string
+
+const (
+	AutoscalingMetricJvmHeap          AutoscalingMetric = "JvmHeap"
+	AutoscalingMetricDiskUsage        AutoscalingMetric = "DiskUsage"
+	AutoscalingMetricSearchRejections AutoscalingMetric = "SearchRejections"
+	AutoscalingMetricCpu              AutoscalingMetric = "Cpu"
+)
+
 // PersistenceConfig defines options for data persistence
 type PersistenceConfig struct {
 	PersistenceSource `json:","`
@@ -171,11 +320,22 @@ type PVCSource struct {
 
 // ConfMgmt defines which additional services will be deployed
 type ConfMgmt struct {
+	// AutoScaler enables the autoscaling of the nodepools that configure autoscaling
 	AutoScaler bool `json:"autoScaler,omitempty"`
 	VerUpdate  bool `json:"VerUpdate,omitempty"`
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Required
 	SmartScaler bool `json:"smartScaler"`
+	// OrphanedPVCCleanup configures the deletion of PVCs that are left behind by removed nodepools or nodes
+	OrphanedPVCCleanup *OrphanedPVCCleanupConfig `json:"orphanedPvcCleanup,omitempty"`
+}
+
+// OrphanedPVCCleanupConfig defines the garbage collection of PVCs no longer used by any node of the cluster
+type OrphanedPVCCleanupConfig struct {
+	Enable bool `json:"enable,omitempty"`
+	// GracePeriod is the time a PVC must be unused before it is deleted (e.g. "24h").
+	// +kubebuilder:default:="24h"
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 type MonitoringConfig struct {
@@ -254,6 +414,10 @@ type DashboardsConfig struct {
 	PriorityClassName string                  `json:"priorityClassName,omitempty"`
 	// OpenSearch Dashboards installation directory inside the container. Defaults to /usr/share/opensearch-dashboards if not set.
 	OpenSearchDashboardsHome string `json:"opensearchDashboardsHome,omitempty"`
+	// Name of an additional service (general.additionalServices) Dashboards connects to instead of the cluster service
+	OpensearchService string `json:"opensearchService,omitempty"`
+	// Exposes the dashboards service outside of the Kubernetes cluster
+	Ingress *IngressConfig `json:"ingress,omitempty"`
 }
 
 type DashboardsTlsConfig struct {
@@ -269,12 +433,20 @@ type DashboardsTlsConfig struct {
 type Security struct {
 	Tls    *TlsConfig      `json:"tls,omitempty"`
 	Config *SecurityConfig `json:"config,omitempty"`
+	// Authentication backends added as auth domains to config.yml of the security config
+	Authentication *AuthenticationConfig `json:"authentication,omitempty"`
+	// Audit logging of the security plugin
+	Audit *AuditConfig `json:"audit,omitempty"`
 }
 
 // Configure tls usage for transport and http interface
 type TlsConfig struct {
 	Transport *TlsConfigTransport `json:"transport,omitempty"`
 	Http      *TlsConfigHttp      `json:"http,omitempty"`
+	// Automatically rotate the CA generated by the operator before it expires, set to -1 to disable.
+	// A rotation can also be requested with the opensearch.org/rotate-ca annotation
+	//+kubebuilder:default=-1
+	RotateCaDaysBeforeExpiry int `json:"rotateCaDaysBeforeExpiry,omitempty"`
 }
 
 type TlsConfigTransport struct {
@@ -335,8 +507,42 @@ type TlsCertificateConfig struct {
 	// Duration controls the validity period of generated certificates (e.g. "8760h", "720h").
 	//+kubebuilder:default:="8760h"
 	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Key algorithm of generated certificates. Defaults to RSA
+	// +kubebuilder:validation:Enum=RSA;ECDSA
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+	// Key size of generated certificates. For RSA the modulus size in bits, at least 2048 (default 4096), for ECDSA the curve size, 256 (default), 384 or 521
+	KeySize int `json:"keySize,omitempty"`
+	// Hash of the signature algorithm used to sign generated certificates, combined with the key algorithm of the CA.
+	// Defaults to a hash matching the key size of the CA
+	// +kubebuilder:validation:Enum=SHA256;SHA384;SHA512
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+	// Validity of the CA generated by the operator, defaults to 10 years. The CA shared by all interfaces
+	// uses the key settings of the transport config, or the http config if there is no transport config
+	CaDuration *metav1.Duration `json:"caDuration,omitempty"`
 	// Enable hot reloading of TLS certificates. When enabled, certificates are mounted as directories instead of using subPath, allowing Kubernetes to update certificate files when secrets are updated.
 	EnableHotReload bool `json:"enableHotReload,omitempty"`
+	// Optional, let cert-manager issue the certificates instead of the operator. Takes precedence over generate and secret
+	CertManager *CertManagerConfig `json:"certManager,omitempty"`
+}
+
+// CertManagerConfig configures issuing certificates by creating cert-manager Certificate objects
+type CertManagerConfig struct {
+	// Enable issuing the certificates with cert-manager
+	Enable bool `json:"enable,omitempty"`
+	// Issuer or ClusterIssuer that signs the certificates
+	IssuerRef CertManagerIssuerRef `json:"issuerRef"`
+	// Renew the certificates this long before they expire, defaults to the cert-manager default of a third of the duration
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CertManagerIssuerRef references a cert-manager Issuer or ClusterIssuer
+type CertManagerIssuerRef struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default=Issuer
+	Kind string `json:"kind,omitempty"`
+	// +kubebuilder:default=cert-manager.io
+	Group string `json:"group,omitempty"`
 }
 
 // Reference to a secret
@@ -361,6 +567,221 @@ type SecurityConfig struct {
 	// Defaults to the host portion of the cluster URL.
 	OperatorClientServerName string                  `json:"operatorClientServerName,omitempty"`
 	UpdateJob                SecurityUpdateJobConfig `json:"updateJob,omitempty"`
+	// How changes to the securityconfig are applied. Job runs securityadmin.sh in a Job for every change, RestAPI applies
+	// the changed files through the REST API of the security plugin with the admin certificate (OpenSearch 2.x only).
+	// The initial securityconfig is always applied by a Job.
+	// +kubebuilder:validation:Enum=Job;RestAPI
+	// +kubebuilder:default=Job
+	UpdateMode SecurityConfigUpdateMode `json:"updateMode,omitempty"`
+	// How the files of securityConfigSecret are combined with the defaults of the operator. Replace uses the provided files
+	// as they are, Merge deep-merges them into the defaults and applies changes through the REST API without removing
+	// resources, so users and roles managed by the OpensearchUser and OpensearchRole resources are preserved (OpenSearch
+	// 2.x only).
+	// +kubebuilder:validation:Enum=Replace;Merge
+	// +kubebuilder:default=Replace
+	MergeMode SecurityConfigMergeMode `json:"mergeMode,omitempty"`
+}
+
+type SecurityConfigMergeMode string
+
+const (
+	SecurityConfigMergeModeReplace SecurityConfigMergeMode = "Replace"
+	SecurityConfigMergeModeMerge   SecurityConfigMergeMode = "Merge"
+)
+
+type SecurityConfigUpdateMode string
+
+const (
+	SecurityConfigUpdateModeJob     SecurityConfigUpdateMode = "Job"
+	SecurityConfigUpdateModeRestAPI SecurityConfigUpdateMode = "RestAPI"
+)
+
+// AuthenticationConfig configures the authentication backends of the security plugin. The operator merges them into
+// config.yml of the security config, next to the authentication of internal users, and configures Dashboards to match.
+type AuthenticationConfig struct {
+	// Authenticate users with OpenID Connect
+	OpenID *OpenIDAuthConfig `json:"openid,omitempty"`
+	// Authenticate users with SAML
+	Saml *SamlAuthConfig `json:"saml,omitempty"`
+	// Authenticate users against LDAP or Active Directory, optionally with their groups as backend roles
+	Ldap *LdapAuthConfig `json:"ldap,omitempty"`
+	// Authenticate requests with signed JSON web tokens
+	Jwt *JwtAuthConfig `json:"jwt,omitempty"`
+	// Authenticate requests with headers set by a trusted proxy
+	Proxy *ProxyAuthConfig `json:"proxy,omitempty"`
+	// Authenticate requests with TLS client certificates
+	ClientCert *ClientCertAuthConfig `json:"clientCert,omitempty"`
+}
+
+type OpenIDAuthConfig struct {
+	// URL of the OpenID Connect discovery document, e.g. https://idp.example.com/.well-known/openid-configuration
+	ConnectURL string `json:"connectUrl"`
+	// Claim of the token that contains the user name, defaults to the sub claim
+	SubjectKey string `json:"subjectKey,omitempty"`
+	// Claim of the token that contains the backend roles
+	RolesKey string `json:"rolesKey,omitempty"`
+	// Client ID Dashboards uses to log in users
+	ClientID string `json:"clientId,omitempty"`
+	// Secret key that contains the client secret Dashboards uses to log in users
+	ClientSecret *corev1.SecretKeySelector `json:"clientSecret,omitempty"`
+	// Scopes Dashboards requests, defaults to "openid profile email address phone"
+	Scope string `json:"scope,omitempty"`
+	// External URL of Dashboards the identity provider redirects users to after the login
+	BaseRedirectURL string `json:"baseRedirectUrl,omitempty"`
+}
+
+type SamlAuthConfig struct {
+	// URL of the metadata of the identity provider
+	IdpMetadataURL string `json:"idpMetadataUrl"`
+	// Entity ID of the identity provider
+	IdpEntityID string `json:"idpEntityId"`
+	// Entity ID of the service provider as configured in the identity provider
+	SpEntityID string `json:"spEntityId"`
+	// External URL of Dashboards
+	DashboardsURL string `json:"dashboardsUrl"`
+	// Attribute of the SAML response that contains the user name, defaults to the NameID
+	SubjectKey string `json:"subjectKey,omitempty"`
+	// Attribute of the SAML response that contains the backend roles
+	RolesKey string `json:"rolesKey,omitempty"`
+	// Secret key that contains the key to sign the tokens issued after the login, at least 32 characters
+	ExchangeKey corev1.SecretKeySelector `json:"exchangeKey"`
+}
+
+type LdapAuthConfig struct {
+	// LDAP servers as host:port
+	Hosts []string `json:"hosts"`
+	// Connect to the servers with TLS
+	EnableSSL bool `json:"enableSsl,omitempty"`
+	// Verify the hostnames of the server certificates, defaults to true
+	VerifyHostnames *bool `json:"verifyHostnames,omitempty"`
+	// DN the operator binds with to search users, anonymous bind if empty
+	BindDn string `json:"bindDn,omitempty"`
+	// Secret key that contains the password of the bind DN
+	BindPassword *corev1.SecretKeySelector `json:"bindPassword,omitempty"`
+	// Base DN of the user search
+	UserBase string `json:"userBase"`
+	// Filter of the user search, {0} is replaced with the user name. Defaults to (sAMAccountName={0})
+	UserSearch string `json:"userSearch,omitempty"`
+	// Attribute used as user name, defaults to the DN
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+	// Base DN of the group search, resolving groups as backend roles is only enabled if set
+	RoleBase string `json:"roleBase,omitempty"`
+	// Filter of the group search, {0} is replaced with the DN of the user. Defaults to (member={0})
+	RoleSearch string `json:"roleSearch,omitempty"`
+	// Attribute of the group used as backend role, defaults to cn
+	RoleName string `json:"roleName,omitempty"`
+}
+
+type JwtAuthConfig struct {
+	// Secret key that contains the HMAC key or the PEM encoded public key the tokens are signed with
+	SigningKey corev1.SecretKeySelector `json:"signingKey"`
+	// Header that contains the token, defaults to Authorization
+	JwtHeader string `json:"jwtHeader,omitempty"`
+	// URL parameter that contains the token
+	JwtURLParameter string `json:"jwtUrlParameter,omitempty"`
+	// Claim of the token that contains the user name, defaults to the sub claim
+	SubjectKey string `json:"subjectKey,omitempty"`
+	// Claim of the token that contains the backend roles
+	RolesKey string `json:"rolesKey,omitempty"`
+}
+
+type ProxyAuthConfig struct {
+	// Regular expression matching the IP addresses of the proxies allowed to set the headers
+	InternalProxies string `json:"internalProxies"`
+	// Header that contains the user name, defaults to x-proxy-user
+	UserHeader string `json:"userHeader,omitempty"`
+	// Header that contains the comma separated backend roles, defaults to x-proxy-roles
+	RolesHeader string `json:"rolesHeader,omitempty"`
+}
+
+type ClientCertAuthConfig struct {
+	// Attribute of the certificate subject used as user name, defaults to cn
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+	// Attribute of the certificate subject used as backend roles
+	RolesAttribute string `json:"rolesAttribute,omitempty"`
+}
+
+// AuditConfig configures the audit logging of the security plugin. The operator renders the sink into opensearch.yml
+// and the remaining options into audit.yml of the security config.
+type AuditConfig struct {
+	// Sink the audit events are written to, defaults to internal_opensearch
+	// +kubebuilder:validation:Enum=internal_opensearch;external_opensearch;webhook;log4j
+	Type string `json:"type,omitempty"`
+	// Index the internal_opensearch and external_opensearch sinks write to, may contain a date pattern,
+	// e.g. 'security-auditlog-'YYYY.MM.dd
+	Index string `json:"index,omitempty"`
+	// Cluster the external_opensearch sink writes to
+	External *AuditExternalConfig `json:"external,omitempty"`
+	// Endpoint the webhook sink sends the events to
+	Webhook *AuditWebhookConfig `json:"webhook,omitempty"`
+	// Logger the log4j sink writes to
+	Log4j *AuditLog4jConfig `json:"log4j,omitempty"`
+	// Log events of the REST layer, defaults to true
+	EnableRest *bool `json:"enableRest,omitempty"`
+	// Log events of the transport layer, defaults to true
+	EnableTransport *bool `json:"enableTransport,omitempty"`
+	// Categories that are logged, all other categories are disabled. Defaults to all categories
+	IncludeCategories []string `json:"includeCategories,omitempty"`
+	// Categories that are not logged, defaults to AUTHENTICATED and GRANTED_PRIVILEGES if no categories are included
+	ExcludeCategories []string `json:"excludeCategories,omitempty"`
+	// Users whose requests are not logged, defaults to kibanaserver
+	IgnoreUsers []string `json:"ignoreUsers,omitempty"`
+	// Request patterns that are not logged, e.g. indices:data/read/*
+	IgnoreRequests []string `json:"ignoreRequests,omitempty"`
+	// Compliance logging of reads and writes of watched indices
+	Compliance *AuditComplianceConfig `json:"compliance,omitempty"`
+}
+
+type AuditExternalConfig struct {
+	// Endpoints of the cluster as host:port
+	HttpEndpoints []string `json:"httpEndpoints"`
+	// Connect to the cluster with TLS
+	EnableSSL bool `json:"enableSsl,omitempty"`
+	// Verify the hostname of the server certificate, defaults to true
+	VerifyHostnames *bool `json:"verifyHostnames,omitempty"`
+	// Secret with the fields username and password to authenticate to the cluster
+	CredentialsSecret *corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+}
+
+type AuditWebhookConfig struct {
+	// URL the events are sent to
+	URL string `json:"url"`
+	// Format of the events, defaults to JSON
+	// +kubebuilder:validation:Enum=URL_PARAMETER_GET;URL_PARAMETER_POST;TEXT;JSON;SLACK
+	Format string `json:"format,omitempty"`
+	// Verify the certificate of the endpoint, defaults to true
+	SslVerify *bool `json:"sslVerify,omitempty"`
+}
+
+type AuditLog4jConfig struct {
+	// Name of the logger, defaults to audit
+	LoggerName string `json:"loggerName,omitempty"`
+	// Level the events are logged with, defaults to INFO
+	// +kubebuilder:validation:Enum=TRACE;DEBUG;INFO;WARN;ERROR
+	Level string `json:"level,omitempty"`
+}
+
+type AuditComplianceConfig struct {
+	// Enable compliance logging, defaults to true
+	Enabled *bool `json:"enabled,omitempty"`
+	// Index patterns whose reads are logged with the fields that are watched, all fields are watched if the list is empty
+	ReadWatchedFields map[string][]string `json:"readWatchedFields,omitempty"`
+	// Only log the metadata of reads, defaults to true
+	ReadMetadataOnly *bool `json:"readMetadataOnly,omitempty"`
+	// Users whose reads are not logged
+	ReadIgnoreUsers []string `json:"readIgnoreUsers,omitempty"`
+	// Index patterns whose writes are logged
+	WriteWatchedIndices []string `json:"writeWatchedIndices,omitempty"`
+	// Only log the metadata of writes, defaults to true
+	WriteMetadataOnly *bool `json:"writeMetadataOnly,omitempty"`
+	// Log the diff of updated documents, requires writeMetadataOnly to be false
+	WriteLogDiffs bool `json:"writeLogDiffs,omitempty"`
+	// Users whose writes are not logged
+	WriteIgnoreUsers []string `json:"writeIgnoreUsers,omitempty"`
+	// Log changes of the security config, defaults to true
+	InternalConfig *bool `json:"internalConfig,omitempty"`
+	// Log the configuration of the nodes when they start
+	ExternalConfig bool `json:"externalConfig,omitempty"`
 }
 
 // Specific configs for the SecurityConfig update job
@@ -461,6 +882,20 @@ type ClusterSpec struct {
 	Security   *Security        `json:"security,omitempty"`
 	NodePools  []NodePool       `json:"nodePools"`
 	InitHelper InitHelperConfig `json:"initHelper,omitempty"`
+	// MaintenanceWindows in which upgrades, rolling restarts, CA rotations and nodepool migrations may be started.
+	// Operations that were already started are completed outside of the windows. If empty they start immediately.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Paused stops the operator from changing the cluster and the resources managed in it, only the status and
+	// metrics of the cluster are still updated. The cluster can also be paused with the opensearch.org/paused annotation.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period in which disruptive operations may be started
+type MaintenanceWindow struct {
+	// Start of the window as cron expression, e.g. "0 2 * * 6" and "Europe/Berlin"
+	Cron CronExpression `json:"cron"`
+	// Length of the window, e.g. 4h
+	Duration metav1.Duration `json:"duration"`
 }
 
 // ClusterStatus defines the observed state of Es
@@ -476,6 +911,215 @@ type ClusterStatus struct {
 	Health               OpenSearchHealth `json:"health,omitempty"`
 	AdminSecretCreated   bool             `json:"adminsecretcreated,omitempty"`
 	ContextSecretCreated bool             `json:"contextsecretcreated,omitempty"`
+	// CaRotation tracks the rotation of the CA generated by the operator
+	CaRotation *CaRotationStatus `json:"caRotation,omitempty"`
+	// Certificates is the inventory of the TLS certificates used by the cluster
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+	// SecurityConfigFiles reports the files of the securityconfig applied through the REST API
+	SecurityConfigFiles []SecurityConfigFileStatus `json:"securityConfigFiles,omitempty"`
+	// SecurityConfigSources reports which source won for each key of the merged securityconfig
+	SecurityConfigSources []SecurityConfigSource `json:"securityConfigSources,omitempty"`
+	// Autoscaling reports the decisions of the autoscaler for every autoscaled nodepool
+	Autoscaling []AutoscalingStatus `json:"autoscaling,omitempty"`
+	// RollingRestart reports the progress of the last rolling restart
+	RollingRestart *RollingRestartStatus `json:"rollingRestart,omitempty"`
+	// FullRestart reports the progress of the last full cluster restart
+	FullRestart *FullRestartStatus `json:"fullRestart,omitempty"`
+	// MaintenanceWindow reports the open or next maintenance window
+	MaintenanceWindow *MaintenanceWindowStatus `json:"maintenanceWindow,omitempty"`
+	// Drains reports the progress of the nodes being drained
+	Drains []DrainStatus `json:"drains,omitempty"`
+	// Conditions of the cluster
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// MaintenanceWindowStatus describes the maintenance window disruptive operations wait for
+type MaintenanceWindowStatus struct {
+	// True if a maintenance window is open
+	Open bool `json:"open"`
+	// Start of the open maintenance window, or of the next one if no window is open
+	Start *metav1.Time `json:"start,omitempty"`
+	// End of the open maintenance window, or of the next one if no window is open
+	End *metav1.Time `json:"end,omitempty"`
+	// Error if the maintenance windows could not be evaluated
+	Message string `json:"message,omitempty"`
+}
+
+// DrainStatus describes the progress of moving the shards off a node
+type DrainStatus struct {
+	// Name of the node
+	Node string `json:"node"`
+	// Time the drain was started
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// Time the drain progress was last reported
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Number of shards still allocated to the node
+	ShardsRemaining int32 `json:"shardsRemaining,omitempty"`
+	// Number of shards relocating away from the node
+	RelocatingShards int32 `json:"relocatingShards,omitempty"`
+	// Store size of the shards allocated to the node when the drain was started
+	InitialBytes int64 `json:"initialBytes,omitempty"`
+	// Store size of the shards still allocated to the node
+	BytesRemaining int64 `json:"bytesRemaining,omitempty"`
+	// Store size of the shards relocating away from the node
+	RelocatingBytes int64 `json:"relocatingBytes,omitempty"`
+	// Estimated time the drain completes, based on the rate bytes were moved off the node so far
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+	// Time the drain was aborted after the drain timeout, it is retried after another timeout
+	AbortedAt *metav1.Time `json:"abortedAt,omitempty"`
+	// Outcome of the drain timeout
+	Message string `json:"message,omitempty"`
+}
+
+// RollingRestartStatus describes the progress of a rolling restart
+type RollingRestartStatus struct {
+	// Value of the opensearch.org/restart-at annotation when the restart was started
+	Request string `json:"request,omitempty"`
+	// Time the restart was started
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// Time the restart was completed
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// Number of pods restarted so far
+	RestartedPods int32 `json:"restartedPods,omitempty"`
+	// Number of pods that still have to be restarted
+	PendingPods int32 `json:"pendingPods,omitempty"`
+}
+
+// FullRestartStep is a step of a full cluster restart
+type FullRestartStep string
+
+const (
+	FullRestartStepDisablingAllocation FullRestartStep = "DisablingAllocation"
+	FullRestartStepFlushing            FullRestartStep = "Flushing"
+	FullRestartStepStoppingNodes       FullRestartStep = "StoppingNodes"
+	FullRestartStepStartingNodes       FullRestartStep = "StartingNodes"
+	FullRestartStepRestoringAllocation FullRestartStep = "RestoringAllocation"
+	FullRestartStepRecoveringShards    FullRestartStep = "RecoveringShards"
+	FullRestartStepCompleted           FullRestartStep = "Completed"
+	FullRestartStepRejected            FullRestartStep = "Rejected"
+)
+
+// FullRestartStatus describes the progress of a full cluster restart
+type FullRestartStatus struct {
+	// Value of the opensearch.org/full-restart-at annotation when the restart was started
+	Request string `json:"request,omitempty"`
+	// Current step of the restart, Completed once the shards recovered or Rejected if the restart was not started
+	Step FullRestartStep `json:"step,omitempty"`
+	// Time the restart was started
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// Time the restart was completed
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// True if the restart limited the shard allocation to primaries, it is restored once the nodes are back
+	AllocationDisabled bool `json:"allocationDisabled,omitempty"`
+	// Transient value of cluster.routing.allocation.enable before the restart
+	PreviousAllocation string `json:"previousAllocation,omitempty"`
+	// Steps of the restart started so far
+	Steps []FullRestartStepStatus `json:"steps,omitempty"`
+	// Progress of the current step
+	Message string `json:"message,omitempty"`
+	// Time the progress of the current step was last reported
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// FullRestartStepStatus describes a step of a full cluster restart
+type FullRestartStepStatus struct {
+	// Name of the step
+	Name FullRestartStep `json:"name"`
+	// Time the step was started
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// Time the step was completed
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// Outcome of the step
+	Message string `json:"message,omitempty"`
+}
+
+// AutoscalingStatus describes the last evaluation of the autoscaling policies of a nodepool
+type AutoscalingStatus struct {
+	// Name of the nodepool
+	Component string `json:"component"`
+	// Number of replicas the autoscaler decided the nodepool should have
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// Time the autoscaler last changed desiredReplicas
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	// ScaleUp or ScaleDown if the policies recommend to scale the nodepool
+	Recommendation string `json:"recommendation,omitempty"`
+	// Time since when the policies give the current recommendation
+	RecommendationTime *metav1.Time `json:"recommendationTime,omitempty"`
+	// Result of the last evaluation of the policies
+	Message string `json:"message,omitempty"`
+	// Total number of rejected search requests of the nodes of the nodepool at the last evaluation, unset if
+	// there is no sample yet
+	SearchRejections *int64 `json:"searchRejections,omitempty"`
+}
+
+// SecurityConfigSource describes where the value of a key of the merged securityconfig comes from
+type SecurityConfigSource struct {
+	// Name of the file, e.g. internal_users.yml
+	File string `json:"file"`
+	// Dot separated path of the key in the file
+	Key string `json:"key"`
+	// Default if the value is a default of the operator, User if it is provided by securityConfigSecret
+	Source string `json:"source"`
+}
+
+// SecurityConfigFileStatus describes the last attempt to apply a file of the securityconfig through the REST API
+type SecurityConfigFileStatus struct {
+	// Name of the file in the securityconfig secret, e.g. roles.yml
+	File string `json:"file"`
+	// Checksum of the content of the file that was applied
+	Checksum string `json:"checksum"`
+	// Applied or Failed
+	Status string `json:"status"`
+	// Error returned by OpenSearch if the file could not be applied
+	Message string `json:"message,omitempty"`
+	// Time the file was last applied
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// CertificateStatus describes a TLS certificate used by the cluster
+type CertificateStatus struct {
+	// Interface the certificate is used for, one of transport, http, admin or dashboards
+	Interface string `json:"interface"`
+	// Node the certificate is issued for, empty if the certificate is shared by all nodes
+	Node string `json:"node,omitempty"`
+	// Subject of the certificate
+	Subject string `json:"subject,omitempty"`
+	// DNS names of the certificate
+	DnsNames []string `json:"dnsNames,omitempty"`
+	// SHA-256 fingerprint of the CA certificate that issued the certificate, empty if the CA is not part of the secret
+	IssuerFingerprint string `json:"issuerFingerprint,omitempty"`
+	// Time the certificate expires
+	NotAfter metav1.Time `json:"notAfter"`
+	// Time the certificate is renewed next, empty if it is not renewed automatically
+	NextRotation *metav1.Time `json:"nextRotation,omitempty"`
+}
+
+type CaRotationPhase string
+
+const (
+	// CaRotationPhaseDistributingTrust distributes a trust bundle containing the old and the new CA
+	CaRotationPhaseDistributingTrust CaRotationPhase = "DistributingTrust"
+	// CaRotationPhaseReissuing reissues all certificates from the new CA while the old CA is still trusted
+	CaRotationPhaseReissuing CaRotationPhase = "Reissuing"
+	// CaRotationPhaseDroppingOldCa removes the old CA from the trust bundle
+	CaRotationPhaseDroppingOldCa CaRotationPhase = "DroppingOldCA"
+)
+
+type CaRotationStatus struct {
+	// Phase of the running rotation, empty if no rotation is in progress
+	Phase CaRotationPhase `json:"phase,omitempty"`
+	// Reason the running rotation was started
+	Reason string `json:"reason,omitempty"`
+	// Time the current phase was started
+	PhaseStartedAt *metav1.Time `json:"phaseStartedAt,omitempty"`
+	// Revision of the certificates the nodes have to load, increased for every phase that requires a restart
+	Revision int32 `json:"revision,omitempty"`
+	// Value of the opensearch.org/rotate-ca annotation that was last handled
+	LastRequest string `json:"lastRequest,omitempty"`
+	// Time the last rotation was completed
+	LastCompletedAt *metav1.Time `json:"lastCompletedAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
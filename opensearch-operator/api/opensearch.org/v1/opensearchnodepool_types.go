@@ -0,0 +1,57 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenSearchNodePoolSpec defines the desired state of OpenSearchNodePool
+type OpenSearchNodePoolSpec struct {
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	// Name of the nodepool in the cluster
+	Component string `json:"component"`
+	// Number of replicas of the nodepool, changed by autoscalers through the scale subresource
+	Replicas int32 `json:"replicas"`
+}
+
+// OpenSearchNodePoolStatus defines the observed state of OpenSearchNodePool
+type OpenSearchNodePoolStatus struct {
+	// Number of replicas of the StatefulSet of the nodepool
+	Replicas int32 `json:"replicas"`
+	// Number of ready pods of the nodepool
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Label selector of the pods of the nodepool
+	Selector string `json:"selector,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=opensearchnodepool
+//+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="desired",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="replicas",type="integer",JSONPath=".status.replicas"
+// +kubebuilder:printcolumn:name="ready",type="integer",JSONPath=".status.readyReplicas"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpenSearchNodePool is a scale target for a nodepool of an OpenSearchCluster. It is created by the operator for
+// nodepools with scaleTarget enabled, so that autoscalers like the HorizontalPodAutoscaler can scale a single nodepool.
+type OpenSearchNodePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchNodePoolSpec   `json:"spec,omitempty"`
+	Status OpenSearchNodePoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenSearchNodePoolList contains a list of OpenSearchNodePool
+type OpenSearchNodePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchNodePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchNodePool{}, &OpenSearchNodePoolList{})
+}
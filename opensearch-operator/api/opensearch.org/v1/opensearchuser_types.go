@@ -30,6 +30,16 @@ const (
 	OpensearchUserStateError   OpensearchUserState = "ERROR"
 )
 
+// +kubebuilder:validation:Enum=Lowercase;Uppercase;Digits;Symbols
+type PasswordCharacterClass string
+
+const (
+	PasswordCharacterClassLowercase PasswordCharacterClass = "Lowercase"
+	PasswordCharacterClassUppercase PasswordCharacterClass = "Uppercase"
+	PasswordCharacterClassDigits    PasswordCharacterClass = "Digits"
+	PasswordCharacterClassSymbols   PasswordCharacterClass = "Symbols"
+)
+
 // OpensearchUserSpec defines the desired state of OpensearchUser
 type OpensearchUserSpec struct {
 	OpensearchRef           corev1.LocalObjectReference `json:"opensearchCluster"`
@@ -37,6 +47,29 @@ type OpensearchUserSpec struct {
 	OpendistroSecurityRoles []string                    `json:"opendistroSecurityRoles,omitempty"`
 	BackendRoles            []string                    `json:"backendRoles,omitempty"`
 	Attributes              map[string]string           `json:"attributes,omitempty"`
+	// Generate the password and store it in the secret of passwordFrom, which is created if it does not exist
+	PasswordGeneration *PasswordGenerationSpec `json:"passwordGeneration,omitempty"`
+	// Write a secret with the connection details of the user, kept in sync when the password or the CA changes
+	ConnectionSecret *UserConnectionSecretSpec `json:"connectionSecret,omitempty"`
+}
+
+type PasswordGenerationSpec struct {
+	// Length of the generated password, at least 12
+	//+kubebuilder:default=32
+	Length int `json:"length,omitempty"`
+	// Character classes the password is generated from, each class is used at least once. Defaults to all classes
+	CharacterClasses []PasswordCharacterClass `json:"characterClasses,omitempty"`
+	// Rotate the password after this interval, e.g. 720h. Without an interval the password is only rotated
+	// when requested with the opensearch.org/rotate-password annotation
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+}
+
+type UserConnectionSecretSpec struct {
+	// Name of the secret, defaults to <name of the user>-connection
+	Name string `json:"name,omitempty"`
+	// Namespace of the secret, defaults to the namespace of the user. Other namespaces are only allowed if enabled for
+	// the operator, which then needs permissions to manage secrets in the namespace
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // OpensearchUserStatus defines the observed state of OpensearchUser
@@ -44,6 +77,17 @@ type OpensearchUserStatus struct {
 	State          OpensearchUserState `json:"state,omitempty"`
 	Reason         string              `json:"reason,omitempty"`
 	ManagedCluster *types.UID          `json:"managedCluster,omitempty"`
+	// Rotation of the generated password
+	Password *UserPasswordStatus `json:"password,omitempty"`
+	// Connection secret written by the operator as namespace/name
+	ConnectionSecret string `json:"connectionSecret,omitempty"`
+}
+
+type UserPasswordStatus struct {
+	// Time the password was last generated
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// Value of the opensearch.org/rotate-password annotation that was last handled
+	LastRequest string `json:"lastRequest,omitempty"`
 }
 
 //+kubebuilder:object:root=true
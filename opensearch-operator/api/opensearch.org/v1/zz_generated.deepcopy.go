@@ -22,6 +22,7 @@ package v1
 
 import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -130,6 +131,45 @@ func (in *Action) DeepCopy() *Action {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalServiceConfig) DeepCopyInto(out *AdditionalServiceConfig) {
+	*out = *in
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalServiceConfig.
+func (in *AdditionalServiceConfig) DeepCopy() *AdditionalServiceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalServiceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdditionalVolume) DeepCopyInto(out *AdditionalVolume) {
 	*out = *in
@@ -282,6 +322,295 @@ func (in *Allocation) DeepCopy() *Allocation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditComplianceConfig) DeepCopyInto(out *AuditComplianceConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadWatchedFields != nil {
+		in, out := &in.ReadWatchedFields, &out.ReadWatchedFields
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ReadMetadataOnly != nil {
+		in, out := &in.ReadMetadataOnly, &out.ReadMetadataOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadIgnoreUsers != nil {
+		in, out := &in.ReadIgnoreUsers, &out.ReadIgnoreUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WriteWatchedIndices != nil {
+		in, out := &in.WriteWatchedIndices, &out.WriteWatchedIndices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WriteMetadataOnly != nil {
+		in, out := &in.WriteMetadataOnly, &out.WriteMetadataOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WriteIgnoreUsers != nil {
+		in, out := &in.WriteIgnoreUsers, &out.WriteIgnoreUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InternalConfig != nil {
+		in, out := &in.InternalConfig, &out.InternalConfig
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditComplianceConfig.
+func (in *AuditComplianceConfig) DeepCopy() *AuditComplianceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditComplianceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditConfig) DeepCopyInto(out *AuditConfig) {
+	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(AuditExternalConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AuditWebhookConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Log4j != nil {
+		in, out := &in.Log4j, &out.Log4j
+		*out = new(AuditLog4jConfig)
+		**out = **in
+	}
+	if in.EnableRest != nil {
+		in, out := &in.EnableRest, &out.EnableRest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableTransport != nil {
+		in, out := &in.EnableTransport, &out.EnableTransport
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludeCategories != nil {
+		in, out := &in.IncludeCategories, &out.IncludeCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeCategories != nil {
+		in, out := &in.ExcludeCategories, &out.ExcludeCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreUsers != nil {
+		in, out := &in.IgnoreUsers, &out.IgnoreUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreRequests != nil {
+		in, out := &in.IgnoreRequests, &out.IgnoreRequests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Compliance != nil {
+		in, out := &in.Compliance, &out.Compliance
+		*out = new(AuditComplianceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditConfig.
+func (in *AuditConfig) DeepCopy() *AuditConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditExternalConfig) DeepCopyInto(out *AuditExternalConfig) {
+	*out = *in
+	if in.HttpEndpoints != nil {
+		in, out := &in.HttpEndpoints, &out.HttpEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerifyHostnames != nil {
+		in, out := &in.VerifyHostnames, &out.VerifyHostnames
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditExternalConfig.
+func (in *AuditExternalConfig) DeepCopy() *AuditExternalConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditExternalConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLog4jConfig) DeepCopyInto(out *AuditLog4jConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLog4jConfig.
+func (in *AuditLog4jConfig) DeepCopy() *AuditLog4jConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLog4jConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditWebhookConfig) DeepCopyInto(out *AuditWebhookConfig) {
+	*out = *in
+	if in.SslVerify != nil {
+		in, out := &in.SslVerify, &out.SslVerify
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditWebhookConfig.
+func (in *AuditWebhookConfig) DeepCopy() *AuditWebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditWebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationConfig) DeepCopyInto(out *AuthenticationConfig) {
+	*out = *in
+	if in.OpenID != nil {
+		in, out := &in.OpenID, &out.OpenID
+		*out = new(OpenIDAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Saml != nil {
+		in, out := &in.Saml, &out.Saml
+		*out = new(SamlAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ldap != nil {
+		in, out := &in.Ldap, &out.Ldap
+		*out = new(LdapAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jwt != nil {
+		in, out := &in.Jwt, &out.Jwt
+		*out = new(JwtAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyAuthConfig)
+		**out = **in
+	}
+	if in.ClientCert != nil {
+		in, out := &in.ClientCert, &out.ClientCert
+		*out = new(ClientCertAuthConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationConfig.
+func (in *AuthenticationConfig) DeepCopy() *AuthenticationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicy) DeepCopyInto(out *AutoscalingPolicy) {
+	*out = *in
+	if in.ScaleDownThreshold != nil {
+		in, out := &in.ScaleDownThreshold, &out.ScaleDownThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicy.
+func (in *AutoscalingPolicy) DeepCopy() *AutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingStatus) DeepCopyInto(out *AutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RecommendationTime != nil {
+		in, out := &in.RecommendationTime, &out.RecommendationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SearchRejections != nil {
+		in, out := &in.SearchRejections, &out.SearchRejections
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingStatus.
+func (in *AutoscalingStatus) DeepCopy() *AutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BootstrapConfig) DeepCopyInto(out *BootstrapConfig) {
 	*out = *in
@@ -370,6 +699,105 @@ func (in *BootstrapConfig) DeepCopy() *BootstrapConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaRotationStatus) DeepCopyInto(out *CaRotationStatus) {
+	*out = *in
+	if in.PhaseStartedAt != nil {
+		in, out := &in.PhaseStartedAt, &out.PhaseStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastCompletedAt != nil {
+		in, out := &in.LastCompletedAt, &out.LastCompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaRotationStatus.
+func (in *CaRotationStatus) DeepCopy() *CaRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CaRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerConfig) DeepCopyInto(out *CertManagerConfig) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerConfig.
+func (in *CertManagerConfig) DeepCopy() *CertManagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerRef) DeepCopyInto(out *CertManagerIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerRef.
+func (in *CertManagerIssuerRef) DeepCopy() *CertManagerIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	if in.DnsNames != nil {
+		in, out := &in.DnsNames, &out.DnsNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	if in.NextRotation != nil {
+		in, out := &in.NextRotation, &out.NextRotation
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertAuthConfig) DeepCopyInto(out *ClientCertAuthConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertAuthConfig.
+func (in *ClientCertAuthConfig) DeepCopy() *ClientCertAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Close) DeepCopyInto(out *Close) {
 	*out = *in
@@ -389,7 +817,7 @@ func (in *Close) DeepCopy() *Close {
 func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
 	in.General.DeepCopyInto(&out.General)
-	out.ConfMgmt = in.ConfMgmt
+	in.ConfMgmt.DeepCopyInto(&out.ConfMgmt)
 	in.Bootstrap.DeepCopyInto(&out.Bootstrap)
 	in.Dashboards.DeepCopyInto(&out.Dashboards)
 	if in.Security != nil {
@@ -405,6 +833,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		}
 	}
 	in.InitHelper.DeepCopyInto(&out.InitHelper)
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -427,6 +860,66 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CaRotation != nil {
+		in, out := &in.CaRotation, &out.CaRotation
+		*out = new(CaRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityConfigFiles != nil {
+		in, out := &in.SecurityConfigFiles, &out.SecurityConfigFiles
+		*out = make([]SecurityConfigFileStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityConfigSources != nil {
+		in, out := &in.SecurityConfigSources, &out.SecurityConfigSources
+		*out = make([]SecurityConfigSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = make([]AutoscalingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RollingRestart != nil {
+		in, out := &in.RollingRestart, &out.RollingRestart
+		*out = new(RollingRestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FullRestart != nil {
+		in, out := &in.FullRestart, &out.FullRestart
+		*out = new(FullRestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drains != nil {
+		in, out := &in.Drains, &out.Drains
+		*out = make([]DrainStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -522,6 +1015,11 @@ func (in *Condition) DeepCopy() *Condition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfMgmt) DeepCopyInto(out *ConfMgmt) {
 	*out = *in
+	if in.OrphanedPVCCleanup != nil {
+		in, out := &in.OrphanedPVCCleanup, &out.OrphanedPVCCleanup
+		*out = new(OrphanedPVCCleanupConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfMgmt.
@@ -699,6 +1197,11 @@ func (in *DashboardsConfig) DeepCopyInto(out *DashboardsConfig) {
 		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardsConfig.
@@ -819,6 +1322,57 @@ func (in *DestinationURL) DeepCopy() *DestinationURL {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainConfig) DeepCopyInto(out *DrainConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainConfig.
+func (in *DrainConfig) DeepCopy() *DrainConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainStatus) DeepCopyInto(out *DrainStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AbortedAt != nil {
+		in, out := &in.AbortedAt, &out.AbortedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainStatus.
+func (in *DrainStatus) DeepCopy() *DrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ErrorNotification) DeepCopyInto(out *ErrorNotification) {
 	*out = *in
@@ -859,6 +1413,78 @@ func (in *ForceMerge) DeepCopy() *ForceMerge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FullRestartStatus) DeepCopyInto(out *FullRestartStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]FullRestartStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullRestartStatus.
+func (in *FullRestartStatus) DeepCopy() *FullRestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FullRestartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FullRestartStepStatus) DeepCopyInto(out *FullRestartStepStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullRestartStepStatus.
+func (in *FullRestartStepStatus) DeepCopy() *FullRestartStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FullRestartStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentRef) DeepCopyInto(out *GatewayParentRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentRef.
+func (in *GatewayParentRef) DeepCopy() *GatewayParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeneralConfig) DeepCopyInto(out *GeneralConfig) {
 	*out = *in
@@ -945,6 +1571,28 @@ func (in *GeneralConfig) DeepCopyInto(out *GeneralConfig) {
 		*out = new(GrpcConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RollingRestart != nil {
+		in, out := &in.RollingRestart, &out.RollingRestart
+		*out = new(RollingRestartConfig)
+		**out = **in
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneralConfig.
@@ -1107,6 +1755,55 @@ func (in *IndexPriority) DeepCopy() *IndexPriority {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TlsSecret != nil {
+		in, out := &in.TlsSecret, &out.TlsSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConfig.
+func (in *IngressConfig) DeepCopy() *IngressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InitHelperConfig) DeepCopyInto(out *InitHelperConfig) {
 	*out = *in
@@ -1133,6 +1830,22 @@ func (in *InitHelperConfig) DeepCopy() *InitHelperConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JwtAuthConfig) DeepCopyInto(out *JwtAuthConfig) {
+	*out = *in
+	in.SigningKey.DeepCopyInto(&out.SigningKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JwtAuthConfig.
+func (in *JwtAuthConfig) DeepCopy() *JwtAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JwtAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeystoreValue) DeepCopyInto(out *KeystoreValue) {
 	*out = *in
@@ -1146,12 +1859,82 @@ func (in *KeystoreValue) DeepCopyInto(out *KeystoreValue) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeystoreValue.
-func (in *KeystoreValue) DeepCopy() *KeystoreValue {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeystoreValue.
+func (in *KeystoreValue) DeepCopy() *KeystoreValue {
+	if in == nil {
+		return nil
+	}
+	out := new(KeystoreValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapAuthConfig) DeepCopyInto(out *LdapAuthConfig) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerifyHostnames != nil {
+		in, out := &in.VerifyHostnames, &out.VerifyHostnames
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BindPassword != nil {
+		in, out := &in.BindPassword, &out.BindPassword
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapAuthConfig.
+func (in *LdapAuthConfig) DeepCopy() *LdapAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Cron = in.Cron
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowStatus) DeepCopyInto(out *MaintenanceWindowStatus) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowStatus.
+func (in *MaintenanceWindowStatus) DeepCopy() *MaintenanceWindowStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeystoreValue)
+	out := new(MaintenanceWindowStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1320,6 +2103,26 @@ func (in *NodePool) DeepCopyInto(out *NodePool) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.ClientService != nil {
+		in, out := &in.ClientService, &out.ClientService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(NodePoolAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartMaxUnavailable != nil {
+		in, out := &in.RestartMaxUnavailable, &out.RestartMaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
@@ -1332,6 +2135,43 @@ func (in *NodePool) DeepCopy() *NodePool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolAutoscaling) DeepCopyInto(out *NodePoolAutoscaling) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]AutoscalingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScaleUpStabilizationWindow != nil {
+		in, out := &in.ScaleUpStabilizationWindow, &out.ScaleUpStabilizationWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownStabilizationWindow != nil {
+		in, out := &in.ScaleDownStabilizationWindow, &out.ScaleDownStabilizationWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolAutoscaling.
+func (in *NodePoolAutoscaling) DeepCopy() *NodePoolAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Notification) DeepCopyInto(out *Notification) {
 	*out = *in
@@ -1408,6 +2248,26 @@ func (in *Open) DeepCopy() *Open {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenIDAuthConfig) DeepCopyInto(out *OpenIDAuthConfig) {
+	*out = *in
+	if in.ClientSecret != nil {
+		in, out := &in.ClientSecret, &out.ClientSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenIDAuthConfig.
+func (in *OpenIDAuthConfig) DeepCopy() *OpenIDAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenIDAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenSearchCluster) DeepCopyInto(out *OpenSearchCluster) {
 	*out = *in
@@ -1564,6 +2424,96 @@ func (in *OpenSearchISMPolicySpec) DeepCopy() *OpenSearchISMPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNodePool) DeepCopyInto(out *OpenSearchNodePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNodePool.
+func (in *OpenSearchNodePool) DeepCopy() *OpenSearchNodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchNodePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNodePoolList) DeepCopyInto(out *OpenSearchNodePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchNodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNodePoolList.
+func (in *OpenSearchNodePoolList) DeepCopy() *OpenSearchNodePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNodePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchNodePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNodePoolSpec) DeepCopyInto(out *OpenSearchNodePoolSpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNodePoolSpec.
+func (in *OpenSearchNodePoolSpec) DeepCopy() *OpenSearchNodePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNodePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNodePoolStatus) DeepCopyInto(out *OpenSearchNodePoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNodePoolStatus.
+func (in *OpenSearchNodePoolStatus) DeepCopy() *OpenSearchNodePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNodePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpensearchActionGroup) DeepCopyInto(out *OpensearchActionGroup) {
 	*out = *in
@@ -2566,6 +3516,16 @@ func (in *OpensearchUserSpec) DeepCopyInto(out *OpensearchUserSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PasswordGeneration != nil {
+		in, out := &in.PasswordGeneration, &out.PasswordGeneration
+		*out = new(PasswordGenerationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionSecret != nil {
+		in, out := &in.ConnectionSecret, &out.ConnectionSecret
+		*out = new(UserConnectionSecretSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpensearchUserSpec.
@@ -2586,6 +3546,11 @@ func (in *OpensearchUserStatus) DeepCopyInto(out *OpensearchUserStatus) {
 		*out = new(types.UID)
 		**out = **in
 	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(UserPasswordStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpensearchUserStatus.
@@ -2598,6 +3563,26 @@ func (in *OpensearchUserStatus) DeepCopy() *OpensearchUserStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedPVCCleanupConfig) DeepCopyInto(out *OrphanedPVCCleanupConfig) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedPVCCleanupConfig.
+func (in *OrphanedPVCCleanupConfig) DeepCopy() *OrphanedPVCCleanupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedPVCCleanupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PVCSource) DeepCopyInto(out *PVCSource) {
 	*out = *in
@@ -2637,6 +3622,31 @@ func (in *PVCSource) DeepCopy() *PVCSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordGenerationSpec) DeepCopyInto(out *PasswordGenerationSpec) {
+	*out = *in
+	if in.CharacterClasses != nil {
+		in, out := &in.CharacterClasses, &out.CharacterClasses
+		*out = make([]PasswordCharacterClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordGenerationSpec.
+func (in *PasswordGenerationSpec) DeepCopy() *PasswordGenerationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordGenerationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PdbConfig) DeepCopyInto(out *PdbConfig) {
 	*out = *in
@@ -2753,6 +3763,21 @@ func (in *ProbesConfig) DeepCopy() *ProbesConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyAuthConfig) DeepCopyInto(out *ProxyAuthConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyAuthConfig.
+func (in *ProxyAuthConfig) DeepCopy() *ProxyAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadOnly) DeepCopyInto(out *ReadOnly) {
 	*out = *in
@@ -2813,6 +3838,44 @@ func (in *Retry) DeepCopy() *Retry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingRestartConfig) DeepCopyInto(out *RollingRestartConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingRestartConfig.
+func (in *RollingRestartConfig) DeepCopy() *RollingRestartConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingRestartConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingRestartStatus) DeepCopyInto(out *RollingRestartStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingRestartStatus.
+func (in *RollingRestartStatus) DeepCopy() *RollingRestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingRestartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollover) DeepCopyInto(out *Rollover) {
 	*out = *in
@@ -2863,6 +3926,22 @@ func (in *Rollup) DeepCopy() *Rollup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SamlAuthConfig) DeepCopyInto(out *SamlAuthConfig) {
+	*out = *in
+	in.ExchangeKey.DeepCopyInto(&out.ExchangeKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamlAuthConfig.
+func (in *SamlAuthConfig) DeepCopy() *SamlAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SamlAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Security) DeepCopyInto(out *Security) {
 	*out = *in
@@ -2876,6 +3955,16 @@ func (in *Security) DeepCopyInto(out *Security) {
 		*out = new(SecurityConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(AuthenticationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(AuditConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Security.
@@ -2908,6 +3997,37 @@ func (in *SecurityConfig) DeepCopy() *SecurityConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityConfigFileStatus) DeepCopyInto(out *SecurityConfigFileStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityConfigFileStatus.
+func (in *SecurityConfigFileStatus) DeepCopy() *SecurityConfigFileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityConfigFileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityConfigSource) DeepCopyInto(out *SecurityConfigSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityConfigSource.
+func (in *SecurityConfigSource) DeepCopy() *SecurityConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityUpdateJobConfig) DeepCopyInto(out *SecurityUpdateJobConfig) {
 	*out = *in
@@ -3230,6 +4350,16 @@ func (in *TlsCertificateConfig) DeepCopyInto(out *TlsCertificateConfig) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.CaDuration != nil {
+		in, out := &in.CaDuration, &out.CaDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TlsCertificateConfig.
@@ -3364,3 +4494,37 @@ func (in *Transition) DeepCopy() *Transition {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserConnectionSecretSpec) DeepCopyInto(out *UserConnectionSecretSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserConnectionSecretSpec.
+func (in *UserConnectionSecretSpec) DeepCopy() *UserConnectionSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UserConnectionSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserPasswordStatus) DeepCopyInto(out *UserPasswordStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserPasswordStatus.
+func (in *UserPasswordStatus) DeepCopy() *UserPasswordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserPasswordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
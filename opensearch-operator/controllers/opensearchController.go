@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -25,6 +26,8 @@ import (
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
@@ -54,6 +57,8 @@ type OpenSearchClusterReconciler struct {
 //+kubebuilder:rbac:groups=opensearch.org,resources=opensearchclusters,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=opensearch.org,resources=opensearchclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=opensearch.org,resources=opensearchclusters/finalizers,verbs=update
+//+kubebuilder:rbac:groups=opensearch.org,resources=opensearchnodepools,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=opensearch.org,resources=opensearchnodepools/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=opensearch.opster.io,resources=opensearchclusters,verbs=get;list;watch
 //+kubebuilder:rbac:groups=opensearch.opster.io,resources=opensearchclusters/status,verbs=get
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
@@ -68,6 +73,9 @@ type OpenSearchClusterReconciler struct {
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -142,6 +150,14 @@ func (r *OpenSearchClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
+	// While the cluster is paused only its status and metrics are updated
+	if helpers.ClusterPaused(r.Instance) {
+		return r.reconcilePaused(ctx)
+	}
+	if err := r.setPausedCondition(ctx, false); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	/// if crd not deleted started phase 1
 	if r.Instance.Status.Phase == "" {
 		r.Instance.Status.Phase = opensearchv1.PhasePending
@@ -169,6 +185,7 @@ func (r *OpenSearchClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&opensearchv1.OpenSearchNodePool{}).
 		Complete(r)
 }
 
@@ -234,6 +251,61 @@ func (r *OpenSearchClusterReconciler) deleteExternalResources(ctx context.Contex
 	return ctrl.Result{}, nil
 }
 
+// reconcilePaused only updates the health and metrics of a paused cluster
+func (r *OpenSearchClusterReconciler) reconcilePaused(ctx context.Context) (ctrl.Result, error) {
+	r.Info("Reconciliation is paused")
+	if err := r.setPausedCondition(ctx, true); err != nil {
+		return ctrl.Result{}, err
+	}
+	if r.Instance.Status.Initialized {
+		reconcilerContext := reconcilers.NewReconcilerContext(r.Recorder, r.Instance, r.Instance.Spec.NodePools)
+		cluster := reconcilers.NewClusterReconciler(
+			r.Client,
+			ctx,
+			r.Recorder,
+			&reconcilerContext,
+			r.Instance,
+		)
+		if err := cluster.UpdateClusterStatus(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+}
+
+// setPausedCondition sets the Paused condition of the cluster. The condition is only added once a cluster is paused.
+func (r *OpenSearchClusterReconciler) setPausedCondition(ctx context.Context, paused bool) error {
+	condition := metav1.Condition{
+		Type:    opensearchv1.ConditionPaused,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Reconciling",
+		Message: "The operator reconciles the cluster",
+	}
+	if paused {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Paused"
+		condition.Message = fmt.Sprintf("Reconciliation is paused with spec.paused or the %s annotation", helpers.PausedAnnotation)
+	}
+	current := meta.FindStatusCondition(r.Instance.Status.Conditions, opensearchv1.ConditionPaused)
+	if (current == nil && !paused) || (current != nil && current.Status == condition.Status) {
+		return nil
+	}
+
+	annotations := map[string]string{"cluster-name": r.Instance.GetName()}
+	if paused {
+		r.Recorder.AnnotatedEventf(r.Instance, annotations, "Normal", "Paused", "Reconciliation paused")
+	} else {
+		r.Recorder.AnnotatedEventf(r.Instance, annotations, "Normal", "Paused", "Reconciliation resumed")
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(r.Instance), r.Instance); err != nil {
+			return err
+		}
+		meta.SetStatusCondition(&r.Instance.Status.Conditions, condition)
+		return r.Status().Update(ctx, r.Instance)
+	})
+}
+
 func (r *OpenSearchClusterReconciler) reconcilePhasePending(ctx context.Context) (ctrl.Result, error) {
 	r.Info("Start reconcile - Phase: PENDING")
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -294,6 +366,12 @@ func (r *OpenSearchClusterReconciler) reconcilePhaseRunning(ctx context.Context)
 		&reconcilerContext,
 		r.Instance,
 	)
+	fullRestart := reconcilers.NewFullRestartReconciler(
+		r.Client,
+		ctx,
+		r.Recorder,
+		r.Instance,
+	)
 	scaler := reconcilers.NewScalerReconciler(
 		r.Client,
 		ctx,
@@ -334,6 +412,7 @@ func (r *OpenSearchClusterReconciler) reconcilePhaseRunning(ctx context.Context)
 		{Name: securityconfig.Name(), Func: securityconfig.Reconcile},
 		{Name: config.Name(), Func: config.Reconcile},
 		{Name: cluster.Name(), Func: cluster.Reconcile},
+		{Name: fullRestart.Name(), Func: fullRestart.Reconcile},
 		{Name: scaler.Name(), Func: scaler.Reconcile},
 		{Name: dashboards.Name(), Func: dashboards.Reconcile},
 		{Name: upgrade.Name(), Func: upgrade.Reconcile},
@@ -20,8 +20,12 @@ import (
 
 	runtime "k8s.io/apimachinery/pkg/runtime"
 
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+
 	types "k8s.io/apimachinery/pkg/types"
 
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -468,6 +472,52 @@ func (_c *MockK8sClient_DeleteJob_Call) RunAndReturn(run func(*batchv1.Job) erro
 	return _c
 }
 
+// DeletePVC provides a mock function with given fields: pvc
+func (_m *MockK8sClient) DeletePVC(pvc *v1.PersistentVolumeClaim) error {
+	ret := _m.Called(pvc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePVC")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*v1.PersistentVolumeClaim) error); ok {
+		r0 = rf(pvc)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockK8sClient_DeletePVC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePVC'
+type MockK8sClient_DeletePVC_Call struct {
+	*mock.Call
+}
+
+// DeletePVC is a helper method to define mock.On call
+//   - pvc *v1.PersistentVolumeClaim
+func (_e *MockK8sClient_Expecter) DeletePVC(pvc interface{}) *MockK8sClient_DeletePVC_Call {
+	return &MockK8sClient_DeletePVC_Call{Call: _e.mock.On("DeletePVC", pvc)}
+}
+
+func (_c *MockK8sClient_DeletePVC_Call) Run(run func(pvc *v1.PersistentVolumeClaim)) *MockK8sClient_DeletePVC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*v1.PersistentVolumeClaim))
+	})
+	return _c
+}
+
+func (_c *MockK8sClient_DeletePVC_Call) Return(_a0 error) *MockK8sClient_DeletePVC_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockK8sClient_DeletePVC_Call) RunAndReturn(run func(*v1.PersistentVolumeClaim) error) *MockK8sClient_DeletePVC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeletePod provides a mock function with given fields: pod
 func (_m *MockK8sClient) DeletePod(pod *v1.Pod) error {
 	ret := _m.Called(pod)
@@ -789,6 +839,63 @@ func (_c *MockK8sClient_GetOpenSearchCluster_Call) RunAndReturn(run func(string,
 	return _c
 }
 
+// GetOpenSearchNodePool provides a mock function with given fields: name, namespace
+func (_m *MockK8sClient) GetOpenSearchNodePool(name string, namespace string) (opensearch_orgv1.OpenSearchNodePool, error) {
+	ret := _m.Called(name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenSearchNodePool")
+	}
+
+	var r0 opensearch_orgv1.OpenSearchNodePool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (opensearch_orgv1.OpenSearchNodePool, error)); ok {
+		return rf(name, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) opensearch_orgv1.OpenSearchNodePool); ok {
+		r0 = rf(name, namespace)
+	} else {
+		r0 = ret.Get(0).(opensearch_orgv1.OpenSearchNodePool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockK8sClient_GetOpenSearchNodePool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenSearchNodePool'
+type MockK8sClient_GetOpenSearchNodePool_Call struct {
+	*mock.Call
+}
+
+// GetOpenSearchNodePool is a helper method to define mock.On call
+//   - name string
+//   - namespace string
+func (_e *MockK8sClient_Expecter) GetOpenSearchNodePool(name interface{}, namespace interface{}) *MockK8sClient_GetOpenSearchNodePool_Call {
+	return &MockK8sClient_GetOpenSearchNodePool_Call{Call: _e.mock.On("GetOpenSearchNodePool", name, namespace)}
+}
+
+func (_c *MockK8sClient_GetOpenSearchNodePool_Call) Run(run func(name string, namespace string)) *MockK8sClient_GetOpenSearchNodePool_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockK8sClient_GetOpenSearchNodePool_Call) Return(_a0 opensearch_orgv1.OpenSearchNodePool, _a1 error) *MockK8sClient_GetOpenSearchNodePool_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockK8sClient_GetOpenSearchNodePool_Call) RunAndReturn(run func(string, string) (opensearch_orgv1.OpenSearchNodePool, error)) *MockK8sClient_GetOpenSearchNodePool_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPVC provides a mock function with given fields: name, namespace
 func (_m *MockK8sClient) GetPVC(name string, namespace string) (v1.PersistentVolumeClaim, error) {
 	ret := _m.Called(name, namespace)
@@ -1074,6 +1181,135 @@ func (_c *MockK8sClient_GetStatefulSet_Call) RunAndReturn(run func(string, strin
 	return _c
 }
 
+// GetUnstructured provides a mock function with given fields: gvk, name, namespace
+func (_m *MockK8sClient) GetUnstructured(gvk schema.GroupVersionKind, name string, namespace string) (*unstructured.Unstructured, error) {
+	ret := _m.Called(gvk, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnstructured")
+	}
+
+	var r0 *unstructured.Unstructured
+	var r1 error
+	if rf, ok := ret.Get(0).(func(schema.GroupVersionKind, string, string) (*unstructured.Unstructured, error)); ok {
+		return rf(gvk, name, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(schema.GroupVersionKind, string, string) *unstructured.Unstructured); ok {
+		r0 = rf(gvk, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*unstructured.Unstructured)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(schema.GroupVersionKind, string, string) error); ok {
+		r1 = rf(gvk, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockK8sClient_GetUnstructured_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnstructured'
+type MockK8sClient_GetUnstructured_Call struct {
+	*mock.Call
+}
+
+// GetUnstructured is a helper method to define mock.On call
+//   - gvk schema.GroupVersionKind
+//   - name string
+//   - namespace string
+func (_e *MockK8sClient_Expecter) GetUnstructured(gvk interface{}, name interface{}, namespace interface{}) *MockK8sClient_GetUnstructured_Call {
+	return &MockK8sClient_GetUnstructured_Call{Call: _e.mock.On("GetUnstructured", gvk, name, namespace)}
+}
+
+func (_c *MockK8sClient_GetUnstructured_Call) Run(run func(gvk schema.GroupVersionKind, name string, namespace string)) *MockK8sClient_GetUnstructured_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(schema.GroupVersionKind), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockK8sClient_GetUnstructured_Call) Return(_a0 *unstructured.Unstructured, _a1 error) *MockK8sClient_GetUnstructured_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockK8sClient_GetUnstructured_Call) RunAndReturn(run func(schema.GroupVersionKind, string, string) (*unstructured.Unstructured, error)) *MockK8sClient_GetUnstructured_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOpenSearchNodePools provides a mock function with given fields: listOptions
+func (_m *MockK8sClient) ListOpenSearchNodePools(listOptions ...client.ListOption) (opensearch_orgv1.OpenSearchNodePoolList, error) {
+	_va := make([]interface{}, len(listOptions))
+	for _i := range listOptions {
+		_va[_i] = listOptions[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOpenSearchNodePools")
+	}
+
+	var r0 opensearch_orgv1.OpenSearchNodePoolList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(...client.ListOption) (opensearch_orgv1.OpenSearchNodePoolList, error)); ok {
+		return rf(listOptions...)
+	}
+	if rf, ok := ret.Get(0).(func(...client.ListOption) opensearch_orgv1.OpenSearchNodePoolList); ok {
+		r0 = rf(listOptions...)
+	} else {
+		r0 = ret.Get(0).(opensearch_orgv1.OpenSearchNodePoolList)
+	}
+
+	if rf, ok := ret.Get(1).(func(...client.ListOption) error); ok {
+		r1 = rf(listOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockK8sClient_ListOpenSearchNodePools_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOpenSearchNodePools'
+type MockK8sClient_ListOpenSearchNodePools_Call struct {
+	*mock.Call
+}
+
+// ListOpenSearchNodePools is a helper method to define mock.On call
+//   - listOptions ...client.ListOption
+func (_e *MockK8sClient_Expecter) ListOpenSearchNodePools(listOptions ...interface{}) *MockK8sClient_ListOpenSearchNodePools_Call {
+	return &MockK8sClient_ListOpenSearchNodePools_Call{Call: _e.mock.On("ListOpenSearchNodePools",
+		append([]interface{}{}, listOptions...)...)}
+}
+
+func (_c *MockK8sClient_ListOpenSearchNodePools_Call) Run(run func(listOptions ...client.ListOption)) *MockK8sClient_ListOpenSearchNodePools_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]client.ListOption, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(client.ListOption)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockK8sClient_ListOpenSearchNodePools_Call) Return(_a0 opensearch_orgv1.OpenSearchNodePoolList, _a1 error) *MockK8sClient_ListOpenSearchNodePools_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockK8sClient_ListOpenSearchNodePools_Call) RunAndReturn(run func(...client.ListOption) (opensearch_orgv1.OpenSearchNodePoolList, error)) *MockK8sClient_ListOpenSearchNodePools_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListPVCs provides a mock function with given fields: listOptions
 func (_m *MockK8sClient) ListPVCs(listOptions *client.ListOptions) (v1.PersistentVolumeClaimList, error) {
 	ret := _m.Called(listOptions)
@@ -1186,6 +1422,75 @@ func (_c *MockK8sClient_ListPods_Call) RunAndReturn(run func(*client.ListOptions
 	return _c
 }
 
+// ListServices provides a mock function with given fields: listOptions
+func (_m *MockK8sClient) ListServices(listOptions ...client.ListOption) (v1.ServiceList, error) {
+	_va := make([]interface{}, len(listOptions))
+	for _i := range listOptions {
+		_va[_i] = listOptions[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServices")
+	}
+
+	var r0 v1.ServiceList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(...client.ListOption) (v1.ServiceList, error)); ok {
+		return rf(listOptions...)
+	}
+	if rf, ok := ret.Get(0).(func(...client.ListOption) v1.ServiceList); ok {
+		r0 = rf(listOptions...)
+	} else {
+		r0 = ret.Get(0).(v1.ServiceList)
+	}
+
+	if rf, ok := ret.Get(1).(func(...client.ListOption) error); ok {
+		r1 = rf(listOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockK8sClient_ListServices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServices'
+type MockK8sClient_ListServices_Call struct {
+	*mock.Call
+}
+
+// ListServices is a helper method to define mock.On call
+//   - listOptions ...client.ListOption
+func (_e *MockK8sClient_Expecter) ListServices(listOptions ...interface{}) *MockK8sClient_ListServices_Call {
+	return &MockK8sClient_ListServices_Call{Call: _e.mock.On("ListServices",
+		append([]interface{}{}, listOptions...)...)}
+}
+
+func (_c *MockK8sClient_ListServices_Call) Run(run func(listOptions ...client.ListOption)) *MockK8sClient_ListServices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]client.ListOption, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(client.ListOption)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockK8sClient_ListServices_Call) Return(_a0 v1.ServiceList, _a1 error) *MockK8sClient_ListServices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockK8sClient_ListServices_Call) RunAndReturn(run func(...client.ListOption) (v1.ServiceList, error)) *MockK8sClient_ListServices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListStatefulSets provides a mock function with given fields: listOptions
 func (_m *MockK8sClient) ListStatefulSets(listOptions ...client.ListOption) (appsv1.StatefulSetList, error) {
 	_va := make([]interface{}, len(listOptions))
@@ -1314,6 +1619,53 @@ func (_c *MockK8sClient_ReconcileResource_Call) RunAndReturn(run func(runtime.Ob
 	return _c
 }
 
+// RemovePodLabels provides a mock function with given fields: pod, keys
+func (_m *MockK8sClient) RemovePodLabels(pod *v1.Pod, keys []string) error {
+	ret := _m.Called(pod, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePodLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*v1.Pod, []string) error); ok {
+		r0 = rf(pod, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockK8sClient_RemovePodLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePodLabels'
+type MockK8sClient_RemovePodLabels_Call struct {
+	*mock.Call
+}
+
+// RemovePodLabels is a helper method to define mock.On call
+//   - pod *v1.Pod
+//   - keys []string
+func (_e *MockK8sClient_Expecter) RemovePodLabels(pod interface{}, keys interface{}) *MockK8sClient_RemovePodLabels_Call {
+	return &MockK8sClient_RemovePodLabels_Call{Call: _e.mock.On("RemovePodLabels", pod, keys)}
+}
+
+func (_c *MockK8sClient_RemovePodLabels_Call) Run(run func(pod *v1.Pod, keys []string)) *MockK8sClient_RemovePodLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*v1.Pod), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockK8sClient_RemovePodLabels_Call) Return(_a0 error) *MockK8sClient_RemovePodLabels_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockK8sClient_RemovePodLabels_Call) RunAndReturn(run func(*v1.Pod, []string) error) *MockK8sClient_RemovePodLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Scheme provides a mock function with no fields
 func (_m *MockK8sClient) Scheme() *runtime.Scheme {
 	ret := _m.Called()
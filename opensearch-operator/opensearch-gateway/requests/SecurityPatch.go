@@ -0,0 +1,8 @@
+package requests
+
+// PatchOperation is a JSON patch operation as accepted by the PATCH endpoints of the security REST API
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
@@ -10,6 +10,14 @@ type FlatClusterSettingsResponse struct {
 	Transient  Settings `json:"transient,omitempty"`
 }
 
+// FlatClusterSettingsWithDefaultsResponse contains the defaults in addition to the set values. The defaults include
+// the settings of the node, e.g. from opensearch.yml.
+type FlatClusterSettingsWithDefaultsResponse struct {
+	Persistent map[string]interface{} `json:"persistent,omitempty"`
+	Transient  map[string]interface{} `json:"transient,omitempty"`
+	Defaults   map[string]interface{} `json:"defaults,omitempty"`
+}
+
 type Settings struct {
 	ClusterRoutingAllocationEnable  string `json:"cluster.routing.allocation.enable,omitempty"`
 	ClusterRoutingAllocationExclude string `json:"cluster.routing.allocation.exclude._name,omitempty"`
@@ -11,3 +11,12 @@ type GetUserResponse map[string]requests.User
 type GetActionGroupResponse map[string]requests.ActionGroup
 
 type GetTenantResponse map[string]requests.Tenant
+
+// SecurityResourceFlags are the flags the security plugin returns for every resource of a security config type
+type SecurityResourceFlags struct {
+	Reserved bool `json:"reserved"`
+	Hidden   bool `json:"hidden"`
+	Static   bool `json:"static"`
+}
+
+type GetSecurityResourcesResponse map[string]SecurityResourceFlags
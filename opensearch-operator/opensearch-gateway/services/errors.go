@@ -10,6 +10,8 @@ var (
 	ErrClusterHealthOperation            = errors.New("cluster health failed")
 	ErrClusterSettingsOperation          = errors.New("cluster settings failed")
 	ErrCatIndicesOperation               = errors.New("cat indices failed")
+	ErrVotingConfigExclusionsOperation   = errors.New("voting config exclusions failed")
+	ErrFlushOperation                    = errors.New("flush failed")
 )
 
 func ErrClusterAllocationExplainGetFailed(resp string) error {
@@ -28,6 +30,14 @@ func ErrClusterSettingsPutFailed(resp string) error {
 	return fmt.Errorf("put error %w: %s", ErrClusterSettingsOperation, resp)
 }
 
+func ErrVotingConfigExclusionsFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrVotingConfigExclusionsOperation, resp)
+}
+
 func ErrCatIndicesFailed(resp string) error {
 	return fmt.Errorf("%w: %s", ErrCatIndicesOperation, resp)
 }
+
+func ErrFlushFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrFlushOperation, resp)
+}
@@ -88,6 +88,26 @@ func doHTTPPut(ctx context.Context, client *opensearch.Client, path strings.Buil
 	return &opensearchapi.Response{StatusCode: res.StatusCode, Body: res.Body, Header: res.Header}, nil
 }
 
+// doHTTPPatch performs a HTTP PATCH request
+func doHTTPPatch(ctx context.Context, client *opensearch.Client, path strings.Builder, body io.Reader) (*opensearchapi.Response, error) {
+	req, err := http.NewRequest(http.MethodPatch, path.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	req.Header.Add(headerContentType, jsonContentHeader)
+
+	res, err := client.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &opensearchapi.Response{StatusCode: res.StatusCode, Body: res.Body, Header: res.Header}, nil
+}
+
 // doHTTPDelete performs a HTTP DELETE request
 func doHTTPDelete(ctx context.Context, client *opensearch.Client, path strings.Builder) (*opensearchapi.Response, error) {
 	req, err := http.NewRequest(http.MethodDelete, path.String(), nil)
@@ -211,6 +211,19 @@ func (client *OsClusterClient) CatShards(headers []string) ([]responses.CatShard
 	return response, err
 }
 
+// CatShardsInBytes returns the shards like CatShards, with the store size in bytes
+func (client *OsClusterClient) CatShardsInBytes(headers []string) ([]responses.CatShardsResponse, error) {
+	req := opensearchapi.CatShardsRequest{Format: "json", H: headers, Bytes: "b"}
+	indicesRes, err := req.Do(context.Background(), client.client)
+	var response []responses.CatShardsResponse
+	if err != nil {
+		return response, err
+	}
+	defer helpers.SafeClose(indicesRes.Body)
+	err = json.NewDecoder(indicesRes.Body).Decode(&response)
+	return response, err
+}
+
 func (client *OsClusterClient) CatNamedIndicesShards(headers []string, indices []string) ([]responses.CatShardsResponse, error) {
 	req := opensearchapi.CatShardsRequest{
 		Index:  indices,
@@ -287,6 +300,26 @@ func (client *OsClusterClient) GetFlatClusterSettings() (responses.FlatClusterSe
 	return response, err
 }
 
+func (client *OsClusterClient) GetFlatClusterSettingsWithDefaults() (responses.FlatClusterSettingsWithDefaultsResponse, error) {
+	req := opensearchapi.ClusterGetSettingsRequest{
+		FlatSettings:    ptr.To(true),
+		IncludeDefaults: ptr.To(true),
+	}
+	settingsRes, err := req.Do(context.Background(), client.client)
+	var response responses.FlatClusterSettingsWithDefaultsResponse
+	if err != nil {
+		return response, err
+	}
+	defer helpers.SafeClose(settingsRes.Body)
+
+	if settingsRes.IsError() {
+		return response, ErrClusterSettingsGetFailed(settingsRes.String())
+	}
+
+	err = json.NewDecoder(settingsRes.Body).Decode(&response)
+	return response, err
+}
+
 func (client *OsClusterClient) PutClusterSettings(settings responses.ClusterSettingsResponse) (responses.ClusterSettingsResponse, error) {
 	body := opensearchutil.NewJSONReader(settings)
 	req := opensearchapi.ClusterPutSettingsRequest{Body: body}
@@ -305,6 +338,68 @@ func (client *OsClusterClient) PutClusterSettings(settings responses.ClusterSett
 	return response, err
 }
 
+// PostVotingConfigExclusions excludes the nodes from the voting configuration, the request returns once the
+// cluster removed them from the configuration or the timeout expired
+func (client *OsClusterClient) PostVotingConfigExclusions(nodeNames []string) error {
+	req := opensearchapi.ClusterPostVotingConfigExclusionsRequest{NodeNames: strings.Join(nodeNames, ",")}
+	res, err := req.Do(context.Background(), client.client)
+	if err != nil {
+		return err
+	}
+	defer helpers.SafeClose(res.Body)
+	if res.IsError() {
+		return ErrVotingConfigExclusionsFailed(res.String())
+	}
+	return nil
+}
+
+// DeleteVotingConfigExclusions clears all voting configuration exclusions without waiting for the excluded nodes to leave
+func (client *OsClusterClient) DeleteVotingConfigExclusions() error {
+	req := opensearchapi.ClusterDeleteVotingConfigExclusionsRequest{WaitForRemoval: opensearchapi.BoolPtr(false)}
+	res, err := req.Do(context.Background(), client.client)
+	if err != nil {
+		return err
+	}
+	defer helpers.SafeClose(res.Body)
+	if res.IsError() {
+		return ErrVotingConfigExclusionsFailed(res.String())
+	}
+	return nil
+}
+
+// GetClusterCoordination returns the committed voting configuration and the voting configuration exclusions
+func (client *OsClusterClient) GetClusterCoordination() (responses.ClusterCoordination, error) {
+	req := opensearchapi.ClusterStateRequest{
+		Metric:     []string{"metadata"},
+		FilterPath: []string{"metadata.cluster_coordination"},
+	}
+	res, err := req.Do(context.Background(), client.client)
+	var response responses.ClusterStateResponse
+	if err != nil {
+		return response.Metadata.ClusterCoordination, err
+	}
+	defer helpers.SafeClose(res.Body)
+	if res.IsError() {
+		return response.Metadata.ClusterCoordination, ErrVotingConfigExclusionsFailed(res.String())
+	}
+	err = json.NewDecoder(res.Body).Decode(&response)
+	return response.Metadata.ClusterCoordination, err
+}
+
+// Flush writes the operations of all indices held in the transaction logs to the Lucene index
+func (client *OsClusterClient) Flush() error {
+	req := opensearchapi.IndicesFlushRequest{}
+	res, err := req.Do(context.Background(), client.client)
+	if err != nil {
+		return err
+	}
+	defer helpers.SafeClose(res.Body)
+	if res.IsError() {
+		return ErrFlushFailed(res.String())
+	}
+	return nil
+}
+
 func (client *OsClusterClient) ReRouteShard(rerouteJson string) (responses.ClusterRerouteResponse, error) {
 	body := strings.NewReader(rerouteJson)
 	req := opensearchapi.ClusterRerouteRequest{Body: body}
@@ -383,6 +478,24 @@ func (client *OsClusterClient) DeleteSecurityResource(ctx context.Context, resou
 	return doHTTPDelete(ctx, client.client, path)
 }
 
+// GetSecurityResources performs an HTTP GET request to OS to fetch all resources of the security config type
+func (client *OsClusterClient) GetSecurityResources(ctx context.Context, resource string) (*opensearchapi.Response, error) {
+	path := generateAPIPathSecurityConfig(resource)
+	return doHTTPGet(ctx, client.client, path)
+}
+
+// PatchSecurityResources performs an HTTP PATCH request to OS to change multiple resources of the security config type
+func (client *OsClusterClient) PatchSecurityResources(ctx context.Context, resource string, body io.Reader) (*opensearchapi.Response, error) {
+	path := generateAPIPathSecurityConfig(resource)
+	return doHTTPPatch(ctx, client.client, path, body)
+}
+
+// PutSecurityConfig performs an HTTP PUT request to OS to replace a security config that is not split into resources, e.g. audit/config
+func (client *OsClusterClient) PutSecurityConfig(ctx context.Context, resource string, body io.Reader) (*opensearchapi.Response, error) {
+	path := generateAPIPathSecurityConfig(resource)
+	return doHTTPPut(ctx, client.client, path, body)
+}
+
 // GetISMConfig performs an HTTP GET request to OS to get the ISM policy resource specified by name
 func (client *OsClusterClient) GetISMConfig(ctx context.Context, name string) (*opensearchapi.Response, error) {
 	path := generateAPIPathISM(ismResource, name)
@@ -550,6 +663,21 @@ func generateAPIPath(resource, name string) strings.Builder {
 	return path
 }
 
+// generates a URI PATH for all resources of a security config type
+func generateAPIPathSecurityConfig(resource string) strings.Builder {
+	var path strings.Builder
+	path.Grow(1 + len("_plugins") + 1 + len("_security") + 1 + len("api") + 1 + len(resource))
+	path.WriteString("/")
+	path.WriteString("_plugins")
+	path.WriteString("/")
+	path.WriteString("_security")
+	path.WriteString("/")
+	path.WriteString("api")
+	path.WriteString("/")
+	path.WriteString(resource)
+	return path
+}
+
 // generates a URI PATH for a given snapshot repository name
 func generateAPIPathSnapshotRepository(name string) strings.Builder {
 	var path strings.Builder
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/Masterminds/semver"
@@ -41,6 +43,42 @@ func HasIndicesWithNoReplica(service *OsClusterClient) (bool, error) {
 	return false, err
 }
 
+// AllocationAwarenessAttributes returns the node attributes used for shard allocation awareness. Transient settings
+// take precedence over persistent settings and those over the settings of the node.
+func AllocationAwarenessAttributes(service *OsClusterClient) ([]string, error) {
+	response, err := service.GetFlatClusterSettingsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+	return awarenessAttributesFromResponse(response), nil
+}
+
+func awarenessAttributesFromResponse(response responses.FlatClusterSettingsWithDefaultsResponse) []string {
+	for _, settings := range []map[string]interface{}{response.Transient, response.Persistent, response.Defaults} {
+		value, ok := settings["cluster.routing.allocation.awareness.attributes"]
+		if !ok {
+			continue
+		}
+		var values []string
+		switch v := value.(type) {
+		case string:
+			values = strings.Split(v, ",")
+		case []interface{}:
+			for _, item := range v {
+				values = append(values, fmt.Sprint(item))
+			}
+		}
+		var attributes []string
+		for _, attribute := range values {
+			if attribute = strings.TrimSpace(attribute); attribute != "" {
+				attributes = append(attributes, attribute)
+			}
+		}
+		return attributes
+	}
+	return nil
+}
+
 // extractNodeName returns the actual node name from the NodeName field.
 // During shard relocation, _cat/shards returns a format like:
 // "opensearch-data-1 -> 172.31.233.51 4kGSHQhmRQ-83pvvBbTYow opensearch-data-8".
@@ -77,6 +115,56 @@ func HasShardsOnNode(service *OsClusterClient, nodeName string) (bool, error) {
 	return hasShardsOnNodeFromResponse(response, nodeName), err
 }
 
+// DrainProgress describes the shards that still have to be moved off a node
+type DrainProgress struct {
+	// Shards allocated to the node, including the ones relocating away from it
+	Shards int32
+	// Shards relocating away from the node
+	RelocatingShards int32
+	// Store size of the shards allocated to the node
+	Bytes int64
+	// Store size of the shards relocating away from the node
+	RelocatingBytes int64
+	// Shards allocated to the node without a started copy on another node
+	ShardsWithoutCopy int32
+}
+
+// drainProgressFromResponse calculates the drain progress of the node from a _cat/shards response with sizes in bytes
+func drainProgressFromResponse(response []responses.CatShardsResponse, nodeName string) DrainProgress {
+	var progress DrainProgress
+	copies := map[string]bool{}
+	for _, shard := range response {
+		if extractNodeName(shard.NodeName) != nodeName && (shard.State == "STARTED" || shard.State == "RELOCATING") {
+			copies[shard.Index+"/"+shard.Shard] = true
+		}
+	}
+	for _, shard := range response {
+		if extractNodeName(shard.NodeName) != nodeName {
+			continue
+		}
+		bytes, _ := strconv.ParseInt(shard.Store, 10, 64)
+		progress.Shards++
+		progress.Bytes += bytes
+		if shard.State == "RELOCATING" {
+			progress.RelocatingShards++
+			progress.RelocatingBytes += bytes
+		}
+		if !copies[shard.Index+"/"+shard.Shard] {
+			progress.ShardsWithoutCopy++
+		}
+	}
+	return progress
+}
+
+// GetDrainProgress returns the shards remaining on the node
+func GetDrainProgress(service *OsClusterClient, nodeName string) (DrainProgress, error) {
+	response, err := service.CatShardsInBytes([]string{"index", "shard", "prirep", "state", "store", "node"})
+	if err != nil {
+		return DrainProgress{}, err
+	}
+	return drainProgressFromResponse(response, nodeName), nil
+}
+
 func HasIndexPrimariesOnNode(service *OsClusterClient, nodeName string, indices []string) (bool, error) {
 	var headers []string
 	response, err := service.CatNamedIndicesShards(headers, indices)
@@ -179,12 +267,129 @@ func RemoveExcludeNodeHost(service *OsClusterClient, lg logr.Logger, nodeNameToE
 	return err == nil, err
 }
 
+// AppendVotingConfigExclusions excludes the cluster manager eligible nodes from the voting configuration. Nodes that
+// are already excluded are skipped, as adding them again fails.
+func AppendVotingConfigExclusions(service *OsClusterClient, lg logr.Logger, nodeNames []string) error {
+	coordination, err := service.GetClusterCoordination()
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, name := range nodeNames {
+		if !slices.ContainsFunc(coordination.VotingConfigExclusions, func(exclusion responses.VotingConfigExclusion) bool {
+			return exclusion.NodeName == name
+		}) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	lg.Info(fmt.Sprintf("Excluding nodes %s from the voting configuration", strings.Join(missing, ",")))
+	return service.PostVotingConfigExclusions(missing)
+}
+
+// VotingConfigExclusionsApplied returns true if the nodes are excluded from the voting configuration and the cluster
+// committed a voting configuration without them
+func VotingConfigExclusionsApplied(service *OsClusterClient, nodeNames []string) (bool, error) {
+	coordination, err := service.GetClusterCoordination()
+	if err != nil {
+		return false, err
+	}
+	return votingConfigExclusionsAppliedFromResponse(coordination, nodeNames), nil
+}
+
+func votingConfigExclusionsAppliedFromResponse(coordination responses.ClusterCoordination, nodeNames []string) bool {
+	for _, name := range nodeNames {
+		index := slices.IndexFunc(coordination.VotingConfigExclusions, func(exclusion responses.VotingConfigExclusion) bool {
+			return exclusion.NodeName == name
+		})
+		if index < 0 || slices.Contains(coordination.LastCommittedConfig, coordination.VotingConfigExclusions[index].NodeId) {
+			return false
+		}
+	}
+	return true
+}
+
+// ClearVotingConfigExclusions removes all voting configuration exclusions, the API does not allow to remove single nodes
+func ClearVotingConfigExclusions(service *OsClusterClient, lg logr.Logger) error {
+	coordination, err := service.GetClusterCoordination()
+	if err != nil {
+		return err
+	}
+	if len(coordination.VotingConfigExclusions) == 0 {
+		return nil
+	}
+	lg.Info("Clearing the voting configuration exclusions")
+	return service.DeleteVotingConfigExclusions()
+}
+
+// ClearVotingConfigExclusionsOfRemovedNodes clears the voting configuration exclusions once none of the excluded
+// nodes is part of the cluster anymore, i.e. the nodes they were added for have been removed
+func ClearVotingConfigExclusionsOfRemovedNodes(service *OsClusterClient, lg logr.Logger) error {
+	coordination, err := service.GetClusterCoordination()
+	if err != nil {
+		return err
+	}
+	if len(coordination.VotingConfigExclusions) == 0 {
+		return nil
+	}
+	nodes, err := service.CatNodes()
+	if err != nil {
+		return err
+	}
+	if !excludedNodesRemovedFromResponse(coordination, nodes) {
+		return nil
+	}
+	lg.Info("Clearing the voting configuration exclusions of removed nodes")
+	return service.DeleteVotingConfigExclusions()
+}
+
+func excludedNodesRemovedFromResponse(coordination responses.ClusterCoordination, nodes []responses.CatNodesResponse) bool {
+	for _, exclusion := range coordination.VotingConfigExclusions {
+		if slices.ContainsFunc(nodes, func(node responses.CatNodesResponse) bool {
+			return node.Name == exclusion.NodeName
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
 func SetClusterShardAllocation(service *OsClusterClient, enableType ClusterSettingsAllocation) error {
 	settings := createClusterSettingsAllocationEnable(enableType)
 	_, err := service.PutClusterSettings(settings)
 	return err
 }
 
+// GetClusterShardAllocation returns the transient value of cluster.routing.allocation.enable, empty if it is not set
+func GetClusterShardAllocation(service *OsClusterClient) (string, error) {
+	flatSettings, err := service.GetFlatClusterSettings()
+	if err != nil {
+		return "", err
+	}
+	return flatSettings.Transient.ClusterRoutingAllocationEnable, nil
+}
+
+// RestoreClusterShardAllocation sets the transient shard allocation back to the given value. An empty value removes
+// the transient setting, so the persistent or default value applies again.
+func RestoreClusterShardAllocation(service *OsClusterClient, previous string) error {
+	if previous != "" {
+		return SetClusterShardAllocation(service, ClusterSettingsAllocation(previous))
+	}
+	settings := responses.ClusterSettingsResponse{Transient: map[string]interface{}{
+		"cluster": map[string]interface{}{
+			"routing": map[string]interface{}{
+				"allocation": map[string]interface{}{
+					"enable": nil,
+				},
+			},
+		},
+	}}
+	_, err := service.PutClusterSettings(settings)
+	return err
+}
+
 func createClusterSettingsResponseWithExcludeName(exclude string) responses.ClusterSettingsResponse {
 	var val *string = nil
 	if exclude != "" {
@@ -215,17 +420,29 @@ func createClusterSettingsAllocationEnable(enable ClusterSettingsAllocation) res
 	}}
 }
 
-func CheckClusterStatusForRestart(service *OsClusterClient, drainNodes bool) (bool, string, error) {
+// CheckClusterStatusForRestart checks whether the cluster health allows to restart the given nodes. If allowYellow is
+// set, a yellow cluster is restarted as long as no shards are moving and the nodes do not hold the last started copy
+// of a shard. The same is checked for a green cluster if several nodes are restarted at the same time.
+func CheckClusterStatusForRestart(service *OsClusterClient, drainNodes bool, allowYellow bool, nodeNames []string) (bool, string, error) {
 	health, err := service.GetHealth()
 	if err != nil {
 		return false, "failed to fetch health", err
 	}
 
 	if health.Status == "green" {
-		return true, "", nil
+		if len(nodeNames) <= 1 {
+			return true, "", nil
+		}
+		return checkNodesHoldShardCopies(service, nodeNames)
 	}
 
 	if health.Status == "yellow" {
+		if allowYellow && !hasMovingShards(health) {
+			ready, message, err := checkNodesHoldShardCopies(service, nodeNames)
+			if err != nil || ready {
+				return ready, message, err
+			}
+		}
 		// During an upgrade, if the primary of a shard end on an upgraded node,
 		// its replicas cannot be allocated to non-upgraded nodes,
 		// which will cause the cluster to remain yellow until the number of upgraded nodes
@@ -261,6 +478,51 @@ func CheckClusterStatusForRestart(service *OsClusterClient, drainNodes bool) (bo
 	return false, "enabled shard allocation", nil
 }
 
+func checkNodesHoldShardCopies(service *OsClusterClient, nodeNames []string) (bool, string, error) {
+	holdAllCopies, err := NodesHoldAllShardCopies(service, nodeNames)
+	if err != nil {
+		return false, "failed to fetch shards", err
+	}
+	if holdAllCopies {
+		return false, "the nodes hold all started copies of a shard", nil
+	}
+	return true, "", nil
+}
+
+// NodesHoldAllShardCopies returns true if all started copies of a shard are allocated to the given nodes, so restarting
+// them at the same time would make the shard unavailable
+func NodesHoldAllShardCopies(service *OsClusterClient, nodeNames []string) (bool, error) {
+	response, err := service.CatShards([]string{"index", "shard", "prirep", "state", "node"})
+	if err != nil {
+		return false, err
+	}
+	return nodesHoldAllShardCopiesFromResponse(response, nodeNames), nil
+}
+
+// nodesHoldAllShardCopiesFromResponse returns true if a shard in the response has a started copy on one of the nodes
+// but none on any other node
+func nodesHoldAllShardCopiesFromResponse(response []responses.CatShardsResponse, nodeNames []string) bool {
+	onNodes := map[string]bool{}
+	elsewhere := map[string]bool{}
+	for _, shard := range response {
+		if shard.State != "STARTED" && shard.State != "RELOCATING" {
+			continue
+		}
+		key := shard.Index + "/" + shard.Shard
+		if slices.Contains(nodeNames, extractNodeName(shard.NodeName)) {
+			onNodes[key] = true
+		} else {
+			elsewhere[key] = true
+		}
+	}
+	for key := range onNodes {
+		if !elsewhere[key] {
+			return true
+		}
+	}
+	return false
+}
+
 func ReactivateShardAllocation(service *OsClusterClient) error {
 	flatSettings, err := service.GetFlatClusterSettings()
 	if err != nil {
@@ -595,7 +857,7 @@ func CheckClusterRestartOnYellow(service *OsClusterClient, health responses.Clus
 
 	// Make sure that there are no moving shards,
 	// i.e. the yellow status is caused by unassigned replicas
-	if health.RelocatingShards > 0 || health.InitializingShards > 0 {
+	if hasMovingShards(health) {
 		return false, nil
 	}
 
@@ -632,6 +894,11 @@ func CheckClusterRestartOnYellow(service *OsClusterClient, health responses.Clus
 	return isDeadlocked, nil
 }
 
+// hasMovingShards returns true if shards are being relocated or initialized
+func hasMovingShards(health responses.ClusterHealthResponse) bool {
+	return health.RelocatingShards > 0 || health.InitializingShards > 0
+}
+
 func CheckPodSafeToDelete(service *OsClusterClient, nodeName string) (bool, error) {
 	// Get all shards on the cluster
 	var headers []string
@@ -90,6 +90,7 @@ var _ = Describe("OpensearchCLuster data service tests", func() {
 })*/
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
@@ -211,6 +212,133 @@ func TestHasShardsOnNodeFromResponse(t *testing.T) {
 	}
 }
 
+func TestDrainProgressFromResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		shards   []responses.CatShardsResponse
+		nodeName string
+		want     DrainProgress
+	}{
+		{
+			name:     "no shards",
+			shards:   nil,
+			nodeName: "opensearch-data-1",
+			want:     DrainProgress{},
+		},
+		{
+			name: "shards with a copy on another node",
+			shards: []responses.CatShardsResponse{
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "1000", NodeName: "opensearch-data-1"},
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "r", State: "STARTED", Store: "1000", NodeName: "opensearch-data-2"},
+			},
+			nodeName: "opensearch-data-1",
+			want:     DrainProgress{Shards: 1, Bytes: 1000},
+		},
+		{
+			name: "relocating shard without a copy",
+			shards: []responses.CatShardsResponse{
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "RELOCATING", Store: "2000", NodeName: "opensearch-data-1 -> 10.0.0.1 xyz opensearch-data-3"},
+				{Index: "idx", Shard: "1", PrimaryOrReplica: "p", State: "STARTED", Store: "500", NodeName: "opensearch-data-1"},
+				{Index: "idx", Shard: "1", PrimaryOrReplica: "r", State: "INITIALIZING", Store: "", NodeName: "opensearch-data-2"},
+			},
+			nodeName: "opensearch-data-1",
+			want:     DrainProgress{Shards: 2, RelocatingShards: 1, Bytes: 2500, RelocatingBytes: 2000, ShardsWithoutCopy: 2},
+		},
+		{
+			name: "shards of other nodes are ignored",
+			shards: []responses.CatShardsResponse{
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "1000", NodeName: "opensearch-data-2"},
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "r", State: "UNASSIGNED", Store: "", NodeName: ""},
+			},
+			nodeName: "opensearch-data-1",
+			want:     DrainProgress{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := drainProgressFromResponse(tt.shards, tt.nodeName)
+			if got != tt.want {
+				t.Errorf("drainProgressFromResponse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVotingConfigExclusionsAppliedFromResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		coordination responses.ClusterCoordination
+		nodeNames    []string
+		want         bool
+	}{
+		{
+			name:         "node not excluded",
+			coordination: responses.ClusterCoordination{LastCommittedConfig: []string{"id-0", "id-1", "id-2"}},
+			nodeNames:    []string{"opensearch-masters-2"},
+			want:         false,
+		},
+		{
+			name: "node excluded but still in the committed configuration",
+			coordination: responses.ClusterCoordination{
+				LastCommittedConfig:    []string{"id-0", "id-1", "id-2"},
+				VotingConfigExclusions: []responses.VotingConfigExclusion{{NodeId: "id-2", NodeName: "opensearch-masters-2"}},
+			},
+			nodeNames: []string{"opensearch-masters-2"},
+			want:      false,
+		},
+		{
+			name: "nodes excluded and removed from the committed configuration",
+			coordination: responses.ClusterCoordination{
+				LastCommittedConfig: []string{"id-0"},
+				VotingConfigExclusions: []responses.VotingConfigExclusion{
+					{NodeId: "id-1", NodeName: "opensearch-masters-1"},
+					{NodeId: "id-2", NodeName: "opensearch-masters-2"},
+				},
+			},
+			nodeNames: []string{"opensearch-masters-1", "opensearch-masters-2"},
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := votingConfigExclusionsAppliedFromResponse(tt.coordination, tt.nodeNames)
+			if got != tt.want {
+				t.Errorf("votingConfigExclusionsAppliedFromResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcludedNodesRemovedFromResponse(t *testing.T) {
+	coordination := responses.ClusterCoordination{
+		VotingConfigExclusions: []responses.VotingConfigExclusion{{NodeId: "id-2", NodeName: "opensearch-masters-2"}},
+	}
+	tests := []struct {
+		name  string
+		nodes []responses.CatNodesResponse
+		want  bool
+	}{
+		{
+			name:  "excluded node still in the cluster",
+			nodes: []responses.CatNodesResponse{{Name: "opensearch-masters-0"}, {Name: "opensearch-masters-1"}, {Name: "opensearch-masters-2"}},
+			want:  false,
+		},
+		{
+			name:  "excluded node removed",
+			nodes: []responses.CatNodesResponse{{Name: "opensearch-masters-0"}, {Name: "opensearch-masters-1"}},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := excludedNodesRemovedFromResponse(coordination, tt.nodes)
+			if got != tt.want {
+				t.Errorf("excludedNodesRemovedFromResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDetermineUnsupportedClusterSettings(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -255,3 +383,110 @@ func TestDetermineUnsupportedClusterSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestNodesHoldAllShardCopiesFromResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		shards    []responses.CatShardsResponse
+		nodeNames []string
+		want      bool
+	}{
+		{
+			name: "copy on another node",
+			shards: []responses.CatShardsResponse{
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", NodeName: "opensearch-data-0"},
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "r", State: "STARTED", NodeName: "opensearch-data-2"},
+			},
+			nodeNames: []string{"opensearch-data-0", "opensearch-data-1"},
+			want:      false,
+		},
+		{
+			name: "all copies in the batch",
+			shards: []responses.CatShardsResponse{
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", NodeName: "opensearch-data-0"},
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "r", State: "STARTED", NodeName: "opensearch-data-1"},
+				{Index: "idx", Shard: "1", PrimaryOrReplica: "p", State: "STARTED", NodeName: "opensearch-data-2"},
+			},
+			nodeNames: []string{"opensearch-data-0", "opensearch-data-1"},
+			want:      true,
+		},
+		{
+			name: "primary with an unassigned replica",
+			shards: []responses.CatShardsResponse{
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", NodeName: "opensearch-data-0"},
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "r", State: "UNASSIGNED", NodeName: ""},
+			},
+			nodeNames: []string{"opensearch-data-0"},
+			want:      true,
+		},
+		{
+			name: "initializing copy does not count",
+			shards: []responses.CatShardsResponse{
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", NodeName: "opensearch-data-0"},
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "r", State: "INITIALIZING", NodeName: "opensearch-data-1"},
+			},
+			nodeNames: []string{"opensearch-data-0"},
+			want:      true,
+		},
+		{
+			name: "no shards on the nodes",
+			shards: []responses.CatShardsResponse{
+				{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", NodeName: "opensearch-data-2"},
+			},
+			nodeNames: []string{"opensearch-data-0"},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nodesHoldAllShardCopiesFromResponse(tt.shards, tt.nodeNames)
+			if got != tt.want {
+				t.Errorf("nodesHoldAllShardCopiesFromResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAwarenessAttributesFromResponse(t *testing.T) {
+	const setting = "cluster.routing.allocation.awareness.attributes"
+	tests := []struct {
+		name     string
+		response responses.FlatClusterSettingsWithDefaultsResponse
+		want     []string
+	}{
+		{
+			name:     "not configured",
+			response: responses.FlatClusterSettingsWithDefaultsResponse{Defaults: map[string]interface{}{setting: []interface{}{}}},
+			want:     nil,
+		},
+		{
+			name:     "node setting",
+			response: responses.FlatClusterSettingsWithDefaultsResponse{Defaults: map[string]interface{}{setting: []interface{}{"zone", "rack"}}},
+			want:     []string{"zone", "rack"},
+		},
+		{
+			name: "persistent setting overrides the node setting",
+			response: responses.FlatClusterSettingsWithDefaultsResponse{
+				Persistent: map[string]interface{}{setting: "zone, rack"},
+				Defaults:   map[string]interface{}{setting: []interface{}{"rack"}},
+			},
+			want: []string{"zone", "rack"},
+		},
+		{
+			name: "transient setting overrides the persistent setting",
+			response: responses.FlatClusterSettingsWithDefaultsResponse{
+				Transient:  map[string]interface{}{setting: ""},
+				Persistent: map[string]interface{}{setting: "zone"},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := awarenessAttributesFromResponse(tt.response)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("awarenessAttributesFromResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
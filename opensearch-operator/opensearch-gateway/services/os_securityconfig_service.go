@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/opensearch-project/opensearch-go/opensearchutil"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/requests"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+)
+
+const (
+	CONFIG    = "config"
+	NODESDN   = "nodesdn"
+	ALLOWLIST = "allowlist"
+	AUDIT     = "audit"
+)
+
+// Flags of the yml files that can not be set through the REST API
+var securityResourceFlags = []string{"reserved", "hidden", "static"}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// ApplySecurityConfig replaces the security config type with the content of its yml file, the same way
+// securityadmin.sh does for a single file. With prune resources missing from the file are removed unless they are
+// static or hidden, otherwise they are kept.
+func ApplySecurityConfig(ctx context.Context, service *OsClusterClient, configType string, data []byte, prune bool) error {
+	content := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return fmt.Errorf("failed to parse %s config: %w", configType, err)
+	}
+	delete(content, "_meta")
+
+	switch configType {
+	case CONFIG:
+		return putSecurityConfig(ctx, service, "securityconfig/config", content[CONFIG])
+	case AUDIT:
+		return putSecurityConfig(ctx, service, "audit/config", content[CONFIG])
+	case ALLOWLIST:
+		return putSecurityConfig(ctx, service, ALLOWLIST, content[CONFIG])
+	case INTERNALUSERS, ROLES, ROLESMAPPING, ACTIONGROUPS, TENANTS, NODESDN:
+		return replaceSecurityResources(ctx, service, configType, content, prune)
+	default:
+		return fmt.Errorf("security config type %s can not be applied through the REST API", configType)
+	}
+}
+
+func putSecurityConfig(ctx context.Context, service *OsClusterClient, resource string, config interface{}) error {
+	if config == nil {
+		return fmt.Errorf("%s config does not contain a config section", resource)
+	}
+	resp, err := service.PutSecurityConfig(ctx, resource, opensearchutil.NewJSONReader(config))
+	if err != nil {
+		return err
+	}
+	defer helpers.SafeClose(resp.Body)
+	if resp.IsError() {
+		return fmt.Errorf("failed to apply %s config: %s", resource, resp.String())
+	}
+	return nil
+}
+
+func replaceSecurityResources(ctx context.Context, service *OsClusterClient, resource string, content map[string]interface{}, prune bool) error {
+	names := make([]string, 0, len(content))
+	for name := range content {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	operations := []requests.PatchOperation{}
+	for _, name := range names {
+		value := content[name]
+		if fields, ok := value.(map[string]interface{}); ok {
+			for _, flag := range securityResourceFlags {
+				delete(fields, flag)
+			}
+		}
+		operations = append(operations, requests.PatchOperation{Op: "add", Path: "/" + jsonPointerEscaper.Replace(name), Value: value})
+	}
+
+	if prune {
+		obsolete, err := obsoleteSecurityResources(ctx, service, resource, content)
+		if err != nil {
+			return err
+		}
+		for _, name := range obsolete {
+			operations = append(operations, requests.PatchOperation{Op: "remove", Path: "/" + jsonPointerEscaper.Replace(name)})
+		}
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+	resp, err := service.PatchSecurityResources(ctx, resource, opensearchutil.NewJSONReader(operations))
+	if err != nil {
+		return err
+	}
+	defer helpers.SafeClose(resp.Body)
+	if resp.IsError() {
+		return fmt.Errorf("failed to apply %s: %s", resource, resp.String())
+	}
+	return nil
+}
+
+// obsoleteSecurityResources returns the resources of the cluster that are missing from content and can be removed
+func obsoleteSecurityResources(ctx context.Context, service *OsClusterClient, resource string, content map[string]interface{}) ([]string, error) {
+	resp, err := service.GetSecurityResources(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	defer helpers.SafeClose(resp.Body)
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get %s: %s", resource, resp.String())
+	}
+	existing := responses.GetSecurityResourcesResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&existing); err != nil {
+		return nil, err
+	}
+
+	obsolete := []string{}
+	for name, flags := range existing {
+		if _, ok := content[name]; !ok && !flags.Static && !flags.Hidden {
+			obsolete = append(obsolete, name)
+		}
+	}
+	sort.Strings(obsolete)
+	return obsolete, nil
+}
@@ -0,0 +1,114 @@
+package builders
+
+import (
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/tls"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CertificateGVK is the cert-manager Certificate kind. Certificates are handled as unstructured objects
+// so the operator does not depend on cert-manager being installed unless it is used.
+var CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// CertManagerCertificate describes a certificate to be issued by cert-manager
+type CertManagerCertificate struct {
+	Name       string
+	SecretName string
+	CommonName string
+	DnsNames   []string
+	// Usages of the certificate, e.g. "server auth" and "client auth"
+	Usages []string
+}
+
+// NewCertManagerCertificate builds a cert-manager Certificate. The subject carries the cluster name as OU,
+// matching the certificates generated by the operator, so the derived nodes_dn and admin_dn stay the same.
+// OpenSearch requires the private key in PKCS8 encoding. Keys default to RSA 4096, like generated certificates.
+func NewCertManagerCertificate(
+	cr *opensearchv1.OpenSearchCluster,
+	config *opensearchv1.TlsCertificateConfig,
+	cert CertManagerCertificate,
+) *unstructured.Unstructured {
+	issuerRef := config.CertManager.IssuerRef
+	kind := issuerRef.Kind
+	if kind == "" {
+		kind = "Issuer"
+	}
+	group := issuerRef.Group
+	if group == "" {
+		group = "cert-manager.io"
+	}
+
+	keyAlgorithm := config.KeyAlgorithm
+	if keyAlgorithm == "" {
+		keyAlgorithm = tls.KeyAlgorithmRSA
+	}
+	keySize := int64(config.KeySize)
+	if keySize == 0 && keyAlgorithm == tls.KeyAlgorithmRSA {
+		keySize = tls.DefaultRSAKeySize
+	} else if keySize == 0 {
+		keySize = tls.DefaultECDSAKeySize
+	}
+
+	spec := map[string]interface{}{
+		"secretName": cert.SecretName,
+		"commonName": cert.CommonName,
+		"subject": map[string]interface{}{
+			"organizationalUnits": []interface{}{cr.Name},
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  issuerRef.Name,
+			"kind":  kind,
+			"group": group,
+		},
+		"privateKey": map[string]interface{}{
+			"algorithm":      keyAlgorithm,
+			"size":           keySize,
+			"encoding":       "PKCS8",
+			"rotationPolicy": "Always",
+		},
+		"secretTemplate": map[string]interface{}{
+			"labels": map[string]interface{}{helpers.ClusterLabel: cr.Name},
+		},
+	}
+	if len(cert.DnsNames) > 0 {
+		dnsNames := make([]interface{}, 0, len(cert.DnsNames))
+		for _, name := range cert.DnsNames {
+			dnsNames = append(dnsNames, name)
+		}
+		spec["dnsNames"] = dnsNames
+	}
+	if len(cert.Usages) > 0 {
+		usages := make([]interface{}, 0, len(cert.Usages))
+		for _, usage := range cert.Usages {
+			usages = append(usages, usage)
+		}
+		spec["usages"] = usages
+	}
+	if config.Duration != nil {
+		spec["duration"] = config.Duration.Duration.String()
+	}
+	if config.CertManager.RenewBefore != nil {
+		spec["renewBefore"] = config.CertManager.RenewBefore.Duration.String()
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	certificate.SetGroupVersionKind(CertificateGVK)
+	certificate.SetName(cert.Name)
+	certificate.SetNamespace(cr.Namespace)
+	certificate.SetLabels(map[string]string{helpers.ClusterLabel: cr.Name})
+	return certificate
+}
+
+// CertManagerCertificateReady reports whether cert-manager has issued the certificate, based on its Ready condition
+func CertManagerCertificateReady(certificate *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Ready" {
+			return condition["status"] == "True"
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +32,7 @@ var (
 
 const (
 	ConfigurationChecksumAnnotation  = "opensearch.org/config"
+	CaRevisionAnnotation             = "opensearch.org/ca-revision"
 	defaultMonitoringPlugin          = "https://github.com/opensearch-project/opensearch-prometheus-exporter/releases/download/%s.0/prometheus-exporter-%s.0.zip"
 	securityconfigChecksumAnnotation = "securityconfig/checksum"
 )
@@ -97,10 +99,11 @@ func NewSTSForNodePool(
 		"search",
 		"warm",
 	}
+	vendor := helpers.VendorForCluster(cr)
 	var selectedRoles []string
 	for _, role := range node.Roles {
 		if helpers.ContainsString(availableRoles, role) {
-			role = helpers.MapClusterRole(role, cr.Spec.General.Version)
+			role = vendor.MapClusterRole(role, cr.Spec.General.Version)
 			selectedRoles = append(selectedRoles, role)
 		}
 	}
@@ -164,7 +167,7 @@ func NewSTSForNodePool(
 		}
 	}
 
-	opensearchHome := cr.Spec.General.GetOpenSearchHome()
+	opensearchHome := helpers.OpenSearchHome(cr)
 	volumeMounts = append(volumeMounts, corev1.VolumeMount{
 		Name:      "data",
 		MountPath: opensearchHome + "/data",
@@ -177,6 +180,14 @@ func NewSTSForNodePool(
 	annotations := map[string]string{
 		ConfigurationChecksumAnnotation: configChecksum,
 	}
+	// Restarts the nodes to load the certificates of a CA rotation
+	if cr.Status.CaRotation != nil && cr.Status.CaRotation.Revision > 0 {
+		annotations[CaRevisionAnnotation] = strconv.Itoa(int(cr.Status.CaRotation.Revision))
+	}
+	// Restarts the nodes when a rolling restart is requested with the restart-at annotation
+	if request := helpers.RestartRequestForNodePool(cr, &node); request != "" {
+		annotations[helpers.RestartAtAnnotation] = request
+	}
 	matchLabels := map[string]string{
 		helpers.ClusterLabel:  cr.Name,
 		helpers.NodePoolLabel: node.Component,
@@ -202,16 +213,6 @@ func NewSTSForNodePool(
 
 	runas := int64(0)
 
-	if cr.Spec.General.Vendor == "Op" || cr.Spec.General.Vendor == "OP" ||
-		cr.Spec.General.Vendor == "Opensearch" ||
-		cr.Spec.General.Vendor == "opensearch" ||
-		cr.Spec.General.Vendor == "" {
-		//	vendor = "opensearchproject/opensearch"
-	} else {
-		panic("vendor=elasticsearch not implemented")
-		// vendor ="elasticsearch"
-	}
-
 	jvmHeapSizeSettings := helpers.CalculateJvmHeapSizeSettings(node.Resources.Requests.Memory())
 	jvm := helpers.AppendJvmHeapSizeSettings(node.Jvm, jvmHeapSizeSettings)
 
@@ -382,7 +383,7 @@ func NewSTSForNodePool(
 	initHelperImage := helpers.ResolveInitHelperImage(cr)
 	resources := cr.Spec.InitHelper.Resources
 
-	startUpCommand := "./opensearch-docker-entrypoint.sh"
+	startUpCommand := vendor.Entrypoint()
 	// If a custom command is specified, use it.
 	if len(cr.Spec.General.Command) > 0 {
 		startUpCommand = cr.Spec.General.Command
@@ -399,7 +400,7 @@ func NewSTSForNodePool(
 
 	pluginslist = helpers.RemoveDuplicateStrings(append(pluginslist, cr.Spec.General.PluginsList...))
 
-	mainCommand := helpers.BuildMainCommand("./bin/opensearch-plugin", pluginslist, true, startUpCommand)
+	mainCommand := helpers.BuildMainCommand(vendor.PluginInstaller(), pluginslist, true, startUpCommand)
 
 	podSecurityContext := cr.Spec.General.PodSecurityContext
 	securityContext := cr.Spec.General.SecurityContext
@@ -523,13 +524,13 @@ func NewSTSForNodePool(
 
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        cr.Name + "-" + node.Component,
+			Name:        StsName(cr, &node),
 			Namespace:   cr.Namespace,
 			Labels:      labels,
 			Annotations: annotations,
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: &node.Replicas,
+			Replicas: ptr.To(helpers.NodePoolReplicas(cr, &node)),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: matchLabels,
 			},
@@ -643,6 +644,10 @@ func NewSTSForNodePool(
 		},
 	}
 
+	if node.Persistence == nil || node.Persistence.PVC != nil {
+		sts.Spec.PersistentVolumeClaimRetentionPolicy = node.PersistentVolumeClaimRetentionPolicy
+	}
+
 	// Add node.roles env var
 	// For coordinator-only nodes (empty roles), set to "[]" which OpenSearch 3.0+ properly handles as an empty array
 	nodeRolesValue := strings.Join(selectedRoles, ",")
@@ -654,6 +659,9 @@ func NewSTSForNodePool(
 		Value: nodeRolesValue,
 	})
 
+	// Credentials of the external audit cluster, referenced in opensearch.yml
+	sts.Spec.Template.Spec.Containers[0].Env = append(sts.Spec.Template.Spec.Containers[0].Env, helpers.AuditEnv(cr)...)
+
 	// Append additional env vars from cr.Spec.NodePool.env
 	sts.Spec.Template.Spec.Containers[0].Env = append(sts.Spec.Template.Spec.Containers[0].Env, node.Env...)
 
@@ -739,14 +747,44 @@ func NewHeadlessServiceForNodePool(cr *opensearchv1.OpenSearchCluster, nodePool
 	}
 }
 
+// NewOpenSearchNodePoolForCR returns the scale target of a nodepool with scaleTarget enabled
+func NewOpenSearchNodePoolForCR(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool, replicas int32) *opensearchv1.OpenSearchNodePool {
+	return &opensearchv1.OpenSearchNodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      OpenSearchNodePoolName(cr, nodePool),
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				helpers.ClusterLabel:  cr.Name,
+				helpers.NodePoolLabel: nodePool.Component,
+			},
+		},
+		Spec: opensearchv1.OpenSearchNodePoolSpec{
+			OpensearchRef: corev1.LocalObjectReference{Name: cr.Name},
+			Component:     nodePool.Component,
+			Replicas:      replicas,
+		},
+	}
+}
+
+// NodePoolSelector returns the label selector of the pods of the nodepool, as used by the scale subresource
+func NodePoolSelector(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) string {
+	return labels.SelectorFromSet(map[string]string{
+		helpers.ClusterLabel:  cr.Name,
+		helpers.NodePoolLabel: nodePool.Component,
+	}).String()
+}
+
 func NewServiceForCR(cr *opensearchv1.OpenSearchCluster) *corev1.Service {
 	labels := map[string]string{
 		helpers.ClusterLabel: cr.Name,
 	}
 
-	httpAppProtocol := "https"
-	if !helpers.IsHttpTlsEnabled(cr) {
-		httpAppProtocol = "http"
+	// Only select the pods of nodepools that did not opt out of the cluster service
+	selector := map[string]string{
+		helpers.ClusterLabel: cr.Name,
+	}
+	if helpers.ClientServiceFiltered(cr) {
+		selector[helpers.ServiceSelectorLabel(cr.Spec.General.ServiceName)] = "true"
 	}
 
 	return &corev1.Service{
@@ -761,66 +799,112 @@ func NewServiceForCR(cr *opensearchv1.OpenSearchCluster) *corev1.Service {
 			Annotations: cr.Spec.General.Annotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Ports: func() []corev1.ServicePort {
-				ports := []corev1.ServicePort{
-					{
-						Name:     "http",
-						Protocol: "TCP",
-						Port:     cr.Spec.General.HttpPort,
-						TargetPort: intstr.IntOrString{
-							IntVal: cr.Spec.General.HttpPort,
-						},
-						AppProtocol: &httpAppProtocol,
-					},
-					{
-						Name:     "transport",
-						Protocol: "TCP",
-						Port:     9300,
-						TargetPort: intstr.IntOrString{
-							IntVal: 9300,
-							StrVal: "9300",
-						},
-					},
-					{
-						Name:     "metrics",
-						Protocol: "TCP",
-						Port:     9600,
-						TargetPort: intstr.IntOrString{
-							IntVal: 9600,
-							StrVal: "9600",
-						},
-					},
-					{
-						Name:     "rca",
-						Protocol: "TCP",
-						Port:     9650,
-						TargetPort: intstr.IntOrString{
-							IntVal: 9650,
-							StrVal: "9650",
-						},
-					},
-				}
-				// Add gRPC port if enabled
-				if grpcPort := getGrpcPort(cr); grpcPort > 0 {
-					grpcAppProtocol := "grpc"
-					ports = append(ports, corev1.ServicePort{
-						Name:     "grpc",
-						Protocol: "TCP",
-						Port:     grpcPort,
-						TargetPort: intstr.IntOrString{
-							IntVal: grpcPort,
-						},
-						AppProtocol: &grpcAppProtocol,
-					})
-				}
-				return ports
-			}(),
-			Selector: labels,
+			Ports:    clientServicePorts(cr),
+			Selector: selector,
 			Type:     "",
 		},
 	}
 }
 
+// NewAdditionalServiceForCR builds a client service selecting the pods of the nodepools matched by the service config
+func NewAdditionalServiceForCR(cr *opensearchv1.OpenSearchCluster, service *opensearchv1.AdditionalServiceConfig) *corev1.Service {
+	labels := map[string]string{}
+	for k, v := range service.Labels {
+		labels[k] = v
+	}
+	labels[helpers.ClusterLabel] = cr.Name
+	labels[helpers.AdditionalServiceLabel] = service.Name
+
+	annotations := make(map[string]string)
+	for k, v := range cr.Spec.General.Annotations {
+		annotations[k] = v
+	}
+	for k, v := range service.Annotations {
+		annotations[k] = v
+	}
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        service.Name,
+			Namespace:   cr.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: clientServicePorts(cr),
+			Selector: map[string]string{
+				helpers.ClusterLabel:                       cr.Name,
+				helpers.ServiceSelectorLabel(service.Name): "true",
+			},
+			Type: service.Type,
+		},
+	}
+}
+
+func clientServicePorts(cr *opensearchv1.OpenSearchCluster) []corev1.ServicePort {
+	httpAppProtocol := "https"
+	if !helpers.IsHttpTlsEnabled(cr) {
+		httpAppProtocol = "http"
+	}
+
+	ports := []corev1.ServicePort{
+		{
+			Name:     "http",
+			Protocol: "TCP",
+			Port:     cr.Spec.General.HttpPort,
+			TargetPort: intstr.IntOrString{
+				IntVal: cr.Spec.General.HttpPort,
+			},
+			AppProtocol: &httpAppProtocol,
+		},
+		{
+			Name:     "transport",
+			Protocol: "TCP",
+			Port:     9300,
+			TargetPort: intstr.IntOrString{
+				IntVal: 9300,
+				StrVal: "9300",
+			},
+		},
+		{
+			Name:     "metrics",
+			Protocol: "TCP",
+			Port:     9600,
+			TargetPort: intstr.IntOrString{
+				IntVal: 9600,
+				StrVal: "9600",
+			},
+		},
+		{
+			Name:     "rca",
+			Protocol: "TCP",
+			Port:     9650,
+			TargetPort: intstr.IntOrString{
+				IntVal: 9650,
+				StrVal: "9650",
+			},
+		},
+	}
+	// Add gRPC port if enabled
+	if grpcPort := getGrpcPort(cr); grpcPort > 0 {
+		grpcAppProtocol := "grpc"
+		ports = append(ports, corev1.ServicePort{
+			Name:     "grpc",
+			Protocol: "TCP",
+			Port:     grpcPort,
+			TargetPort: intstr.IntOrString{
+				IntVal: grpcPort,
+			},
+			AppProtocol: &grpcAppProtocol,
+		})
+	}
+	return ports
+}
+
 func NewDiscoveryServiceForCR(cr *opensearchv1.OpenSearchCluster) *corev1.Service {
 	labels := map[string]string{
 		helpers.ClusterLabel: cr.Name,
@@ -898,6 +982,11 @@ func NewBootstrapPod(
 		helpers.ClusterLabel: cr.Name,
 	}
 
+	// Keep the bootstrap pod reachable through the cluster service during the initial setup
+	if helpers.ClientServiceFiltered(cr) {
+		labels[helpers.ServiceSelectorLabel(cr.Spec.General.ServiceName)] = "true"
+	}
+
 	// Merge Bootstrap.Labels into labels
 	if cr.Spec.Bootstrap.Labels != nil {
 		for k, v := range cr.Spec.Bootstrap.Labels {
@@ -910,9 +999,10 @@ func NewBootstrapPod(
 	jvmHeapSizeSettings := helpers.CalculateJvmHeapSizeSettings(cr.Spec.Bootstrap.Resources.Requests.Memory())
 	jvm := helpers.AppendJvmHeapSizeSettings(cr.Spec.Bootstrap.Jvm, jvmHeapSizeSettings)
 
+	vendor := helpers.VendorForCluster(cr)
 	image := helpers.ResolveImage(cr, nil)
 	initHelperImage := helpers.ResolveInitHelperImage(cr)
-	masterRole := helpers.ResolveClusterManagerRole(cr.Spec.General.Version)
+	masterRole := vendor.ClusterManagerRole(cr.Spec.General.Version)
 
 	probe := corev1.Probe{
 		PeriodSeconds:       20,
@@ -934,7 +1024,7 @@ func NewBootstrapPod(
 		},
 	})
 
-	opensearchHome := cr.Spec.General.GetOpenSearchHome()
+	opensearchHome := helpers.OpenSearchHome(cr)
 	volumeMounts = append(volumeMounts, corev1.VolumeMount{
 		Name:      "data",
 		MountPath: opensearchHome + "/data",
@@ -992,6 +1082,9 @@ func NewBootstrapPod(
 		},
 	})
 
+	// Credentials of the external audit cluster, referenced in opensearch.yml
+	env = append(env, helpers.AuditEnv(cr)...)
+
 	// Add Bootstrap.Env
 	if cr.Spec.Bootstrap.Env != nil {
 		env = append(env, cr.Spec.Bootstrap.Env...)
@@ -1119,7 +1212,7 @@ func NewBootstrapPod(
 		hostAliases = cr.Spec.Bootstrap.HostAliases
 	}
 
-	startUpCommand := "./opensearch-docker-entrypoint.sh"
+	startUpCommand := vendor.Entrypoint()
 
 	// Use General.PluginsList by default, override with Bootstrap.PluginsList if set
 	pluginslist := cr.Spec.General.PluginsList
@@ -1127,7 +1220,7 @@ func NewBootstrapPod(
 		pluginslist = cr.Spec.Bootstrap.PluginsList
 	}
 	pluginslist = helpers.RemoveDuplicateStrings(pluginslist)
-	mainCommand := helpers.BuildMainCommand("./bin/opensearch-plugin", pluginslist, true, startUpCommand)
+	mainCommand := helpers.BuildMainCommand(vendor.PluginInstaller(), pluginslist, true, startUpCommand)
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        BootstrapPodName(cr),
@@ -1241,6 +1334,14 @@ func URLForCluster(cr *opensearchv1.OpenSearchCluster) string {
 	return helpers.ClusterURL(cr)
 }
 
+// URLForDashboards returns the URL Dashboards connects to, either the cluster service or the configured additional service
+func URLForDashboards(cr *opensearchv1.OpenSearchCluster) string {
+	if cr.Spec.Dashboards.OpensearchService != "" {
+		return helpers.ServiceURL(cr, cr.Spec.Dashboards.OpensearchService)
+	}
+	return URLForCluster(cr)
+}
+
 func PasswordSecret(cr *opensearchv1.OpenSearchCluster, username, password string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1258,8 +1359,13 @@ func DnsOfService(cr *opensearchv1.OpenSearchCluster) string {
 	return fmt.Sprintf("%s.%s", cr.Spec.General.ServiceName, cr.Namespace)
 }
 
+// StsName returns the name of the StatefulSet currently serving the node pool
 func StsName(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) string {
-	return cr.Name + "-" + nodePool.Component
+	return helpers.NodePoolStsName(cr, nodePool.Component)
+}
+
+func OpenSearchNodePoolName(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) string {
+	return fmt.Sprintf("%s-%s", cr.Name, nodePool.Component)
 }
 
 func DiscoveryServiceName(cr *opensearchv1.OpenSearchCluster) string {
@@ -1445,8 +1551,9 @@ func NewSecurityconfigUpdateJob(
 
 func AllMastersReady(ctx context.Context, k8sClient client.Client, cr *opensearchv1.OpenSearchCluster) bool {
 	wrappedClient := k8s.NewK8sClient(k8sClient, ctx)
+	vendor := helpers.VendorForCluster(cr)
 	for _, nodePool := range cr.Spec.NodePools {
-		masterRole := helpers.ResolveClusterManagerRole(cr.Spec.General.Version)
+		masterRole := vendor.ClusterManagerRole(cr.Spec.General.Version)
 		if helpers.ContainsString(helpers.MapClusterRoles(nodePool.Roles, cr.Spec.General.Version), masterRole) {
 			sts := &appsv1.StatefulSet{}
 			if err := k8sClient.Get(ctx, types.NamespacedName{
@@ -202,6 +202,17 @@ var _ = Describe("Builders", func() {
 				"testAnnotationKey":             "testAnnotationValue",
 			}))
 		})
+		It("should add the requested restart to the selected nodepools", func() {
+			clusterObject := ClusterDescWithVersion("1.3.0")
+			clusterObject.Annotations = map[string]string{
+				helpers.RestartAtAnnotation:        "2024-06-01T10:00:00Z",
+				helpers.RestartNodePoolsAnnotation: "masters",
+			}
+			masters := NewSTSForNodePool("foobar", &clusterObject, opensearchv1.NodePool{Component: "masters"}, "foobar", nil, nil)
+			Expect(masters.Spec.Template.Annotations).To(HaveKeyWithValue(helpers.RestartAtAnnotation, "2024-06-01T10:00:00Z"))
+			data := NewSTSForNodePool("foobar", &clusterObject, opensearchv1.NodePool{Component: "data"}, "foobar", nil, nil)
+			Expect(data.Spec.Template.Annotations).ToNot(HaveKey(helpers.RestartAtAnnotation))
+		})
 		It("should have a priority class name added to the node", func() {
 			clusterObject := ClusterDescWithVersion("1.3.0")
 			nodePool := opensearchv1.NodePool{
@@ -621,6 +632,21 @@ var _ = Describe("Builders", func() {
 			}))
 		})
 
+		It("should pass the credentials of the external audit cluster", func() {
+			clusterObject := ClusterDescWithVersion("2.2.1")
+			clusterObject.Spec.Security = &opensearchv1.Security{Audit: &opensearchv1.AuditConfig{
+				Type: "external_opensearch",
+				External: &opensearchv1.AuditExternalConfig{
+					HttpEndpoints:     []string{"audit-cluster:9200"},
+					CredentialsSecret: &corev1.LocalObjectReference{Name: "audit-credentials"},
+				},
+			}}
+			result := NewBootstrapPod(&clusterObject, nil, nil)
+
+			Expect(result.Spec.Containers[0].Env).To(ContainElements(helpers.AuditEnv(&clusterObject)))
+			Expect(helpers.AuditEnv(&clusterObject)).To(HaveLen(2))
+		})
+
 		It("should apply bootstrap pod annotations", func() {
 			clusterObject := ClusterDescWithVersion("2.2.1")
 			expectedAnnotations := map[string]string{
@@ -1694,4 +1720,49 @@ var _ = Describe("Builders", func() {
 			Expect(pod.Spec.HostAliases).To(Equal([]corev1.HostAlias{bootstrapHostAlias}))
 		})
 	})
+
+	When("selecting nodepools for the client services", func() {
+		It("should only select nodepools that did not opt out of the cluster service", func() {
+			clusterObject := ClusterDescWithVersion("2.2.1")
+			clusterObject.Name = "foobar"
+			clusterObject.Spec.General.ServiceName = "foobar"
+			clusterObject.Spec.NodePools = []opensearchv1.NodePool{
+				{Component: "masters", Replicas: 3, Roles: []string{"cluster_manager"}, ClientService: ptr.To(false)},
+				{Component: "coordinators", Replicas: 2, Roles: []string{}},
+			}
+
+			service := NewServiceForCR(&clusterObject)
+			Expect(service.Spec.Selector).To(HaveKeyWithValue(helpers.ServiceSelectorLabel("foobar"), "true"))
+
+			Expect(helpers.ServiceLabelsForNodePool(&clusterObject, &clusterObject.Spec.NodePools[0])).NotTo(HaveKey(helpers.ServiceSelectorLabel("foobar")))
+			Expect(helpers.ServiceLabelsForNodePool(&clusterObject, &clusterObject.Spec.NodePools[1])).To(HaveKeyWithValue(helpers.ServiceSelectorLabel("foobar"), "true"))
+			coordinators := NewSTSForNodePool("foobar", &clusterObject, clusterObject.Spec.NodePools[1], "foobar", nil, nil)
+			Expect(coordinators.Spec.Template.Labels).NotTo(HaveKey(helpers.ServiceSelectorLabel("foobar")))
+		})
+
+		It("should create additional services for role groups", func() {
+			clusterObject := ClusterDescWithVersion("2.2.1")
+			clusterObject.Name = "foobar"
+			clusterObject.Spec.General.ServiceName = "foobar"
+			clusterObject.Spec.General.AdditionalServices = []opensearchv1.AdditionalServiceConfig{
+				{Name: "foobar-coordinating", Roles: []string{helpers.CoordinatingRole}, Type: corev1.ServiceTypeLoadBalancer},
+			}
+			clusterObject.Spec.NodePools = []opensearchv1.NodePool{
+				{Component: "masters", Replicas: 3, Roles: []string{"cluster_manager"}},
+				{Component: "coordinators", Replicas: 2, Roles: []string{}},
+			}
+
+			service := NewAdditionalServiceForCR(&clusterObject, &clusterObject.Spec.General.AdditionalServices[0])
+			Expect(service.Name).To(Equal("foobar-coordinating"))
+			Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+			Expect(service.Spec.Selector).To(HaveKeyWithValue(helpers.ServiceSelectorLabel("foobar-coordinating"), "true"))
+			Expect(service.Labels).To(HaveKeyWithValue(helpers.AdditionalServiceLabel, "foobar-coordinating"))
+
+			Expect(helpers.ServiceLabelsForNodePool(&clusterObject, &clusterObject.Spec.NodePools[0])).NotTo(HaveKey(helpers.ServiceSelectorLabel("foobar-coordinating")))
+			Expect(helpers.ServiceLabelsForNodePool(&clusterObject, &clusterObject.Spec.NodePools[1])).To(HaveKeyWithValue(helpers.ServiceSelectorLabel("foobar-coordinating"), "true"))
+			coordinators := NewSTSForNodePool("foobar", &clusterObject, clusterObject.Spec.NodePools[1], "foobar", nil, nil)
+			Expect(coordinators.Spec.Template.Labels).NotTo(HaveKey(helpers.ServiceSelectorLabel("foobar-coordinating")))
+			Expect(NewServiceForCR(&clusterObject).Spec.Selector).To(Equal(map[string]string{helpers.ClusterLabel: "foobar"}))
+		})
+	})
 })
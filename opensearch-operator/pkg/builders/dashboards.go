@@ -45,7 +45,7 @@ func NewDashboardsDeploymentForCR(cr *opensearchv1.OpenSearchCluster, volumes []
 	env := []corev1.EnvVar{
 		{
 			Name:  "OPENSEARCH_HOSTS",
-			Value: URLForCluster(cr),
+			Value: URLForDashboards(cr),
 		},
 		{
 			Name:  "SERVER_HOST",
@@ -68,6 +68,10 @@ func NewDashboardsDeploymentForCR(cr *opensearchv1.OpenSearchCluster, volumes []
 	}
 	env = append(env, corev1.EnvVar{Name: "OPENSEARCH_USERNAME", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "username"}}})
 	env = append(env, corev1.EnvVar{Name: "OPENSEARCH_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "password"}}})
+	if auth := helpers.Authentication(cr); auth != nil && auth.OpenID != nil && auth.OpenID.ClientSecret != nil {
+		// Referenced as ${OPENSEARCH_OPENID_CLIENT_SECRET} in opensearch_dashboards.yml to keep it out of the config map
+		env = append(env, corev1.EnvVar{Name: helpers.OpenIDClientSecretEnv, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: auth.OpenID.ClientSecret}})
+	}
 
 	labels := map[string]string{
 		"opensearch.cluster.dashboards": cr.Name,
@@ -0,0 +1,192 @@
+package builders
+
+import (
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	IngressKindIngress   = "Ingress"
+	IngressKindHTTPRoute = "HTTPRoute"
+	IngressKindTLSRoute  = "TLSRoute"
+)
+
+var (
+	HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: IngressKindHTTPRoute}
+	TLSRouteGVK  = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: IngressKindTLSRoute}
+)
+
+// IngressBackend identifies the service an ingress config routes to
+type IngressBackend struct {
+	Name        string
+	Namespace   string
+	ServiceName string
+	Port        int32
+	Labels      map[string]string
+}
+
+// OpenSearchIngressBackend routes to the cluster service
+func OpenSearchIngressBackend(cr *opensearchv1.OpenSearchCluster) IngressBackend {
+	return IngressBackend{
+		Name:        cr.Spec.General.ServiceName,
+		Namespace:   cr.Namespace,
+		ServiceName: cr.Spec.General.ServiceName,
+		Port:        cr.Spec.General.HttpPort,
+		Labels:      map[string]string{helpers.ClusterLabel: cr.Name},
+	}
+}
+
+// DashboardsIngressBackend routes to the dashboards service
+func DashboardsIngressBackend(cr *opensearchv1.OpenSearchCluster) IngressBackend {
+	return IngressBackend{
+		Name:        cr.Spec.General.ServiceName + "-dashboards",
+		Namespace:   cr.Namespace,
+		ServiceName: cr.Spec.General.ServiceName + "-dashboards",
+		Port:        5601,
+		Labels:      map[string]string{"opensearch.cluster.dashboards": cr.Name},
+	}
+}
+
+func ingressObjectMeta(config *opensearchv1.IngressConfig, backend IngressBackend) metav1.ObjectMeta {
+	labels := map[string]string{}
+	for k, v := range config.Labels {
+		labels[k] = v
+	}
+	for k, v := range backend.Labels {
+		labels[k] = v
+	}
+	return metav1.ObjectMeta{
+		Name:        backend.Name,
+		Namespace:   backend.Namespace,
+		Labels:      labels,
+		Annotations: config.Annotations,
+	}
+}
+
+func ingressPath(config *opensearchv1.IngressConfig) string {
+	if config.Path == "" {
+		return "/"
+	}
+	return config.Path
+}
+
+// NewIngress builds a networking.k8s.io/v1 Ingress routing the configured hosts to the backend service
+func NewIngress(config *opensearchv1.IngressConfig, backend IngressBackend) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	ruleValue := networkingv1.IngressRuleValue{
+		HTTP: &networkingv1.HTTPIngressRuleValue{
+			Paths: []networkingv1.HTTPIngressPath{
+				{
+					Path:     ingressPath(config),
+					PathType: &pathType,
+					Backend: networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: backend.ServiceName,
+							Port: networkingv1.ServiceBackendPort{Number: backend.Port},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var rules []networkingv1.IngressRule
+	for _, host := range config.Hosts {
+		rules = append(rules, networkingv1.IngressRule{Host: host, IngressRuleValue: ruleValue})
+	}
+	if len(rules) == 0 {
+		rules = append(rules, networkingv1.IngressRule{IngressRuleValue: ruleValue})
+	}
+
+	var tls []networkingv1.IngressTLS
+	if config.TlsSecret != nil && config.TlsSecret.Name != "" {
+		tls = append(tls, networkingv1.IngressTLS{Hosts: config.Hosts, SecretName: config.TlsSecret.Name})
+	}
+
+	return &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: ingressObjectMeta(config, backend),
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: config.IngressClassName,
+			Rules:            rules,
+			TLS:              tls,
+		},
+	}
+}
+
+// NewGatewayRoute builds a Gateway API HTTPRoute or TLSRoute routing the configured hosts to the backend service.
+// The routes are built as unstructured objects so the operator does not depend on the Gateway API CRDs being installed.
+func NewGatewayRoute(config *opensearchv1.IngressConfig, backend IngressBackend) *unstructured.Unstructured {
+	gvk := HTTPRouteGVK
+	if config.Kind == IngressKindTLSRoute {
+		gvk = TLSRouteGVK
+	}
+
+	parentRefs := make([]interface{}, 0, len(config.ParentRefs))
+	for _, ref := range config.ParentRefs {
+		parentRef := map[string]interface{}{"name": ref.Name}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	rule := map[string]interface{}{
+		"backendRefs": []interface{}{
+			map[string]interface{}{
+				"name": backend.ServiceName,
+				"port": int64(backend.Port),
+			},
+		},
+	}
+	if gvk.Kind == IngressKindHTTPRoute {
+		rule["matches"] = []interface{}{
+			map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":  "PathPrefix",
+					"value": ingressPath(config),
+				},
+			},
+		}
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules":      []interface{}{rule},
+	}
+	if len(config.Hosts) > 0 {
+		hostnames := make([]interface{}, 0, len(config.Hosts))
+		for _, host := range config.Hosts {
+			hostnames = append(hostnames, host)
+		}
+		spec["hostnames"] = hostnames
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	route.SetGroupVersionKind(gvk)
+	meta := ingressObjectMeta(config, backend)
+	route.SetName(meta.Name)
+	route.SetNamespace(meta.Namespace)
+	route.SetLabels(meta.Labels)
+	route.SetAnnotations(meta.Annotations)
+	return route
+}
+
+// NewEmptyGatewayRoute returns a route of the given kind that only identifies the object, used to delete routes
+func NewEmptyGatewayRoute(gvk schema.GroupVersionKind, backend IngressBackend) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(gvk)
+	route.SetName(backend.Name)
+	route.SetNamespace(backend.Namespace)
+	return route
+}
@@ -0,0 +1,87 @@
+package builders
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Builders", func() {
+	When("building ingresses", func() {
+		clusterName := "ingress-cluster"
+		spec := opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{ServiceName: clusterName, HttpPort: 9200},
+			},
+		}
+
+		It("should route the hosts to the cluster service", func() {
+			config := &opensearchv1.IngressConfig{
+				Enable:           true,
+				Hosts:            []string{"opensearch.example.com"},
+				IngressClassName: ptr.To("nginx"),
+				TlsSecret:        &corev1.LocalObjectReference{Name: "opensearch-tls"},
+				Annotations:      map[string]string{"testAnnotationKey": "testValue"},
+			}
+			result := NewIngress(config, OpenSearchIngressBackend(&spec))
+			Expect(result.Name).To(Equal(clusterName))
+			Expect(result.Annotations).To(HaveKeyWithValue("testAnnotationKey", "testValue"))
+			Expect(result.Spec.IngressClassName).To(Equal(ptr.To("nginx")))
+			Expect(result.Spec.Rules).To(HaveLen(1))
+			Expect(result.Spec.Rules[0].Host).To(Equal("opensearch.example.com"))
+			path := result.Spec.Rules[0].HTTP.Paths[0]
+			Expect(path.Path).To(Equal("/"))
+			Expect(path.Backend.Service.Name).To(Equal(clusterName))
+			Expect(path.Backend.Service.Port.Number).To(BeEquivalentTo(9200))
+			Expect(result.Spec.TLS).To(HaveLen(1))
+			Expect(result.Spec.TLS[0].SecretName).To(Equal("opensearch-tls"))
+		})
+
+		It("should build an HTTPRoute for the dashboards service", func() {
+			config := &opensearchv1.IngressConfig{
+				Enable:     true,
+				Kind:       IngressKindHTTPRoute,
+				Hosts:      []string{"dashboards.example.com"},
+				Path:       "/dashboards",
+				ParentRefs: []opensearchv1.GatewayParentRef{{Name: "gateway", Namespace: "infra", SectionName: "https"}},
+			}
+			result := NewGatewayRoute(config, DashboardsIngressBackend(&spec))
+			Expect(result.GroupVersionKind()).To(Equal(HTTPRouteGVK))
+			Expect(result.GetName()).To(Equal(clusterName + "-dashboards"))
+
+			hostnames, _, _ := unstructured.NestedStringSlice(result.Object, "spec", "hostnames")
+			Expect(hostnames).To(Equal([]string{"dashboards.example.com"}))
+			parentRefs, _, _ := unstructured.NestedSlice(result.Object, "spec", "parentRefs")
+			Expect(parentRefs).To(Equal([]interface{}{
+				map[string]interface{}{"name": "gateway", "namespace": "infra", "sectionName": "https"},
+			}))
+			rules, _, _ := unstructured.NestedSlice(result.Object, "spec", "rules")
+			Expect(rules).To(HaveLen(1))
+			rule := rules[0].(map[string]interface{})
+			Expect(rule["backendRefs"]).To(Equal([]interface{}{
+				map[string]interface{}{"name": clusterName + "-dashboards", "port": int64(5601)},
+			}))
+			Expect(rule["matches"]).To(Equal([]interface{}{
+				map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/dashboards"}},
+			}))
+		})
+
+		It("should build a TLSRoute without path matches", func() {
+			config := &opensearchv1.IngressConfig{
+				Enable:     true,
+				Kind:       IngressKindTLSRoute,
+				Hosts:      []string{"opensearch.example.com"},
+				ParentRefs: []opensearchv1.GatewayParentRef{{Name: "gateway"}},
+			}
+			result := NewGatewayRoute(config, OpenSearchIngressBackend(&spec))
+			Expect(result.GroupVersionKind()).To(Equal(TLSRouteGVK))
+			rules, _, _ := unstructured.NestedSlice(result.Object, "spec", "rules")
+			Expect(rules[0].(map[string]interface{})).NotTo(HaveKey("matches"))
+		})
+	})
+})
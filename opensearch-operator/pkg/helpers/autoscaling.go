@@ -0,0 +1,140 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+)
+
+const (
+	AutoscalingScaleUp   = "ScaleUp"
+	AutoscalingScaleDown = "ScaleDown"
+
+	defaultScaleUpStabilizationWindow   = 3 * time.Minute
+	defaultScaleDownStabilizationWindow = 15 * time.Minute
+	defaultAutoscalingCooldown          = 10 * time.Minute
+)
+
+// NodePoolAutoscaling returns the autoscaling configuration of the nodepool, or nil if the nodepool is not autoscaled
+func NodePoolAutoscaling(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) *opensearchv1.NodePoolAutoscaling {
+	if !cr.Spec.ConfMgmt.AutoScaler || nodePool.ScaleTarget {
+		return nil
+	}
+	return nodePool.Autoscaling
+}
+
+// NodePoolReplicas returns the number of replicas the nodepool should have. For autoscaled nodepools this is the
+// number of replicas decided by the autoscaler, limited to minReplicas and maxReplicas. For nodepools with a scale
+// target it is the number of replicas of their OpenSearchNodePool.
+func NodePoolReplicas(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) int32 {
+	status, found := FindAutoscalingStatus(cr.Status.Autoscaling, nodePool.Component)
+	if nodePool.ScaleTarget {
+		if found && status.DesiredReplicas > 0 {
+			return status.DesiredReplicas
+		}
+		return nodePool.Replicas
+	}
+	autoscaling := NodePoolAutoscaling(cr, nodePool)
+	if autoscaling == nil {
+		return nodePool.Replicas
+	}
+	replicas := nodePool.Replicas
+	if found {
+		replicas = status.DesiredReplicas
+	}
+	return max(min(replicas, autoscaling.MaxReplicas), autoscaling.MinReplicas)
+}
+
+// NodePoolMaxReplicas returns the highest number of replicas the nodepool can be scaled to, so that resources for new
+// nodes like their certificates exist before the autoscaler adds them
+func NodePoolMaxReplicas(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) int32 {
+	if autoscaling := NodePoolAutoscaling(cr, nodePool); autoscaling != nil {
+		return max(autoscaling.MaxReplicas, NodePoolReplicas(cr, nodePool))
+	}
+	return NodePoolReplicas(cr, nodePool)
+}
+
+// FindAutoscalingStatus returns the autoscaling status of the nodepool with the given component
+func FindAutoscalingStatus(statuses []opensearchv1.AutoscalingStatus, component string) (opensearchv1.AutoscalingStatus, bool) {
+	for _, status := range statuses {
+		if status.Component == component {
+			return status, true
+		}
+	}
+	return opensearchv1.AutoscalingStatus{}, false
+}
+
+// ValidateAutoscaling checks the autoscaling configuration of a nodepool
+func ValidateAutoscaling(autoscaling *opensearchv1.NodePoolAutoscaling) error {
+	if autoscaling.MinReplicas < 1 {
+		return fmt.Errorf("minReplicas must be at least 1")
+	}
+	if autoscaling.MaxReplicas < autoscaling.MinReplicas {
+		return fmt.Errorf("maxReplicas must not be less than minReplicas")
+	}
+	if len(autoscaling.Policies) == 0 {
+		return fmt.Errorf("at least one policy is required")
+	}
+	for _, policy := range autoscaling.Policies {
+		if policy.ScaleDownThreshold != nil && *policy.ScaleDownThreshold > policy.ScaleUpThreshold {
+			return fmt.Errorf("scaleDownThreshold of the %s policy must not exceed its scaleUpThreshold", policy.Metric)
+		}
+	}
+	return nil
+}
+
+// AutoscalingWindows returns the scale-up and scale-down stabilization windows and the cooldown of the nodepool
+func AutoscalingWindows(autoscaling *opensearchv1.NodePoolAutoscaling) (scaleUp, scaleDown, cooldown time.Duration) {
+	scaleUp, scaleDown, cooldown = defaultScaleUpStabilizationWindow, defaultScaleDownStabilizationWindow, defaultAutoscalingCooldown
+	if autoscaling.ScaleUpStabilizationWindow != nil {
+		scaleUp = autoscaling.ScaleUpStabilizationWindow.Duration
+	}
+	if autoscaling.ScaleDownStabilizationWindow != nil {
+		scaleDown = autoscaling.ScaleDownStabilizationWindow.Duration
+	}
+	if autoscaling.Cooldown != nil {
+		cooldown = autoscaling.Cooldown.Duration
+	}
+	return
+}
+
+// NodeStatsMetric reads the value of an autoscaling metric from the stats of a node. SearchRejections is the total
+// number of rejected requests of the search thread pool, the caller has to compute the increase.
+func NodeStatsMetric(stats responses.NodeStatResponse, metric opensearchv1.AutoscalingMetric) (float64, bool) {
+	switch metric {
+	case opensearchv1.AutoscalingMetricJvmHeap:
+		return statsNumber(stats.Jvm, "mem", "heap_used_percent")
+	case opensearchv1.AutoscalingMetricCpu:
+		return statsNumber(stats.Os, "cpu", "percent")
+	case opensearchv1.AutoscalingMetricDiskUsage:
+		total, ok := statsNumber(stats.Fs, "total", "total_in_bytes")
+		if !ok || total == 0 {
+			return 0, false
+		}
+		available, ok := statsNumber(stats.Fs, "total", "available_in_bytes")
+		if !ok {
+			return 0, false
+		}
+		return (total - available) * 100 / total, true
+	case opensearchv1.AutoscalingMetricSearchRejections:
+		threadPool, ok := stats.ThreadPool["search"]
+		return float64(threadPool.Rejected), ok
+	}
+	return 0, false
+}
+
+// statsNumber reads a number from nested objects of the node stats
+func statsNumber(stats map[string]interface{}, path ...string) (float64, bool) {
+	var value interface{} = stats
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return 0, false
+		}
+		value = object[key]
+	}
+	number, ok := value.(float64)
+	return number, ok
+}
@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Autoscaling", func() {
+	newCluster := func(autoScaler bool, desiredReplicas int32) (*opensearchv1.OpenSearchCluster, *opensearchv1.NodePool) {
+		cluster := &opensearchv1.OpenSearchCluster{
+			Spec: opensearchv1.ClusterSpec{
+				ConfMgmt: opensearchv1.ConfMgmt{AutoScaler: autoScaler},
+				NodePools: []opensearchv1.NodePool{{
+					Component: "data",
+					Replicas:  3,
+					Autoscaling: &opensearchv1.NodePoolAutoscaling{
+						MinReplicas: 2,
+						MaxReplicas: 5,
+						Policies:    []opensearchv1.AutoscalingPolicy{{Metric: opensearchv1.AutoscalingMetricCpu, ScaleUpThreshold: 80}},
+					},
+				}},
+			},
+		}
+		if desiredReplicas > 0 {
+			cluster.Status.Autoscaling = []opensearchv1.AutoscalingStatus{{Component: "data", DesiredReplicas: desiredReplicas}}
+		}
+		return cluster, &cluster.Spec.NodePools[0]
+	}
+
+	DescribeTable("should determine the replicas of a nodepool",
+		func(autoScaler bool, desiredReplicas int, expected int32) {
+			cluster, nodePool := newCluster(autoScaler, int32(desiredReplicas))
+			Expect(NodePoolReplicas(cluster, nodePool)).To(Equal(expected))
+		},
+		Entry("autoscaler disabled", false, 4, int32(3)),
+		Entry("no decision yet", true, 0, int32(3)),
+		Entry("decision of the autoscaler", true, 4, int32(4)),
+		Entry("decision above maxReplicas", true, 7, int32(5)),
+		Entry("decision below minReplicas", true, 1, int32(2)),
+	)
+
+	It("should prepare resources for the maximum number of replicas", func() {
+		cluster, nodePool := newCluster(true, 4)
+		Expect(NodePoolMaxReplicas(cluster, nodePool)).To(Equal(int32(5)))
+		cluster.Spec.ConfMgmt.AutoScaler = false
+		Expect(NodePoolMaxReplicas(cluster, nodePool)).To(Equal(int32(3)))
+	})
+
+	It("should follow the OpenSearchNodePool of nodepools with a scale target", func() {
+		cluster, nodePool := newCluster(true, 7)
+		nodePool.ScaleTarget = true
+		Expect(NodePoolAutoscaling(cluster, nodePool)).To(BeNil())
+		Expect(NodePoolReplicas(cluster, nodePool)).To(Equal(int32(7)))
+		Expect(NodePoolMaxReplicas(cluster, nodePool)).To(Equal(int32(7)))
+		cluster.Status.Autoscaling = nil
+		Expect(NodePoolReplicas(cluster, nodePool)).To(Equal(int32(3)))
+	})
+
+	It("should reject invalid configurations", func() {
+		_, nodePool := newCluster(true, 0)
+		autoscaling := nodePool.Autoscaling
+		Expect(ValidateAutoscaling(autoscaling)).To(Succeed())
+		autoscaling.MaxReplicas = 1
+		Expect(ValidateAutoscaling(autoscaling)).To(MatchError("maxReplicas must not be less than minReplicas"))
+		autoscaling.MaxReplicas = 5
+		autoscaling.Policies[0].ScaleDownThreshold = ptr.To[int32](90)
+		Expect(ValidateAutoscaling(autoscaling)).To(MatchError("scaleDownThreshold of the Cpu policy must not exceed its scaleUpThreshold"))
+	})
+
+	It("should read the metrics from the node stats", func() {
+		stats := responses.NodeStatResponse{
+			Jvm: map[string]interface{}{"mem": map[string]interface{}{"heap_used_percent": float64(75)}},
+			Os:  map[string]interface{}{"cpu": map[string]interface{}{"percent": float64(42)}},
+			Fs: map[string]interface{}{"total": map[string]interface{}{
+				"total_in_bytes":     float64(1000),
+				"available_in_bytes": float64(250),
+			}},
+			ThreadPool: map[string]responses.NodeStatThreadPool{"search": {Rejected: 12}},
+		}
+		for metric, expected := range map[opensearchv1.AutoscalingMetric]float64{
+			opensearchv1.AutoscalingMetricJvmHeap:          75,
+			opensearchv1.AutoscalingMetricCpu:              42,
+			opensearchv1.AutoscalingMetricDiskUsage:        75,
+			opensearchv1.AutoscalingMetricSearchRejections: 12,
+		} {
+			value, ok := NodeStatsMetric(stats, metric)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(expected))
+		}
+
+		_, ok := NodeStatsMetric(responses.NodeStatResponse{}, opensearchv1.AutoscalingMetricDiskUsage)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -8,15 +8,25 @@ import (
 const (
 	DashboardConfigName          = "opensearch_dashboards.yml"
 	DashboardChecksumName        = "checksum/dashboards.yml"
+	DashboardCertChecksumName    = "checksum/dashboards-cert"
 	ClusterLabel                 = "opensearch.org/opensearch-cluster"
 	OldClusterLabel              = "opster.io/opensearch-cluster"
 	JobLabel                     = "opensearch.org/opensearch-job"
 	NodePoolLabel                = "opensearch.org/opensearch-nodepool"
+	AdditionalServiceLabel       = "opensearch.org/opensearch-additional-service"
 	OsUserNameAnnotation         = "opensearchuser/name"
 	OsUserNamespaceAnnotation    = "opensearchuser/namespace"
+	RotateCaAnnotation           = "opensearch.org/rotate-ca"
+	RotatePasswordAnnotation     = "opensearch.org/rotate-password"
+	RestartAtAnnotation          = "opensearch.org/restart-at"
+	RestartNodePoolsAnnotation   = "opensearch.org/restart-nodepools"
+	FullRestartAtAnnotation      = "opensearch.org/full-restart-at"
+	PausedAnnotation             = "opensearch.org/paused"
 	DnsBaseEnvVariable           = "DNS_BASE"
 	ParallelRecoveryEnabled      = "PARALLEL_RECOVERY_ENABLED"
 	SkipInitContainerEnvVariable = "SKIP_INIT_CONTAINER"
+
+	CrossNamespaceConnectionSecretsEnvVariable = "CROSS_NAMESPACE_CONNECTION_SECRETS_ENABLED"
 )
 
 func SkipInitContainer() bool {
@@ -42,6 +52,22 @@ func ClusterDnsBase() string {
 	return env
 }
 
+// CrossNamespaceConnectionSecrets returns true if OpensearchUsers may write their connection secret to another
+// namespace. Disabled by default, as anyone allowed to create an OpensearchUser could otherwise write secrets to any
+// namespace the operator has access to.
+func CrossNamespaceConnectionSecrets() bool {
+	env, found := os.LookupEnv(CrossNamespaceConnectionSecretsEnvVariable)
+
+	if !found || len(env) == 0 {
+		return false
+	}
+	ok, err := strconv.ParseBool(env)
+	if err != nil {
+		return false
+	}
+	return ok
+}
+
 func ParallelRecoveryMode() bool {
 	env, found := os.LookupEnv(ParallelRecoveryEnabled)
 
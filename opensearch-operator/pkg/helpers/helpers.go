@@ -10,6 +10,7 @@ import (
 	"log"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	version "github.com/hashicorp/go-version"
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/tls"
 	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -138,6 +140,57 @@ func TlsCASecretRef(cluster *opensearchv1.OpenSearchCluster) corev1.LocalObjectR
 	return corev1.LocalObjectReference{}
 }
 
+// HttpCASecretName returns the name of the secret whose ca.crt contains the CAs clients have to trust to connect to the
+// HTTP layer of the cluster, or an empty string if HTTP TLS is disabled
+func HttpCASecretName(cluster *opensearchv1.OpenSearchCluster) string {
+	if !IsHttpTlsEnabled(cluster) {
+		return ""
+	}
+	tlsConfig := cluster.Spec.Security.Tls.Http
+	if tlsConfig.Generate || CertManagerEnabled(&tlsConfig.TlsCertificateConfig) {
+		return fmt.Sprintf("%s-http-cert", cluster.Name)
+	}
+	if tlsConfig.CaSecret.Name != "" {
+		return tlsConfig.CaSecret.Name
+	}
+	return tlsConfig.Secret.Name
+}
+
+// CertManagerEnabled reports whether the certificates of the config are issued by cert-manager
+func CertManagerEnabled(config *opensearchv1.TlsCertificateConfig) bool {
+	return config != nil && config.CertManager != nil && config.CertManager.Enable
+}
+
+// CertOptions returns the key and signature settings of the config for generating certificates
+func CertOptions(config *opensearchv1.TlsCertificateConfig) []tls.CertOption {
+	if config == nil {
+		return nil
+	}
+	opts := []tls.CertOption{
+		tls.WithKey(config.KeyAlgorithm, config.KeySize),
+		tls.WithSignatureHash(config.SignatureAlgorithm),
+	}
+	if config.CaDuration != nil {
+		opts = append(opts, tls.WithCAValidity(config.CaDuration.Duration))
+	}
+	return opts
+}
+
+// GeneratedCaCertConfig returns the config whose settings are used to generate the CA shared by all interfaces,
+// the transport config or, if there is none, the http config
+func GeneratedCaCertConfig(cluster *opensearchv1.OpenSearchCluster) *opensearchv1.TlsCertificateConfig {
+	if cluster == nil || cluster.Spec.Security == nil || cluster.Spec.Security.Tls == nil {
+		return nil
+	}
+	if cluster.Spec.Security.Tls.Transport != nil {
+		return &cluster.Spec.Security.Tls.Transport.TlsCertificateConfig
+	}
+	if cluster.Spec.Security.Tls.Http != nil {
+		return &cluster.Spec.Security.Tls.Http.TlsCertificateConfig
+	}
+	return nil
+}
+
 func SupportsHotReload(cluster *opensearchv1.OpenSearchCluster) bool {
 	return CheckVersionConstraint(
 		cluster,
@@ -184,35 +237,39 @@ func ClusterURL(cluster *opensearchv1.OpenSearchCluster) string {
 		return ""
 	}
 
-	namespace := cluster.Namespace
-	serviceName := cluster.Spec.General.ServiceName
-	httpPort := cluster.Spec.General.HttpPort
 	operatorClusterURL := cluster.Spec.General.OperatorClusterURL
-
-	if httpPort == 0 {
-		httpPort = 9200 // default port
-	}
-
-	protocol := "https"
-	// Check if HTTP TLS is enabled
-	if !IsHttpTlsEnabled(cluster) {
-		protocol = "http"
-	}
-
 	if operatorClusterURL != nil && *operatorClusterURL != "" {
-		return fmt.Sprintf("%s://%s:%d", protocol, *operatorClusterURL, httpPort)
+		return fmt.Sprintf("%s://%s:%d", clusterProtocol(cluster), *operatorClusterURL, clusterHttpPort(cluster))
 	}
 
-	// Default internal Kubernetes service DNS name
+	return ServiceURL(cluster, cluster.Spec.General.ServiceName)
+}
+
+// ServiceURL returns the internal Kubernetes URL of the given client service of the cluster
+func ServiceURL(cluster *opensearchv1.OpenSearchCluster, serviceName string) string {
 	return fmt.Sprintf("%s://%s.%s.svc.%s:%d",
-		protocol,
+		clusterProtocol(cluster),
 		serviceName,
-		namespace,
+		cluster.Namespace,
 		ClusterDnsBase(),
-		httpPort,
+		clusterHttpPort(cluster),
 	)
 }
 
+func clusterProtocol(cluster *opensearchv1.OpenSearchCluster) string {
+	if !IsHttpTlsEnabled(cluster) {
+		return "http"
+	}
+	return "https"
+}
+
+func clusterHttpPort(cluster *opensearchv1.OpenSearchCluster) int32 {
+	if cluster.Spec.General.HttpPort == 0 {
+		return 9200 // default port
+	}
+	return cluster.Spec.General.HttpPort
+}
+
 func GetField(v *appsv1.StatefulSetSpec, field string) interface{} {
 	r := reflect.ValueOf(v)
 	f := reflect.Indirect(r).FieldByName(field).Interface()
@@ -326,30 +383,50 @@ func EnsureAdminCredentialsSecret(k8sClient k8s.K8sClient, cr *opensearchv1.Open
 	return &createdSecret, true, nil
 }
 
-func BuildGeneratedSecurityConfigSecret(k8sClient k8s.K8sClient, cr *opensearchv1.OpenSearchCluster, adminSecret *corev1.Secret) (*corev1.Secret, error) {
+// BuildGeneratedSecurityConfigSecret builds the securityconfig that is applied to the cluster. If the provided files are
+// merged into the defaults, the source of every key is returned as well.
+func BuildGeneratedSecurityConfigSecret(k8sClient k8s.K8sClient, cr *opensearchv1.OpenSearchCluster, adminSecret *corev1.Secret) (*corev1.Secret, []opensearchv1.SecurityConfigSource, error) {
 	baseData, err := defaultSecurityconfigData()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	userData := map[string][]byte{}
 	if cr.Spec.Security != nil && cr.Spec.Security.Config != nil && cr.Spec.Security.Config.SecurityconfigSecret.Name != "" {
 		userSecret, err := k8sClient.GetSecret(cr.Spec.Security.Config.SecurityconfigSecret.Name, cr.Namespace)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		userData = userSecret.Data
+	}
+
+	var sources []opensearchv1.SecurityConfigSource
+	if IsSecurityConfigMergeEnabled(cr) {
+		sources, err = mergeSecurityConfig(baseData, userData)
+		if err != nil {
+			return nil, nil, err
 		}
-		for key, value := range userSecret.Data {
+	} else {
+		for key, value := range userData {
 			baseData[key] = append([]byte(nil), value...)
 		}
 	}
 
+	if err := applyAuthentication(k8sClient, cr, baseData); err != nil {
+		return nil, nil, err
+	}
+	if err := applyAudit(cr, baseData); err != nil {
+		return nil, nil, err
+	}
+
 	adminPassword, passwordExists := adminSecret.Data["password"]
 	if !passwordExists {
-		return nil, errors.New("admin credentials secret missing password field")
+		return nil, nil, errors.New("admin credentials secret missing password field")
 	}
 
 	dashboardsSecret, _, err := EnsureDashboardsCredentialsSecret(k8sClient, cr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	var dashboardsPassword []byte
 	if dashboardsSecret != nil {
@@ -358,12 +435,12 @@ func BuildGeneratedSecurityConfigSecret(k8sClient k8s.K8sClient, cr *opensearchv
 		}
 	}
 	if len(dashboardsPassword) == 0 {
-		return nil, errors.New("dashboards credentials secret missing password field")
+		return nil, nil, errors.New("dashboards credentials secret missing password field")
 	}
 
 	internalUsers, ok := baseData["internal_users.yml"]
 	if !ok {
-		return nil, errors.New("securityconfig missing internal_users.yml")
+		return nil, nil, errors.New("securityconfig missing internal_users.yml")
 	}
 
 	generatedName := GeneratedSecurityConfigSecretName(cr)
@@ -372,7 +449,7 @@ func BuildGeneratedSecurityConfigSecret(k8sClient k8s.K8sClient, cr *opensearchv
 	if err == nil {
 		existingGenerated = &existingSecret
 	} else if !k8serrors.IsNotFound(err) {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var adminHashOverride, dashboardsHashOverride string
@@ -394,7 +471,7 @@ func BuildGeneratedSecurityConfigSecret(k8sClient k8s.K8sClient, cr *opensearchv
 
 	internalUsers, err = applyUserHashes(internalUsers, adminPassword, adminHashOverride, dashboardsPassword, dashboardsHashOverride)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	baseData["internal_users.yml"] = internalUsers
 
@@ -407,7 +484,7 @@ func BuildGeneratedSecurityConfigSecret(k8sClient k8s.K8sClient, cr *opensearchv
 		Type: corev1.SecretTypeOpaque,
 	}
 
-	return secret, nil
+	return secret, sources, nil
 }
 
 func applyUserHashes(internalUserData []byte, adminPassword []byte, adminHashOverride string, dashboardsPassword []byte, dashboardsHashOverride string) ([]byte, error) {
@@ -676,6 +753,11 @@ func CountRunningPodsForNodePool(k8sClient k8s.K8sClient, cr *opensearchv1.OpenS
 	return numReadyPods, nil
 }
 
+// PodsForNodePool returns the pods of the given NodePool, including terminating ones
+func PodsForNodePool(k8sClient k8s.K8sClient, cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) ([]corev1.Pod, error) {
+	return listPodsForNodePool(k8sClient, cr, nodePool)
+}
+
 // ReadyReplicasForNodePool returns the number of ready replicas derived from the actual running pods.
 func ReadyReplicasForNodePool(k8sClient k8s.K8sClient, cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) (int32, error) {
 	numReadyPods, err := CountRunningPodsForNodePool(k8sClient, cr, nodePool)
@@ -757,16 +839,15 @@ func WaitForSTSStatus(ctx context.Context, k8sClient k8s.K8sClient, obj *appsv1.
 	return &result, err
 }
 
-// GetSTSForNodePool returns the corresponding sts for a given nodePool and cluster name
-func GetSTSForNodePool(k8sClient k8s.K8sClient, nodePool opensearchv1.NodePool, clusterName, clusterNamespace string) (*appsv1.StatefulSet, error) {
-	stsName := clusterName + "-" + nodePool.Component
-	existing, err := k8sClient.GetStatefulSet(stsName, clusterNamespace)
+// GetSTSForNodePool returns the corresponding sts for a given nodePool and cluster
+func GetSTSForNodePool(k8sClient k8s.K8sClient, nodePool opensearchv1.NodePool, cr *opensearchv1.OpenSearchCluster) (*appsv1.StatefulSet, error) {
+	existing, err := k8sClient.GetStatefulSet(NodePoolStsName(cr, nodePool.Component), cr.Namespace)
 	return &existing, err
 }
 
 // DeleteSTSForNodePool deletes the sts for the corresponding nodePool
-func DeleteSTSForNodePool(ctx context.Context, k8sClient k8s.K8sClient, nodePool opensearchv1.NodePool, clusterName, clusterNamespace string) error {
-	sts, err := GetSTSForNodePool(k8sClient, nodePool, clusterName, clusterNamespace)
+func DeleteSTSForNodePool(ctx context.Context, k8sClient k8s.K8sClient, nodePool opensearchv1.NodePool, cr *opensearchv1.OpenSearchCluster) error {
+	sts, err := GetSTSForNodePool(k8sClient, nodePool, cr)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			return nil
@@ -812,6 +893,74 @@ func HasManagerRole(nodePool *opensearchv1.NodePool) bool {
 	return ContainsString(nodePool.Roles, "master") || ContainsString(nodePool.Roles, "cluster_manager")
 }
 
+// ClusterPaused returns true if the reconciliation of the cluster is paused with spec.paused or the paused annotation
+func ClusterPaused(cr *opensearchv1.OpenSearchCluster) bool {
+	if cr.Spec.Paused {
+		return true
+	}
+	paused, err := strconv.ParseBool(cr.Annotations[PausedAnnotation])
+	return err == nil && paused
+}
+
+// RestartRequestForNodePool returns the value of the restart-at annotation of the cluster if the nodepool is listed in
+// the restart-nodepools annotation or the annotation is not set, an empty string otherwise
+func RestartRequestForNodePool(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) string {
+	request := cr.Annotations[RestartAtAnnotation]
+	if request == "" {
+		return ""
+	}
+	nodePools := strings.TrimSpace(cr.Annotations[RestartNodePoolsAnnotation])
+	if nodePools == "" {
+		return request
+	}
+	for _, component := range strings.Split(nodePools, ",") {
+		if strings.TrimSpace(component) == nodePool.Component {
+			return request
+		}
+	}
+	return ""
+}
+
+// FullRestartRequest returns the value of the full-restart-at annotation of the cluster if it requests a full restart
+// that was not started yet, an empty string otherwise
+func FullRestartRequest(cr *opensearchv1.OpenSearchCluster) string {
+	request := cr.Annotations[FullRestartAtAnnotation]
+	if request == "" || (cr.Status.FullRestart != nil && cr.Status.FullRestart.Request == request) {
+		return ""
+	}
+	return request
+}
+
+// FullRestartInProgress returns true if a full restart of the cluster was started and is not completed yet
+func FullRestartInProgress(status opensearchv1.ClusterStatus) bool {
+	if status.FullRestart == nil {
+		return false
+	}
+	switch status.FullRestart.Step {
+	case "", opensearchv1.FullRestartStepCompleted, opensearchv1.FullRestartStepRejected:
+		return false
+	}
+	return true
+}
+
+// FullRestartStoppingNodes returns true if the nodes of the cluster are being stopped for a full restart
+func FullRestartStoppingNodes(status opensearchv1.ClusterStatus) bool {
+	return status.FullRestart != nil && status.FullRestart.Step == opensearchv1.FullRestartStepStoppingNodes
+}
+
+// FullRestartStartingNodes returns true if the nodes of the cluster were stopped for a full restart and are started
+// again or recover their shards
+func FullRestartStartingNodes(status opensearchv1.ClusterStatus) bool {
+	if status.FullRestart == nil {
+		return false
+	}
+	switch status.FullRestart.Step {
+	case opensearchv1.FullRestartStepStartingNodes, opensearchv1.FullRestartStepRestoringAllocation, opensearchv1.FullRestartStepRecoveringShards:
+		return true
+	}
+	return false
+}
+
 func RemoveDuplicateStrings(strSlice []string) []string {
 	allKeys := make(map[string]bool)
 	list := []string{}
@@ -953,6 +1102,26 @@ func GetPodWithOlderRevision(k8sClient k8s.K8sClient, sts *appsv1.StatefulSet) (
 	return nil, nil
 }
 
+// GetPodsWithOlderRevision fetches all pods that are not having the updated revision.
+func GetPodsWithOlderRevision(k8sClient k8s.K8sClient, sts *appsv1.StatefulSet) ([]corev1.Pod, error) {
+	var pods []corev1.Pod
+	for i := int32(0); i < lo.FromPtrOr(sts.Spec.Replicas, 1); i++ {
+		podName := ReplicaHostName(*sts, i)
+		pod, err := k8sClient.GetPod(podName, sts.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		podRevision, ok := pod.Labels[stsRevisionLabel]
+		if !ok {
+			return nil, fmt.Errorf("pod %s has no revision label", podName)
+		}
+		if podRevision != sts.Status.UpdateRevision {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
 func GetDashboardsDeployment(k8sClient k8s.K8sClient, clusterName, clusterNamespace string) (*appsv1.Deployment, error) {
 	deploy, err := k8sClient.GetDeployment(clusterName+"-dashboards", clusterNamespace)
 	return &deploy, err
@@ -343,3 +343,190 @@ var _ = Describe("TlsCASecretRef", func() {
 		Expect(TlsCASecretRef(cluster).Name).To(BeEmpty())
 	})
 })
+
+var _ = Describe("NodePoolStsName", func() {
+	cluster := func(components ...opensearchv1.ComponentStatus) *opensearchv1.OpenSearchCluster {
+		return &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Status:     opensearchv1.ClusterStatus{ComponentsStatus: components},
+		}
+	}
+
+	It("should use the default name without a migration", func() {
+		Expect(NodePoolStsName(cluster(), "data")).To(Equal("test-data"))
+	})
+
+	It("should keep the old statefulset while a migration is in progress", func() {
+		cr := cluster(opensearchv1.ComponentStatus{
+			Component:   NodePoolMigrationComponent,
+			Status:      NodePoolMigrationDraining,
+			Description: "data",
+			Conditions:  NodePoolMigrationConditions("test-data", "test-data-abcde"),
+		})
+		Expect(NodePoolStsName(cr, "data")).To(Equal("test-data"))
+		Expect(IsNodePoolMigrationInProgress(cr.Status, "data")).To(BeTrue())
+	})
+
+	It("should use the shadow statefulset after a completed migration", func() {
+		cr := cluster(opensearchv1.ComponentStatus{
+			Component:   NodePoolMigrationComponent,
+			Status:      NodePoolMigrationCompleted,
+			Description: "data",
+			Conditions:  NodePoolMigrationConditions("test-data", "test-data-abcde"),
+		})
+		Expect(NodePoolStsName(cr, "data")).To(Equal("test-data-abcde"))
+		Expect(NodePoolStsName(cr, "masters")).To(Equal("test-masters"))
+	})
+
+	It("should derive distinct shadow names from the storage settings", func() {
+		modes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		first := NodePoolMigrationTargetName(cluster(), "data", StorageTypePVC, "standard", modes)
+		second := NodePoolMigrationTargetName(cluster(), "data", StorageTypePVC, "premium", modes)
+		Expect(first).To(HavePrefix("test-data-"))
+		Expect(first).NotTo(Equal(second))
+		Expect(first).To(Equal(NodePoolMigrationTargetName(cluster(), "data", StorageTypePVC, "standard", modes)))
+		Expect(first).ToNot(Equal(NodePoolMigrationTargetName(cluster(), "data", StorageTypeHostPath, "", nil)))
+	})
+
+	It("should list the pods of both statefulsets during a migration", func() {
+		cr := cluster(opensearchv1.ComponentStatus{
+			Component:   NodePoolMigrationComponent,
+			Status:      NodePoolMigrationDraining,
+			Description: "data",
+			Conditions:  NodePoolMigrationConditions("test-data", "test-data-abcde"),
+		})
+		nodePool := &opensearchv1.NodePool{Component: "data", Replicas: 2}
+		Expect(NodePoolPodNames(cr, nodePool)).To(ConsistOf("test-data-0", "test-data-1", "test-data-abcde-0", "test-data-abcde-1"))
+		Expect(NodePoolPodNames(cluster(), nodePool)).To(ConsistOf("test-data-0", "test-data-1"))
+	})
+})
+
+var _ = Describe("Vendor", func() {
+	It("should resolve the opensearch vendor by its aliases", func() {
+		for _, name := range []string{"", "opensearch", "Opensearch", "OP", "Op", "os"} {
+			vendor, err := LookupVendor(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vendor.Name()).To(Equal(DefaultVendorName))
+		}
+	})
+
+	It("should reject unknown vendors", func() {
+		_, err := LookupVendor("elasticsearch")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should derive paths from the vendor", func() {
+		cr := &opensearchv1.OpenSearchCluster{}
+		Expect(OpenSearchHome(cr)).To(Equal("/usr/share/opensearch"))
+		Expect(VendorForCluster(cr).SecurityAdminScript(OpenSearchHome(cr))).To(Equal("/usr/share/opensearch/plugins/opensearch-security/tools/securityadmin.sh"))
+
+		cr.Spec.General.OpenSearchHome = "/opt/opensearch/"
+		Expect(OpenSearchHome(cr)).To(Equal("/opt/opensearch"))
+	})
+})
+
+var _ = Describe("Service selection", func() {
+	cluster := func(nodePools ...opensearchv1.NodePool) *opensearchv1.OpenSearchCluster {
+		return &opensearchv1.OpenSearchCluster{
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{
+					ServiceName: "test",
+					AdditionalServices: []opensearchv1.AdditionalServiceConfig{
+						{Name: "test-coordinating", Roles: []string{CoordinatingRole}},
+						{Name: "test-ingest", NodePools: []string{"ingest"}},
+					},
+				},
+				NodePools: nodePools,
+			},
+		}
+	}
+
+	It("should not label pods for the cluster service if no nodepool opted out", func() {
+		cr := cluster(opensearchv1.NodePool{Component: "data", Roles: []string{"data"}})
+		Expect(ClientServiceFiltered(cr)).To(BeFalse())
+		Expect(ServiceLabelsForNodePool(cr, &cr.Spec.NodePools[0])).To(BeEmpty())
+	})
+
+	It("should select nodepools by role and component", func() {
+		cr := cluster(
+			opensearchv1.NodePool{Component: "data", Roles: []string{"data"}, ClientService: ptr.To(false)},
+			opensearchv1.NodePool{Component: "coordinators", Roles: []string{}},
+			opensearchv1.NodePool{Component: "ingest", Roles: []string{"ingest"}},
+		)
+		Expect(ServiceLabelsForNodePool(cr, &cr.Spec.NodePools[0])).To(BeEmpty())
+		Expect(ServiceLabelsForNodePool(cr, &cr.Spec.NodePools[1])).To(Equal(map[string]string{
+			ServiceSelectorLabel("test"):              "true",
+			ServiceSelectorLabel("test-coordinating"): "true",
+		}))
+		Expect(ServiceLabelsForNodePool(cr, &cr.Spec.NodePools[2])).To(Equal(map[string]string{
+			ServiceSelectorLabel("test"):        "true",
+			ServiceSelectorLabel("test-ingest"): "true",
+		}))
+	})
+})
+
+var _ = Describe("RestartRequestForNodePool", func() {
+	data := &opensearchv1.NodePool{Component: "data"}
+	masters := &opensearchv1.NodePool{Component: "masters"}
+
+	cluster := func(annotations map[string]string) *opensearchv1.OpenSearchCluster {
+		return &opensearchv1.OpenSearchCluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: annotations}}
+	}
+
+	It("should not request a restart without annotation", func() {
+		Expect(RestartRequestForNodePool(cluster(nil), data)).To(BeEmpty())
+	})
+
+	It("should restart all nodepools if no nodepool is selected", func() {
+		cr := cluster(map[string]string{RestartAtAnnotation: "2024-06-01T10:00:00Z"})
+		Expect(RestartRequestForNodePool(cr, data)).To(Equal("2024-06-01T10:00:00Z"))
+		Expect(RestartRequestForNodePool(cr, masters)).To(Equal("2024-06-01T10:00:00Z"))
+	})
+
+	It("should only restart the selected nodepools", func() {
+		cr := cluster(map[string]string{
+			RestartAtAnnotation:        "2024-06-01T10:00:00Z",
+			RestartNodePoolsAnnotation: "ingest, data",
+		})
+		Expect(RestartRequestForNodePool(cr, data)).To(Equal("2024-06-01T10:00:00Z"))
+		Expect(RestartRequestForNodePool(cr, masters)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("FullRestartRequest", func() {
+	It("should only request a full restart for a new annotation value", func() {
+		cr := &opensearchv1.OpenSearchCluster{}
+		Expect(FullRestartRequest(cr)).To(BeEmpty())
+		cr.Annotations = map[string]string{FullRestartAtAnnotation: "2024-06-01T10:00:00Z"}
+		Expect(FullRestartRequest(cr)).To(Equal("2024-06-01T10:00:00Z"))
+		cr.Status.FullRestart = &opensearchv1.FullRestartStatus{Request: "2024-06-01T10:00:00Z", Step: opensearchv1.FullRestartStepCompleted}
+		Expect(FullRestartRequest(cr)).To(BeEmpty())
+	})
+
+	It("should report the steps of a full restart in progress", func() {
+		status := opensearchv1.ClusterStatus{}
+		Expect(FullRestartInProgress(status)).To(BeFalse())
+		status.FullRestart = &opensearchv1.FullRestartStatus{Step: opensearchv1.FullRestartStepStoppingNodes}
+		Expect(FullRestartInProgress(status)).To(BeTrue())
+		Expect(FullRestartStoppingNodes(status)).To(BeTrue())
+		Expect(FullRestartStartingNodes(status)).To(BeFalse())
+		status.FullRestart.Step = opensearchv1.FullRestartStepRecoveringShards
+		Expect(FullRestartStartingNodes(status)).To(BeTrue())
+		status.FullRestart.Step = opensearchv1.FullRestartStepRejected
+		Expect(FullRestartInProgress(status)).To(BeFalse())
+	})
+})
+
+var _ = Describe("ClusterPaused", func() {
+	It("should be paused with spec.paused or the annotation", func() {
+		cr := &opensearchv1.OpenSearchCluster{}
+		Expect(ClusterPaused(cr)).To(BeFalse())
+		cr.Annotations = map[string]string{PausedAnnotation: "false"}
+		Expect(ClusterPaused(cr)).To(BeFalse())
+		cr.Annotations[PausedAnnotation] = "true"
+		Expect(ClusterPaused(cr)).To(BeTrue())
+		cr.Annotations = nil
+		cr.Spec.Paused = true
+		Expect(ClusterPaused(cr)).To(BeTrue())
+	})
+})
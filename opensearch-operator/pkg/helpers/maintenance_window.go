@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/robfig/cron/v3"
+)
+
+// MaintenanceWindowAt evaluates the maintenance windows at the given time. It returns whether a window is open and the
+// start and end of the open window, or of the next one if no window is open. Without windows disruptive operations are
+// always allowed, so the window is open and start and end are zero.
+func MaintenanceWindowAt(windows []opensearchv1.MaintenanceWindow, now time.Time) (bool, time.Time, time.Time, error) {
+	var open bool
+	var start, end time.Time
+	if len(windows) == 0 {
+		return true, start, end, nil
+	}
+
+	for _, window := range windows {
+		schedule, err := parseMaintenanceWindow(window)
+		if err != nil {
+			return false, time.Time{}, time.Time{}, err
+		}
+		duration := window.Duration.Duration
+		// The first start after now-duration is either within the window that is open now or the next one
+		windowStart := schedule.Next(now.Add(-duration))
+		if windowStart.IsZero() {
+			continue
+		}
+		windowEnd := windowStart.Add(duration)
+		windowOpen := !windowStart.After(now)
+		switch {
+		case windowOpen && (!open || windowEnd.After(end)):
+			open, start, end = true, windowStart, windowEnd
+		case !windowOpen && !open && (start.IsZero() || windowStart.Before(start)):
+			start, end = windowStart, windowEnd
+		}
+	}
+	return open, start, end, nil
+}
+
+func parseMaintenanceWindow(window opensearchv1.MaintenanceWindow) (cron.Schedule, error) {
+	if window.Duration.Duration <= 0 {
+		return nil, fmt.Errorf("duration of maintenance window %q must be positive", window.Cron.Expression)
+	}
+	timezone := window.Cron.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone of maintenance window %q: %w", window.Cron.Expression, err)
+	}
+	schedule, err := cron.ParseStandard(window.Cron.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression of maintenance window %q: %w", window.Cron.Expression, err)
+	}
+	if specSchedule, ok := schedule.(*cron.SpecSchedule); ok {
+		specSchedule.Location = location
+	}
+	return schedule, nil
+}
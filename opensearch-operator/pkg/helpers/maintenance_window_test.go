@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("MaintenanceWindowAt", func() {
+	window := func(expression, timezone string, duration time.Duration) opensearchv1.MaintenanceWindow {
+		return opensearchv1.MaintenanceWindow{
+			Cron:     opensearchv1.CronExpression{Expression: expression, Timezone: timezone},
+			Duration: metav1.Duration{Duration: duration},
+		}
+	}
+	// Saturday, 1st of June 2024
+	saturday := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	It("should always be open without windows", func() {
+		open, start, end, err := MaintenanceWindowAt(nil, saturday)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+		Expect(start.IsZero()).To(BeTrue())
+		Expect(end.IsZero()).To(BeTrue())
+	})
+
+	It("should return the open window", func() {
+		windows := []opensearchv1.MaintenanceWindow{window("0 2 * * 6", "UTC", 4*time.Hour)}
+		open, start, end, err := MaintenanceWindowAt(windows, saturday.Add(3*time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+		Expect(start).To(BeTemporally("==", saturday.Add(2*time.Hour)))
+		Expect(end).To(BeTemporally("==", saturday.Add(6*time.Hour)))
+	})
+
+	It("should return the next window if no window is open", func() {
+		windows := []opensearchv1.MaintenanceWindow{
+			window("0 2 * * 6", "UTC", 4*time.Hour),
+			window("0 22 * * 1-5", "UTC", time.Hour),
+		}
+		open, start, end, err := MaintenanceWindowAt(windows, saturday.Add(6*time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeFalse())
+		// Monday 22:00
+		Expect(start).To(BeTemporally("==", saturday.Add(2*24*time.Hour+22*time.Hour)))
+		Expect(end).To(BeTemporally("==", saturday.Add(2*24*time.Hour+23*time.Hour)))
+	})
+
+	It("should evaluate the cron expression in the timezone", func() {
+		windows := []opensearchv1.MaintenanceWindow{window("0 2 * * 6", "Europe/Berlin", 4*time.Hour)}
+		open, start, _, err := MaintenanceWindowAt(windows, saturday)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+		// 2am in Berlin is midnight UTC in summer
+		Expect(start).To(BeTemporally("==", saturday))
+	})
+
+	It("should return an error for invalid windows", func() {
+		_, _, _, err := MaintenanceWindowAt([]opensearchv1.MaintenanceWindow{window("0 2 * *", "UTC", time.Hour)}, saturday)
+		Expect(err).To(HaveOccurred())
+		_, _, _, err = MaintenanceWindowAt([]opensearchv1.MaintenanceWindow{window("0 2 * * 6", "Mars/Olympus", time.Hour)}, saturday)
+		Expect(err).To(HaveOccurred())
+		_, _, _, err = MaintenanceWindowAt([]opensearchv1.MaintenanceWindow{window("0 2 * * 6", "UTC", 0)}, saturday)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"time"
+
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
 	"github.com/prometheus/client_golang/prometheus"
@@ -49,10 +51,46 @@ var (
 		}, []string{
 			"namespace", "opensearch_cluster", "reconciler",
 		})
+	DrainShardsRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: clusterMetricsPrefix + "drain_shards_remaining",
+			Help: "The number of shards still allocated to a node being drained.",
+		}, []string{
+			"namespace", "opensearch_cluster", "node",
+		})
+	DrainBytesRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: clusterMetricsPrefix + "drain_bytes_remaining",
+			Help: "The store size of the shards still allocated to a node being drained.",
+		}, []string{
+			"namespace", "opensearch_cluster", "node",
+		})
+	DrainRelocatingBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: clusterMetricsPrefix + "drain_relocating_bytes",
+			Help: "The store size of the shards relocating away from a node being drained.",
+		}, []string{
+			"namespace", "opensearch_cluster", "node",
+		})
+	DrainEstimatedSecondsRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: clusterMetricsPrefix + "drain_estimated_remaining_seconds",
+			Help: "Estimated seconds until a node being drained is empty. -1 if there is no estimate yet.",
+		}, []string{
+			"namespace", "opensearch_cluster", "node",
+		})
+	DrainTimeouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: clusterMetricsPrefix + "drain_timeouts_total",
+			Help: "Total number of drains that exceeded the drain timeout.",
+		}, []string{
+			"namespace", "opensearch_cluster", "policy",
+		})
 )
 
 func RegisterMetrics() {
-	metrics.Registry.MustRegister(TlsCertificateDaysRemaining, ClusterInfo, ClusterHealth, ClusterShards, ReconcileErrors)
+	metrics.Registry.MustRegister(TlsCertificateDaysRemaining, ClusterInfo, ClusterHealth, ClusterShards, ReconcileErrors,
+		DrainShardsRemaining, DrainBytesRemaining, DrainRelocatingBytes, DrainEstimatedSecondsRemaining, DrainTimeouts)
 }
 
 func DeleteClusterMetrics(namespace string, clusterName string) {
@@ -61,6 +99,11 @@ func DeleteClusterMetrics(namespace string, clusterName string) {
 	ClusterHealth.Delete(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName})
 	ClusterShards.Delete(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName})
 	ReconcileErrors.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName})
+	DrainShardsRemaining.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName})
+	DrainBytesRemaining.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName})
+	DrainRelocatingBytes.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName})
+	DrainEstimatedSecondsRemaining.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName})
+	DrainTimeouts.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName})
 }
 
 func UpdateClusterInfo(instance *opensearchv1.OpenSearchCluster, health opensearchv1.OpenSearchHealth, healthResponse responses.ClusterHealthResponse) {
@@ -91,3 +134,25 @@ func UpdateClusterInfo(instance *opensearchv1.OpenSearchCluster, health opensear
 		ClusterShards.With(prometheus.Labels{"namespace": namespace, "opensearch_cluster": clusterName, "status": "unassigned"}).Set(float64(healthResponse.UnassignedShards))
 	}
 }
+
+// UpdateDrainMetrics reports the progress of draining a node
+func UpdateDrainMetrics(instance *opensearchv1.OpenSearchCluster, status opensearchv1.DrainStatus, now time.Time) {
+	labels := prometheus.Labels{"namespace": instance.Namespace, "opensearch_cluster": instance.Name, "node": status.Node}
+	DrainShardsRemaining.With(labels).Set(float64(status.ShardsRemaining))
+	DrainBytesRemaining.With(labels).Set(float64(status.BytesRemaining))
+	DrainRelocatingBytes.With(labels).Set(float64(status.RelocatingBytes))
+	remaining := -1.0
+	if status.EstimatedCompletionTime != nil {
+		remaining = max(status.EstimatedCompletionTime.Sub(now).Seconds(), 0)
+	}
+	DrainEstimatedSecondsRemaining.With(labels).Set(remaining)
+}
+
+// DeleteDrainMetrics removes the drain metrics of a node once it is drained
+func DeleteDrainMetrics(instance *opensearchv1.OpenSearchCluster, node string) {
+	labels := prometheus.Labels{"namespace": instance.Namespace, "opensearch_cluster": instance.Name, "node": node}
+	DrainShardsRemaining.Delete(labels)
+	DrainBytesRemaining.Delete(labels)
+	DrainRelocatingBytes.Delete(labels)
+	DrainEstimatedSecondsRemaining.Delete(labels)
+}
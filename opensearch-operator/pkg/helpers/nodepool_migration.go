@@ -0,0 +1,139 @@
+package helpers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// A node pool migration replaces the StatefulSet of a node pool with a shadow StatefulSet that uses new
+// storage settings, or with the StatefulSet of a node pool renamed with renamedFrom. Progress is tracked in a ComponentStatus with Component=NodePoolMigrationComponent,
+// Description=<node pool component>, Status=<phase> and the source/target StatefulSet names as conditions.
+// The entry is kept with Status=Completed afterwards so the shadow StatefulSet is known as the active one.
+const (
+	NodePoolMigrationComponent = "NodePoolMigration"
+
+	NodePoolMigrationCreatingTarget = "CreatingTarget"
+	NodePoolMigrationDraining       = "Draining"
+	NodePoolMigrationDeletingSource = "DeletingSource"
+	NodePoolMigrationCompleted      = "Completed"
+
+	StorageTypePVC      = "pvc"
+	StorageTypeHostPath = "hostPath"
+	StorageTypeEmptyDir = "emptyDir"
+
+	nodePoolMigrationSourcePrefix = "source:"
+	nodePoolMigrationTargetPrefix = "target:"
+)
+
+// FindNodePoolMigration returns the migration status entry for the given node pool component
+func FindNodePoolMigration(status opensearchv1.ClusterStatus, component string) (opensearchv1.ComponentStatus, bool) {
+	item := opensearchv1.ComponentStatus{
+		Component:   NodePoolMigrationComponent,
+		Description: component,
+	}
+	return FindFirstPartial(status.ComponentsStatus, item, GetByDescriptionAndComponent)
+}
+
+// IsNodePoolMigrationInProgress returns true if a migration for the node pool has started but not yet completed
+func IsNodePoolMigrationInProgress(status opensearchv1.ClusterStatus, component string) bool {
+	migration, found := FindNodePoolMigration(status, component)
+	return found && migration.Status != NodePoolMigrationCompleted
+}
+
+// AnyNodePoolMigrationInProgress returns true if any node pool of the cluster is being migrated
+func AnyNodePoolMigrationInProgress(status opensearchv1.ClusterStatus) bool {
+	for _, componentStatus := range status.ComponentsStatus {
+		if componentStatus.Component == NodePoolMigrationComponent && componentStatus.Status != NodePoolMigrationCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+// NodePoolMigrationConditions builds the status conditions recording the source and target StatefulSets
+func NodePoolMigrationConditions(source, target string) []string {
+	return []string{nodePoolMigrationSourcePrefix + source, nodePoolMigrationTargetPrefix + target}
+}
+
+// NodePoolMigrationSource returns the name of the StatefulSet being replaced
+func NodePoolMigrationSource(migration opensearchv1.ComponentStatus) string {
+	return nodePoolMigrationCondition(migration, nodePoolMigrationSourcePrefix)
+}
+
+// NodePoolMigrationTarget returns the name of the shadow StatefulSet replacing the node pool
+func NodePoolMigrationTarget(migration opensearchv1.ComponentStatus) string {
+	return nodePoolMigrationCondition(migration, nodePoolMigrationTargetPrefix)
+}
+
+func nodePoolMigrationCondition(migration opensearchv1.ComponentStatus, prefix string) string {
+	for _, condition := range migration.Conditions {
+		if strings.HasPrefix(condition, prefix) {
+			return strings.TrimPrefix(condition, prefix)
+		}
+	}
+	return ""
+}
+
+// NodePoolStsName returns the name of the StatefulSet currently serving the node pool.
+// After a completed migration this is the former shadow StatefulSet.
+func NodePoolStsName(cr *opensearchv1.OpenSearchCluster, component string) string {
+	if migration, found := FindNodePoolMigration(cr.Status, component); found && migration.Status == NodePoolMigrationCompleted {
+		if target := NodePoolMigrationTarget(migration); target != "" {
+			return target
+		}
+	}
+	return cr.Name + "-" + component
+}
+
+// NodePoolPodNames returns the names of all pods the node pool may run. During a migration these are the pods of both
+// the source and the shadow StatefulSet.
+func NodePoolPodNames(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) []string {
+	stsNames := []string{NodePoolStsName(cr, nodePool.Component)}
+	if migration, found := FindNodePoolMigration(cr.Status, nodePool.Component); found && migration.Status != NodePoolMigrationCompleted {
+		stsNames = []string{NodePoolMigrationSource(migration), NodePoolMigrationTarget(migration)}
+	}
+	var podNames []string
+	for _, stsName := range stsNames {
+		for i := int32(0); i < NodePoolMaxReplicas(cr, nodePool); i++ {
+			podNames = append(podNames, fmt.Sprintf("%s-%d", stsName, i))
+		}
+	}
+	return podNames
+}
+
+// StatefulSetStorageType returns where the pods of the StatefulSet store their data: in PVCs, a hostPath or an emptyDir
+func StatefulSetStorageType(sts *appsv1.StatefulSet) string {
+	if len(sts.Spec.VolumeClaimTemplates) > 0 {
+		return StorageTypePVC
+	}
+	for _, volume := range sts.Spec.Template.Spec.Volumes {
+		if volume.Name != "data" {
+			continue
+		}
+		if volume.HostPath != nil {
+			return StorageTypeHostPath
+		}
+		if volume.EmptyDir != nil {
+			return StorageTypeEmptyDir
+		}
+	}
+	return ""
+}
+
+// NodePoolMigrationTargetName returns the name of the shadow StatefulSet for the given storage settings.
+// The suffix is derived from the settings so repeated migrations never reuse the name of the active StatefulSet.
+func NodePoolMigrationTargetName(cr *opensearchv1.OpenSearchCluster, component string, storageType string, storageClass string, accessModes []corev1.PersistentVolumeAccessMode) string {
+	hash := sha1.New()
+	hash.Write([]byte(storageType))
+	hash.Write([]byte(storageClass))
+	for _, mode := range accessModes {
+		hash.Write([]byte(mode))
+	}
+	return fmt.Sprintf("%s-%s-%s", cr.Name, component, hex.EncodeToString(hash.Sum(nil))[:5])
+}
@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+)
+
+// MinGeneratedPasswordLength is the minimum length of passwords generated for OpensearchUsers
+const MinGeneratedPasswordLength = 12
+
+var passwordCharacters = map[opensearchv1.PasswordCharacterClass]string{
+	opensearchv1.PasswordCharacterClassLowercase: "abcdefghijklmnopqrstuvwxyz",
+	opensearchv1.PasswordCharacterClassUppercase: "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	opensearchv1.PasswordCharacterClassDigits:    "0123456789",
+	// Symbols that need no quoting in shells, URLs and the YAML of the security config
+	opensearchv1.PasswordCharacterClassSymbols: "-_.~!*+=^",
+}
+
+// GeneratePassword returns a random password of the given length that contains at least one character of every
+// class. All classes are used if none are given.
+func GeneratePassword(length int, classes []opensearchv1.PasswordCharacterClass) (string, error) {
+	if len(classes) == 0 {
+		classes = []opensearchv1.PasswordCharacterClass{
+			opensearchv1.PasswordCharacterClassLowercase,
+			opensearchv1.PasswordCharacterClassUppercase,
+			opensearchv1.PasswordCharacterClassDigits,
+			opensearchv1.PasswordCharacterClassSymbols,
+		}
+	}
+	if length < MinGeneratedPasswordLength {
+		return "", fmt.Errorf("password length %d is shorter than the minimum of %d", length, MinGeneratedPasswordLength)
+	}
+
+	var alphabet string
+	password := make([]byte, 0, length)
+	for _, class := range classes {
+		characters, ok := passwordCharacters[class]
+		if !ok {
+			return "", fmt.Errorf("unknown password character class %s", class)
+		}
+		alphabet += characters
+		c, err := randomCharacter(characters)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	for len(password) < length {
+		c, err := randomCharacter(alphabet)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// Shuffle so the guaranteed characters are not always at the start
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
+
+func randomCharacter(characters string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(characters))))
+	if err != nil {
+		return 0, err
+	}
+	return characters[i.Int64()], nil
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+)
+
+var _ = Describe("Password generation", func() {
+	It("should use every character class at least once", func() {
+		for i := 0; i < 20; i++ {
+			password, err := GeneratePassword(MinGeneratedPasswordLength, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(password).To(HaveLen(MinGeneratedPasswordLength))
+			for _, characters := range passwordCharacters {
+				Expect(strings.ContainsAny(password, characters)).To(BeTrue())
+			}
+		}
+	})
+
+	It("should only use the selected character classes", func() {
+		password, err := GeneratePassword(64, []opensearchv1.PasswordCharacterClass{opensearchv1.PasswordCharacterClassDigits})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(password).To(MatchRegexp("^[0-9]{64}$"))
+	})
+
+	It("should reject short passwords", func() {
+		_, err := GeneratePassword(8, nil)
+		Expect(err).To(MatchError("password length 8 is shorter than the minimum of 12"))
+	})
+})
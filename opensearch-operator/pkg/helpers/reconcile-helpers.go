@@ -42,14 +42,15 @@ func ResolveImage(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.Nod
 		return
 	}
 
+	vendor := VendorForCluster(cr)
 	version := cr.Spec.General.Version
-	defaultRepo := "docker.io/opensearchproject"
+	defaultRepo := vendor.DefaultRepo()
 	if cr.Spec.General.DefaultRepo != nil {
 		defaultRepo = *cr.Spec.General.DefaultRepo
 	}
 	imageSpec := cr.Spec.General.ImageSpec
 
-	defaultImage := "opensearch"
+	defaultImage := vendor.ImageName()
 
 	// If a general custom image is specified, use it.
 	if imageSpec != nil {
@@ -154,13 +155,14 @@ func VersionCheck(instance *opensearchv1.OpenSearchCluster) (int32, int32, strin
 		}
 	}
 
-	opensearchHome := instance.Spec.General.GetOpenSearchHome()
+	opensearchHome := OpenSearchHome(instance)
+	securityPlugin := VendorForCluster(instance).SecurityPluginName()
 	if isVersion2OrHigher {
 		securityConfigPort = httpPort
-		securityConfigPath = opensearchHome + "/config/opensearch-security"
+		securityConfigPath = opensearchHome + "/config/" + securityPlugin
 	} else {
 		securityConfigPort = 9300
-		securityConfigPath = opensearchHome + "/plugins/opensearch-security/securityconfig"
+		securityConfigPath = opensearchHome + "/plugins/" + securityPlugin + "/securityconfig"
 	}
 	return httpPort, securityConfigPort, securityConfigPath
 }
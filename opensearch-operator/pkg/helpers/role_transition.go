@@ -0,0 +1,99 @@
+package helpers
+
+import (
+	"strings"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// A role transition removes the data or cluster manager role from the nodes of a node pool. The nodes keep their
+// current roles until they are drained and excluded from the voting configuration, then they are restarted with the
+// new roles. Progress is tracked in a ComponentStatus with Component=RoleTransitionComponent,
+// Description=<node pool component>, Status=<phase> and the removed roles as conditions. The entry is removed once
+// the nodes run with the new roles.
+const (
+	RoleTransitionComponent = "RoleTransition"
+
+	RoleTransitionDraining       = "Draining"
+	RoleTransitionExcludingVotes = "ExcludingVotes"
+	RoleTransitionRestarting     = "Restarting"
+
+	dataRole    = "data"
+	managerRole = "cluster_manager"
+)
+
+// FindRoleTransition returns the role transition status entry for the given node pool component
+func FindRoleTransition(status opensearchv1.ClusterStatus, component string) (opensearchv1.ComponentStatus, bool) {
+	item := opensearchv1.ComponentStatus{
+		Component:   RoleTransitionComponent,
+		Description: component,
+	}
+	return FindFirstPartial(status.ComponentsStatus, item, GetByDescriptionAndComponent)
+}
+
+// AnyRoleTransitionInProgress returns true if the roles of any node pool of the cluster are being changed
+func AnyRoleTransitionInProgress(status opensearchv1.ClusterStatus) bool {
+	for _, componentStatus := range status.ComponentsStatus {
+		if componentStatus.Component == RoleTransitionComponent {
+			return true
+		}
+	}
+	return false
+}
+
+// StatefulSetRoles returns the roles the pods of the StatefulSet are started with
+func StatefulSetRoles(sts *appsv1.StatefulSet) []string {
+	if len(sts.Spec.Template.Spec.Containers) == 0 {
+		return nil
+	}
+	for _, env := range sts.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "node.roles" {
+			if env.Value == "" || env.Value == "[]" {
+				return nil
+			}
+			return strings.Split(env.Value, ",")
+		}
+	}
+	return nil
+}
+
+// StatefulSetHasManagerRole returns true if the pods of the StatefulSet are cluster manager eligible
+func StatefulSetHasManagerRole(sts *appsv1.StatefulSet) bool {
+	return hasManagerRole(StatefulSetRoles(sts))
+}
+
+// RemovedRoles returns the roles that need to be prepared before nodes stop having them, i.e. data if the nodes hold
+// shards and cluster_manager if they vote in the elections. master and cluster_manager are the same role.
+func RemovedRoles(existing, desired []string) []string {
+	var removed []string
+	if ContainsString(existing, dataRole) && !ContainsString(desired, dataRole) {
+		removed = append(removed, dataRole)
+	}
+	if hasManagerRole(existing) && !hasManagerRole(desired) {
+		removed = append(removed, managerRole)
+	}
+	return removed
+}
+
+// RoleTransitionRemovesData returns true if the nodes of the role transition lose the data role
+func RoleTransitionRemovesData(transition opensearchv1.ComponentStatus) bool {
+	return ContainsString(transition.Conditions, dataRole)
+}
+
+// RoleTransitionRemovesManager returns true if the nodes of the role transition lose the cluster manager role
+func RoleTransitionRemovesManager(transition opensearchv1.ComponentStatus) bool {
+	return ContainsString(transition.Conditions, managerRole)
+}
+
+// RoleTransitionApplied returns true if the roles no longer contain any role removed by the role transition
+func RoleTransitionApplied(transition opensearchv1.ComponentStatus, roles []string) bool {
+	if RoleTransitionRemovesData(transition) && ContainsString(roles, dataRole) {
+		return false
+	}
+	return !RoleTransitionRemovesManager(transition) || !hasManagerRole(roles)
+}
+
+func hasManagerRole(roles []string) bool {
+	return ContainsString(roles, "master") || ContainsString(roles, managerRole)
+}
@@ -0,0 +1,195 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Environment variables of the OpenSearch containers that contain the credentials of the external audit cluster
+	AuditUsernameEnv = "OPENSEARCH_AUDIT_USERNAME"
+	AuditPasswordEnv = "OPENSEARCH_AUDIT_PASSWORD"
+
+	defaultAuditType = "internal_opensearch"
+)
+
+// AuditCategories are the categories of audit events logged by the security plugin
+var AuditCategories = []string{
+	"FAILED_LOGIN",
+	"AUTHENTICATED",
+	"MISSING_PRIVILEGES",
+	"GRANTED_PRIVILEGES",
+	"SSL_EXCEPTION",
+	"OPENDISTRO_SECURITY_INDEX_ATTEMPT",
+	"BAD_HEADERS",
+	"INDEX_EVENT",
+}
+
+// Audit returns the configured audit logging, nil if it is not configured
+func Audit(cr *opensearchv1.OpenSearchCluster) *opensearchv1.AuditConfig {
+	if cr.Spec.Security == nil {
+		return nil
+	}
+	return cr.Spec.Security.Audit
+}
+
+// AuditSettings returns the opensearch.yml settings of the audit log sink. Without audit configuration the events are
+// written to the internal audit index.
+func AuditSettings(cr *opensearchv1.OpenSearchCluster) map[string]string {
+	settings := map[string]string{"plugins.security.audit.type": defaultAuditType}
+	audit := Audit(cr)
+	if audit == nil {
+		return settings
+	}
+	if audit.Type != "" {
+		settings["plugins.security.audit.type"] = audit.Type
+	}
+	if audit.Index != "" {
+		settings["plugins.security.audit.config.index"] = audit.Index
+	}
+
+	if external := audit.External; external != nil {
+		endpoints, _ := json.Marshal(external.HttpEndpoints)
+		settings["plugins.security.audit.config.http_endpoints"] = string(endpoints)
+		settings["plugins.security.audit.config.enable_ssl"] = strconv.FormatBool(external.EnableSSL)
+		if external.VerifyHostnames != nil {
+			settings["plugins.security.audit.config.verify_hostnames"] = strconv.FormatBool(*external.VerifyHostnames)
+		}
+		if external.CredentialsSecret != nil {
+			// Resolved by OpenSearch from the environment to keep the credentials out of the config map
+			settings["plugins.security.audit.config.username"] = fmt.Sprintf("${%s}", AuditUsernameEnv)
+			settings["plugins.security.audit.config.password"] = fmt.Sprintf("${%s}", AuditPasswordEnv)
+		}
+	}
+
+	if webhook := audit.Webhook; webhook != nil {
+		settings["plugins.security.audit.config.webhook.url"] = webhook.URL
+		settings["plugins.security.audit.config.webhook.format"] = defaultString(webhook.Format, "JSON")
+		if webhook.SslVerify != nil {
+			settings["plugins.security.audit.config.webhook.ssl.verify"] = strconv.FormatBool(*webhook.SslVerify)
+		}
+	}
+
+	if log4j := audit.Log4j; log4j != nil {
+		settings["plugins.security.audit.config.log4j.logger_name"] = defaultString(log4j.LoggerName, "audit")
+		settings["plugins.security.audit.config.log4j.level"] = defaultString(log4j.Level, "INFO")
+	}
+	return settings
+}
+
+// AuditEnv returns the environment variables of the OpenSearch containers that are referenced by the audit settings
+func AuditEnv(cr *opensearchv1.OpenSearchCluster) []corev1.EnvVar {
+	audit := Audit(cr)
+	if audit == nil || audit.External == nil || audit.External.CredentialsSecret == nil {
+		return nil
+	}
+	secretRef := *audit.External.CredentialsSecret
+	return []corev1.EnvVar{
+		{Name: AuditUsernameEnv, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "username"}}},
+		{Name: AuditPasswordEnv, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "password"}}},
+	}
+}
+
+// applyAudit writes the audit options of spec.security.audit to audit.yml of the security config. The user provided
+// audit.yml is extended if present, otherwise the bundled default.
+func applyAudit(cr *opensearchv1.OpenSearchCluster, data map[string][]byte) error {
+	audit := Audit(cr)
+	if audit == nil {
+		return nil
+	}
+
+	auditData, ok := data["audit.yml"]
+	if !ok {
+		var err error
+		auditData, err = defaultSecurityConfigFS.ReadFile("securityconfigdefaults/audit.yml")
+		if err != nil {
+			return err
+		}
+	}
+	config := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(auditData, &config); err != nil {
+		return fmt.Errorf("failed to parse audit.yml of the securityconfig: %w", err)
+	}
+	auditConfig := yamlMap(config, "config")
+	auditConfig["enabled"] = true
+
+	logging := yamlMap(auditConfig, "audit")
+	if audit.EnableRest != nil {
+		logging["enable_rest"] = *audit.EnableRest
+	}
+	if audit.EnableTransport != nil {
+		logging["enable_transport"] = *audit.EnableTransport
+	}
+	if len(audit.IncludeCategories) > 0 || len(audit.ExcludeCategories) > 0 {
+		disabled, err := disabledAuditCategories(audit.IncludeCategories, audit.ExcludeCategories)
+		if err != nil {
+			return err
+		}
+		logging["disabled_rest_categories"] = disabled
+		logging["disabled_transport_categories"] = disabled
+	}
+	if audit.IgnoreUsers != nil {
+		logging["ignore_users"] = audit.IgnoreUsers
+	}
+	if audit.IgnoreRequests != nil {
+		logging["ignore_requests"] = audit.IgnoreRequests
+	}
+
+	if compliance := audit.Compliance; compliance != nil {
+		complianceConfig := yamlMap(auditConfig, "compliance")
+		complianceConfig["enabled"] = compliance.Enabled == nil || *compliance.Enabled
+		if compliance.InternalConfig != nil {
+			complianceConfig["internal_config"] = *compliance.InternalConfig
+		}
+		complianceConfig["external_config"] = compliance.ExternalConfig
+		if compliance.ReadWatchedFields != nil {
+			complianceConfig["read_watched_fields"] = compliance.ReadWatchedFields
+		}
+		if compliance.ReadMetadataOnly != nil {
+			complianceConfig["read_metadata_only"] = *compliance.ReadMetadataOnly
+		}
+		if compliance.ReadIgnoreUsers != nil {
+			complianceConfig["read_ignore_users"] = compliance.ReadIgnoreUsers
+		}
+		if compliance.WriteWatchedIndices != nil {
+			complianceConfig["write_watched_indices"] = compliance.WriteWatchedIndices
+		}
+		if compliance.WriteMetadataOnly != nil {
+			complianceConfig["write_metadata_only"] = *compliance.WriteMetadataOnly
+		}
+		complianceConfig["write_log_diffs"] = compliance.WriteLogDiffs
+		if compliance.WriteIgnoreUsers != nil {
+			complianceConfig["write_ignore_users"] = compliance.WriteIgnoreUsers
+		}
+	}
+
+	result, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	data["audit.yml"] = result
+	return nil
+}
+
+// disabledAuditCategories returns the categories that are not logged. Only the included categories are logged if
+// any are given, excluded categories are never logged.
+func disabledAuditCategories(include, exclude []string) ([]string, error) {
+	for _, category := range append(slices.Clone(include), exclude...) {
+		if !slices.Contains(AuditCategories, category) {
+			return nil, fmt.Errorf("unknown audit category %s", category)
+		}
+	}
+	disabled := []string{}
+	for _, category := range AuditCategories {
+		if (len(include) > 0 && !slices.Contains(include, category)) || slices.Contains(exclude, category) {
+			disabled = append(disabled, category)
+		}
+	}
+	return disabled, nil
+}
@@ -0,0 +1,131 @@
+package helpers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Security config audit", func() {
+	newCluster := func(audit *opensearchv1.AuditConfig) *opensearchv1.OpenSearchCluster {
+		return &opensearchv1.OpenSearchCluster{
+			Spec: opensearchv1.ClusterSpec{Security: &opensearchv1.Security{Audit: audit}},
+		}
+	}
+	parseAudit := func(data map[string][]byte) map[interface{}]interface{} {
+		config := map[interface{}]interface{}{}
+		Expect(yaml.Unmarshal(data["audit.yml"], &config)).To(Succeed())
+		return yamlMap(config, "config")
+	}
+
+	It("should write audit events to the internal index by default", func() {
+		Expect(AuditSettings(newCluster(nil))).To(Equal(map[string]string{"plugins.security.audit.type": "internal_opensearch"}))
+		Expect(AuditEnv(newCluster(nil))).To(BeEmpty())
+
+		data := map[string][]byte{}
+		Expect(applyAudit(newCluster(nil), data)).To(Succeed())
+		Expect(data).ToNot(HaveKey("audit.yml"))
+	})
+
+	It("should configure an external cluster with credentials from the environment", func() {
+		cluster := newCluster(&opensearchv1.AuditConfig{
+			Type:  "external_opensearch",
+			Index: "'audit-'YYYY.MM.dd",
+			External: &opensearchv1.AuditExternalConfig{
+				HttpEndpoints:     []string{"audit-cluster:9200"},
+				EnableSSL:         true,
+				CredentialsSecret: &corev1.LocalObjectReference{Name: "audit-credentials"},
+			},
+		})
+
+		Expect(AuditSettings(cluster)).To(Equal(map[string]string{
+			"plugins.security.audit.type":                  "external_opensearch",
+			"plugins.security.audit.config.index":          "'audit-'YYYY.MM.dd",
+			"plugins.security.audit.config.http_endpoints": `["audit-cluster:9200"]`,
+			"plugins.security.audit.config.enable_ssl":     "true",
+			"plugins.security.audit.config.username":       "${OPENSEARCH_AUDIT_USERNAME}",
+			"plugins.security.audit.config.password":       "${OPENSEARCH_AUDIT_PASSWORD}",
+		}))
+		env := AuditEnv(cluster)
+		Expect(env).To(HaveLen(2))
+		Expect(env[1].Name).To(Equal(AuditPasswordEnv))
+		Expect(env[1].ValueFrom.SecretKeyRef.Name).To(Equal("audit-credentials"))
+		Expect(env[1].ValueFrom.SecretKeyRef.Key).To(Equal("password"))
+	})
+
+	It("should configure the webhook and log4j sinks with defaults", func() {
+		settings := AuditSettings(newCluster(&opensearchv1.AuditConfig{
+			Type:    "webhook",
+			Webhook: &opensearchv1.AuditWebhookConfig{URL: "https://hooks.example.com", SslVerify: ptr.To(false)},
+			Log4j:   &opensearchv1.AuditLog4jConfig{},
+		}))
+		Expect(settings).To(HaveKeyWithValue("plugins.security.audit.config.webhook.format", "JSON"))
+		Expect(settings).To(HaveKeyWithValue("plugins.security.audit.config.webhook.ssl.verify", "false"))
+		Expect(settings).To(HaveKeyWithValue("plugins.security.audit.config.log4j.logger_name", "audit"))
+		Expect(settings).To(HaveKeyWithValue("plugins.security.audit.config.log4j.level", "INFO"))
+	})
+
+	It("should render the categories, ignored users and compliance into the default audit.yml", func() {
+		cluster := newCluster(&opensearchv1.AuditConfig{
+			EnableTransport:   ptr.To(false),
+			IncludeCategories: []string{"FAILED_LOGIN", "MISSING_PRIVILEGES", "AUTHENTICATED"},
+			ExcludeCategories: []string{"AUTHENTICATED"},
+			IgnoreUsers:       []string{"kibanaserver", "monitoring"},
+			Compliance: &opensearchv1.AuditComplianceConfig{
+				ReadWatchedFields:   map[string][]string{"customers": {"email"}},
+				WriteWatchedIndices: []string{"orders-*"},
+				WriteMetadataOnly:   ptr.To(false),
+				WriteLogDiffs:       true,
+			},
+		})
+
+		data := map[string][]byte{}
+		Expect(applyAudit(cluster, data)).To(Succeed())
+		config := parseAudit(data)
+		Expect(config["enabled"]).To(BeTrue())
+
+		audit := yamlMap(config, "audit")
+		Expect(audit["enable_rest"]).To(BeTrue())
+		Expect(audit["enable_transport"]).To(BeFalse())
+		disabled := []interface{}{"AUTHENTICATED", "GRANTED_PRIVILEGES", "SSL_EXCEPTION", "OPENDISTRO_SECURITY_INDEX_ATTEMPT", "BAD_HEADERS", "INDEX_EVENT"}
+		Expect(audit["disabled_rest_categories"]).To(Equal(disabled))
+		Expect(audit["disabled_transport_categories"]).To(Equal(disabled))
+		Expect(audit["ignore_users"]).To(Equal([]interface{}{"kibanaserver", "monitoring"}))
+
+		compliance := yamlMap(config, "compliance")
+		Expect(compliance["enabled"]).To(BeTrue())
+		Expect(compliance["read_watched_fields"]).To(Equal(map[interface{}]interface{}{"customers": []interface{}{"email"}}))
+		Expect(compliance["read_metadata_only"]).To(BeTrue())
+		Expect(compliance["write_watched_indices"]).To(Equal([]interface{}{"orders-*"}))
+		Expect(compliance["write_metadata_only"]).To(BeFalse())
+		Expect(compliance["write_log_diffs"]).To(BeTrue())
+	})
+
+	It("should extend the provided audit.yml", func() {
+		data := map[string][]byte{"audit.yml": []byte(`_meta:
+  type: "audit"
+  config_version: 2
+config:
+  enabled: false
+  audit:
+    log_request_body: false
+`)}
+		Expect(applyAudit(newCluster(&opensearchv1.AuditConfig{ExcludeCategories: []string{"BAD_HEADERS"}}), data)).To(Succeed())
+		config := parseAudit(data)
+		Expect(config["enabled"]).To(BeTrue())
+		Expect(yamlMap(config, "audit")).To(Equal(map[interface{}]interface{}{
+			"log_request_body":              false,
+			"disabled_rest_categories":      []interface{}{"BAD_HEADERS"},
+			"disabled_transport_categories": []interface{}{"BAD_HEADERS"},
+		}))
+	})
+
+	It("should reject unknown categories", func() {
+		data := map[string][]byte{}
+		err := applyAudit(newCluster(&opensearchv1.AuditConfig{IncludeCategories: []string{"COMPLIANCE_DOC_READ"}}), data)
+		Expect(err).To(MatchError("unknown audit category COMPLIANCE_DOC_READ"))
+	})
+})
@@ -0,0 +1,309 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Environment variable of the Dashboards container that contains the OpenID Connect client secret
+	OpenIDClientSecretEnv = "OPENSEARCH_OPENID_CLIENT_SECRET"
+
+	basicInternalAuthDomain = "basic_internal_auth_domain"
+)
+
+// Order of the auth domains the operator adds, after the authentication of internal users
+const (
+	proxyAuthDomainOrder = iota + 1
+	clientCertAuthDomainOrder
+	jwtAuthDomainOrder
+	ldapAuthDomainOrder
+	openIDAuthDomainOrder
+	samlAuthDomainOrder
+)
+
+// Authentication returns the configured authentication backends, nil if there are none
+func Authentication(cr *opensearchv1.OpenSearchCluster) *opensearchv1.AuthenticationConfig {
+	if cr.Spec.Security == nil {
+		return nil
+	}
+	return cr.Spec.Security.Authentication
+}
+
+// applyAuthentication adds the auth domains of spec.security.authentication to config.yml of the security config.
+// The user provided config.yml is extended if present, otherwise the bundled default that only authenticates internal users.
+func applyAuthentication(k8sClient k8s.K8sClient, cr *opensearchv1.OpenSearchCluster, data map[string][]byte) error {
+	auth := Authentication(cr)
+	if auth == nil {
+		return nil
+	}
+
+	configData, ok := data["config.yml"]
+	if !ok {
+		var err error
+		configData, err = defaultSecurityConfigFS.ReadFile("securityconfigdefaults/config.yml")
+		if err != nil {
+			return err
+		}
+	}
+	config := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(configData, &config); err != nil {
+		return fmt.Errorf("failed to parse config.yml of the securityconfig: %w", err)
+	}
+	dynamic := yamlMap(yamlMap(config, "config"), "dynamic")
+	authc := yamlMap(dynamic, "authc")
+
+	secretValue := func(selector corev1.SecretKeySelector) (string, error) {
+		secret, err := k8sClient.GetSecret(selector.Name, cr.Namespace)
+		if err != nil {
+			return "", err
+		}
+		value, ok := secret.Data[selector.Key]
+		if !ok {
+			return "", fmt.Errorf("secret %s does not contain key %s", selector.Name, selector.Key)
+		}
+		return string(value), nil
+	}
+
+	// Only one domain may challenge clients, which has to be SAML if it is used
+	if auth.OpenID != nil || auth.Saml != nil {
+		if basic, ok := authc[basicInternalAuthDomain].(map[interface{}]interface{}); ok {
+			yamlMap(basic, "http_authenticator")["challenge"] = false
+		}
+	}
+
+	if auth.Proxy != nil {
+		authc["proxy_auth_domain"] = authDomain(proxyAuthDomainOrder, "proxy", false, map[string]interface{}{
+			"user_header":  defaultString(auth.Proxy.UserHeader, "x-proxy-user"),
+			"roles_header": defaultString(auth.Proxy.RolesHeader, "x-proxy-roles"),
+		})
+		yamlMap(dynamic, "http")["xff"] = map[string]interface{}{
+			"enabled":         true,
+			"internalProxies": auth.Proxy.InternalProxies,
+			"remoteIpHeader":  "x-forwarded-for",
+		}
+	}
+
+	if auth.ClientCert != nil {
+		authc["clientcert_auth_domain"] = authDomain(clientCertAuthDomainOrder, "clientcert", false, map[string]interface{}{
+			"username_attribute": defaultString(auth.ClientCert.UsernameAttribute, "cn"),
+			"roles_attribute":    auth.ClientCert.RolesAttribute,
+		})
+	}
+
+	if auth.Jwt != nil {
+		signingKey, err := secretValue(auth.Jwt.SigningKey)
+		if err != nil {
+			return err
+		}
+		authc["jwt_auth_domain"] = authDomain(jwtAuthDomainOrder, "jwt", false, map[string]interface{}{
+			"signing_key":       signingKey,
+			"jwt_header":        defaultString(auth.Jwt.JwtHeader, "Authorization"),
+			"jwt_url_parameter": auth.Jwt.JwtURLParameter,
+			"subject_key":       auth.Jwt.SubjectKey,
+			"roles_key":         auth.Jwt.RolesKey,
+		})
+	}
+
+	if auth.Ldap != nil {
+		connection := map[string]interface{}{
+			"enable_ssl":       auth.Ldap.EnableSSL,
+			"verify_hostnames": auth.Ldap.VerifyHostnames == nil || *auth.Ldap.VerifyHostnames,
+			"hosts":            auth.Ldap.Hosts,
+			"bind_dn":          auth.Ldap.BindDn,
+			"userbase":         auth.Ldap.UserBase,
+			"usersearch":       defaultString(auth.Ldap.UserSearch, "(sAMAccountName={0})"),
+		}
+		if auth.Ldap.BindPassword != nil {
+			password, err := secretValue(*auth.Ldap.BindPassword)
+			if err != nil {
+				return err
+			}
+			connection["password"] = password
+		}
+
+		authenticationConfig := withoutEmpty(connection)
+		if auth.Ldap.UsernameAttribute != "" {
+			authenticationConfig["username_attribute"] = auth.Ldap.UsernameAttribute
+		}
+		domain := authDomain(ldapAuthDomainOrder, "basic", false, nil)
+		domain["description"] = "Authenticate via LDAP, managed by the operator"
+		domain["transport_enabled"] = true
+		domain["authentication_backend"] = map[string]interface{}{"type": "ldap", "config": authenticationConfig}
+		authc["ldap_auth_domain"] = domain
+
+		if auth.Ldap.RoleBase != "" {
+			authorizationConfig := withoutEmpty(connection)
+			authorizationConfig["rolebase"] = auth.Ldap.RoleBase
+			authorizationConfig["rolesearch"] = defaultString(auth.Ldap.RoleSearch, "(member={0})")
+			authorizationConfig["userrolename"] = "disabled"
+			authorizationConfig["rolename"] = defaultString(auth.Ldap.RoleName, "cn")
+			authorizationConfig["resolve_nested_roles"] = false
+			yamlMap(dynamic, "authz")["ldap_roles"] = map[string]interface{}{
+				"description":           "Resolve backend roles from LDAP groups",
+				"http_enabled":          true,
+				"transport_enabled":     true,
+				"authorization_backend": map[string]interface{}{"type": "ldap", "config": authorizationConfig},
+			}
+		}
+	}
+
+	if auth.OpenID != nil {
+		authc["openid_auth_domain"] = authDomain(openIDAuthDomainOrder, "openid", false, map[string]interface{}{
+			"openid_connect_url": auth.OpenID.ConnectURL,
+			"subject_key":        auth.OpenID.SubjectKey,
+			"roles_key":          auth.OpenID.RolesKey,
+		})
+	}
+
+	if auth.Saml != nil {
+		exchangeKey, err := secretValue(auth.Saml.ExchangeKey)
+		if err != nil {
+			return err
+		}
+		authc["saml_auth_domain"] = authDomain(samlAuthDomainOrder, "saml", true, map[string]interface{}{
+			"idp": map[string]interface{}{
+				"metadata_url": auth.Saml.IdpMetadataURL,
+				"entity_id":    auth.Saml.IdpEntityID,
+			},
+			"sp": map[string]interface{}{
+				"entity_id": auth.Saml.SpEntityID,
+			},
+			"kibana_url":   auth.Saml.DashboardsURL,
+			"subject_key":  auth.Saml.SubjectKey,
+			"roles_key":    auth.Saml.RolesKey,
+			"exchange_key": exchangeKey,
+		})
+	}
+
+	result, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	data["config.yml"] = result
+	return nil
+}
+
+// authDomain returns an auth domain that authenticates HTTP requests with the given authenticator. Without an
+// authentication backend the user name and roles of the authenticator are used as they are.
+func authDomain(order int, authenticator string, challenge bool, config map[string]interface{}) map[string]interface{} {
+	httpAuthenticator := map[string]interface{}{
+		"type":      authenticator,
+		"challenge": challenge,
+	}
+	if config = withoutEmpty(config); len(config) > 0 {
+		httpAuthenticator["config"] = config
+	}
+	return map[string]interface{}{
+		"description":            fmt.Sprintf("Authenticate via %s, managed by the operator", authenticator),
+		"http_enabled":           true,
+		"transport_enabled":      false,
+		"order":                  order,
+		"http_authenticator":     httpAuthenticator,
+		"authentication_backend": map[string]interface{}{"type": "noop"},
+	}
+}
+
+// DashboardsAuthenticationConfig returns the opensearch_dashboards.yml settings that let Dashboards log in users
+// with the configured authentication backends
+func DashboardsAuthenticationConfig(cr *opensearchv1.OpenSearchCluster) map[string]string {
+	auth := Authentication(cr)
+	if auth == nil {
+		return nil
+	}
+	config := map[string]string{}
+
+	var authTypes []string
+	if auth.OpenID != nil {
+		authTypes = append(authTypes, "openid")
+		config["opensearch_security.openid.connect_url"] = strconv.Quote(auth.OpenID.ConnectURL)
+		if auth.OpenID.ClientID != "" {
+			config["opensearch_security.openid.client_id"] = strconv.Quote(auth.OpenID.ClientID)
+		}
+		if auth.OpenID.ClientSecret != nil {
+			config["opensearch_security.openid.client_secret"] = strconv.Quote(fmt.Sprintf("${%s}", OpenIDClientSecretEnv))
+		}
+		if auth.OpenID.Scope != "" {
+			config["opensearch_security.openid.scope"] = strconv.Quote(auth.OpenID.Scope)
+		}
+		if auth.OpenID.BaseRedirectURL != "" {
+			config["opensearch_security.openid.base_redirect_url"] = strconv.Quote(auth.OpenID.BaseRedirectURL)
+		}
+	}
+	if auth.Saml != nil {
+		authTypes = append(authTypes, "saml")
+		config["server.xsrf.allowlist"] = quotedList([]string{
+			"/_opendistro/_security/saml/acs",
+			"/_opendistro/_security/saml/acs/idpinitiated",
+			"/_opendistro/_security/saml/logout",
+		})
+	}
+
+	switch {
+	case len(authTypes) > 1:
+		// The login page offers all methods, including the login of internal users
+		config["opensearch_security.auth.multiple_auth_enabled"] = "true"
+		config["opensearch_security.auth.type"] = quotedList(append([]string{"basicauth"}, authTypes...))
+	case len(authTypes) == 1:
+		config["opensearch_security.auth.type"] = strconv.Quote(authTypes[0])
+	case auth.Proxy != nil:
+		config["opensearch_security.auth.type"] = strconv.Quote("proxy")
+		config["opensearch.requestHeadersAllowlist"] = quotedList([]string{
+			"securitytenant",
+			"Authorization",
+			"x-forwarded-for",
+			defaultString(auth.Proxy.UserHeader, "x-proxy-user"),
+			defaultString(auth.Proxy.RolesHeader, "x-proxy-roles"),
+		})
+	case auth.Jwt != nil:
+		config["opensearch_security.auth.type"] = strconv.Quote("jwt")
+		if auth.Jwt.JwtURLParameter != "" {
+			config["opensearch_security.jwt.url_param"] = strconv.Quote(auth.Jwt.JwtURLParameter)
+		}
+		if auth.Jwt.JwtHeader != "" {
+			config["opensearch_security.jwt.header"] = strconv.Quote(auth.Jwt.JwtHeader)
+		}
+	}
+	return config
+}
+
+// yamlMap returns the map stored under key, creating it if it does not exist
+func yamlMap(parent map[interface{}]interface{}, key string) map[interface{}]interface{} {
+	if child, ok := parent[key].(map[interface{}]interface{}); ok {
+		return child
+	}
+	child := map[interface{}]interface{}{}
+	parent[key] = child
+	return child
+}
+
+func withoutEmpty(values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if value != "" {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func defaultString(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func quotedList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
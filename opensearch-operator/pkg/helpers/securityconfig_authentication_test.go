@@ -0,0 +1,142 @@
+package helpers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Security config authentication", func() {
+	newCluster := func(auth *opensearchv1.AuthenticationConfig) *opensearchv1.OpenSearchCluster {
+		return &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "auth"},
+			Spec:       opensearchv1.ClusterSpec{Security: &opensearchv1.Security{Authentication: auth}},
+		}
+	}
+	parseConfig := func(data map[string][]byte) map[interface{}]interface{} {
+		config := map[interface{}]interface{}{}
+		Expect(yaml.Unmarshal(data["config.yml"], &config)).To(Succeed())
+		return config
+	}
+	authc := func(config map[interface{}]interface{}) map[interface{}]interface{} {
+		return yamlMap(yamlMap(yamlMap(config, "config"), "dynamic"), "authc")
+	}
+
+	It("should not add config.yml without authentication", func() {
+		data := map[string][]byte{}
+		Expect(applyAuthentication(k8s.NewMockK8sClient(GinkgoT()), newCluster(nil), data)).To(Succeed())
+		Expect(data).ToNot(HaveKey("config.yml"))
+	})
+
+	It("should add OpenID Connect and LDAP domains to the default config", func() {
+		mockClient := k8s.NewMockK8sClient(GinkgoT())
+		mockClient.EXPECT().GetSecret("ldap", "auth").Return(corev1.Secret{Data: map[string][]byte{"password": []byte("secret")}}, nil)
+		cluster := newCluster(&opensearchv1.AuthenticationConfig{
+			OpenID: &opensearchv1.OpenIDAuthConfig{ConnectURL: "https://idp/.well-known/openid-configuration", RolesKey: "groups"},
+			Ldap: &opensearchv1.LdapAuthConfig{
+				Hosts:        []string{"ldap:636"},
+				EnableSSL:    true,
+				BindDn:       "cn=admin,dc=example",
+				BindPassword: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ldap"}, Key: "password"},
+				UserBase:     "ou=people,dc=example",
+				RoleBase:     "ou=groups,dc=example",
+			},
+		})
+
+		data := map[string][]byte{}
+		Expect(applyAuthentication(mockClient, cluster, data)).To(Succeed())
+		config := parseConfig(data)
+		domains := authc(config)
+
+		basic := domains[basicInternalAuthDomain].(map[interface{}]interface{})
+		Expect(yamlMap(basic, "http_authenticator")["challenge"]).To(BeFalse())
+
+		openid := domains["openid_auth_domain"].(map[interface{}]interface{})
+		Expect(openid["order"]).To(Equal(openIDAuthDomainOrder))
+		Expect(yamlMap(openid, "http_authenticator")["config"]).To(Equal(map[interface{}]interface{}{
+			"openid_connect_url": "https://idp/.well-known/openid-configuration",
+			"roles_key":          "groups",
+		}))
+
+		ldap := domains["ldap_auth_domain"].(map[interface{}]interface{})
+		backend := yamlMap(yamlMap(ldap, "authentication_backend"), "config")
+		Expect(backend["password"]).To(Equal("secret"))
+		Expect(backend["usersearch"]).To(Equal("(sAMAccountName={0})"))
+		Expect(backend["verify_hostnames"]).To(BeTrue())
+
+		roles := yamlMap(yamlMap(yamlMap(config, "config"), "dynamic"), "authz")["ldap_roles"].(map[interface{}]interface{})
+		Expect(yamlMap(yamlMap(roles, "authorization_backend"), "config")["rolebase"]).To(Equal("ou=groups,dc=example"))
+	})
+
+	It("should extend a user provided config.yml", func() {
+		mockClient := k8s.NewMockK8sClient(GinkgoT())
+		mockClient.EXPECT().GetSecret("saml", "auth").Return(corev1.Secret{Data: map[string][]byte{"key": []byte("exchange-key")}}, nil)
+		cluster := newCluster(&opensearchv1.AuthenticationConfig{
+			Saml: &opensearchv1.SamlAuthConfig{
+				IdpMetadataURL: "https://idp/metadata",
+				IdpEntityID:    "idp",
+				SpEntityID:     "dashboards",
+				DashboardsURL:  "https://dashboards",
+				ExchangeKey:    corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "saml"}, Key: "key"},
+			},
+			Proxy: &opensearchv1.ProxyAuthConfig{InternalProxies: "10\\..*"},
+		})
+		data := map[string][]byte{"config.yml": []byte(`_meta:
+  type: config
+  config_version: 2
+config:
+  dynamic:
+    kibana:
+      multitenancy_enabled: false
+    authc:
+      custom_domain:
+        order: 9
+`)}
+
+		Expect(applyAuthentication(mockClient, cluster, data)).To(Succeed())
+		config := parseConfig(data)
+		dynamic := yamlMap(yamlMap(config, "config"), "dynamic")
+		Expect(yamlMap(dynamic, "kibana")["multitenancy_enabled"]).To(BeFalse())
+		Expect(authc(config)).To(HaveKey("custom_domain"))
+		Expect(yamlMap(dynamic, "http")["xff"]).To(HaveKeyWithValue("internalProxies", "10\\..*"))
+
+		saml := authc(config)["saml_auth_domain"].(map[interface{}]interface{})
+		authenticator := yamlMap(saml, "http_authenticator")
+		Expect(authenticator["challenge"]).To(BeTrue())
+		Expect(yamlMap(authenticator, "config")["exchange_key"]).To(Equal("exchange-key"))
+		Expect(yamlMap(yamlMap(authenticator, "config"), "idp")["entity_id"]).To(Equal("idp"))
+	})
+
+	It("should fail if a referenced secret key does not exist", func() {
+		mockClient := k8s.NewMockK8sClient(GinkgoT())
+		mockClient.EXPECT().GetSecret("jwt", "auth").Return(corev1.Secret{}, nil)
+		cluster := newCluster(&opensearchv1.AuthenticationConfig{
+			Jwt: &opensearchv1.JwtAuthConfig{SigningKey: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "jwt"}, Key: "key"}},
+		})
+		Expect(applyAuthentication(mockClient, cluster, map[string][]byte{})).To(MatchError("secret jwt does not contain key key"))
+	})
+
+	It("should configure the Dashboards login", func() {
+		cluster := newCluster(&opensearchv1.AuthenticationConfig{
+			OpenID: &opensearchv1.OpenIDAuthConfig{
+				ConnectURL:   "https://idp/.well-known/openid-configuration",
+				ClientID:     "dashboards",
+				ClientSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oidc"}, Key: "secret"},
+			},
+		})
+		config := DashboardsAuthenticationConfig(cluster)
+		Expect(config).To(HaveKeyWithValue("opensearch_security.auth.type", `"openid"`))
+		Expect(config).To(HaveKeyWithValue("opensearch_security.openid.client_id", `"dashboards"`))
+		Expect(config).To(HaveKeyWithValue("opensearch_security.openid.client_secret", `"${OPENSEARCH_OPENID_CLIENT_SECRET}"`))
+
+		cluster.Spec.Security.Authentication.Saml = &opensearchv1.SamlAuthConfig{}
+		config = DashboardsAuthenticationConfig(cluster)
+		Expect(config).To(HaveKeyWithValue("opensearch_security.auth.multiple_auth_enabled", "true"))
+		Expect(config).To(HaveKeyWithValue("opensearch_security.auth.type", `["basicauth", "openid", "saml"]`))
+		Expect(config).To(HaveKey("server.xsrf.allowlist"))
+	})
+})
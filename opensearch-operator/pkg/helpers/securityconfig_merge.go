@@ -0,0 +1,129 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	SecurityConfigSourceDefault = "Default"
+	SecurityConfigSourceUser    = "User"
+)
+
+// IsSecurityConfigMergeEnabled returns true if the provided securityconfig is merged into the defaults of the operator
+func IsSecurityConfigMergeEnabled(cr *opensearchv1.OpenSearchCluster) bool {
+	return cr.Spec.Security != nil && cr.Spec.Security.Config != nil &&
+		cr.Spec.Security.Config.MergeMode == opensearchv1.SecurityConfigMergeModeMerge
+}
+
+// mergeSecurityConfig deep-merges the user provided files into the securityconfig. Files the operator has a default
+// for are merged with it, values of the user win over the defaults. It returns the source of every key.
+func mergeSecurityConfig(data map[string][]byte, userData map[string][]byte) ([]opensearchv1.SecurityConfigSource, error) {
+	for file, content := range userData {
+		defaults, ok := data[file]
+		if !ok {
+			var err error
+			defaults, err = defaultSecurityConfigFS.ReadFile(fmt.Sprintf("securityconfigdefaults/%s", file))
+			if errors.Is(err, fs.ErrNotExist) {
+				data[file] = append([]byte(nil), content...)
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
+		defaultConfig := map[interface{}]interface{}{}
+		if err := yaml.Unmarshal(defaults, &defaultConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse default %s of the securityconfig: %w", file, err)
+		}
+		userConfig := map[interface{}]interface{}{}
+		if err := yaml.Unmarshal(content, &userConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of the securityconfig: %w", file, err)
+		}
+		merged, err := yaml.Marshal(deepMerge(defaultConfig, userConfig))
+		if err != nil {
+			return nil, err
+		}
+		data[file] = merged
+	}
+
+	var sources []opensearchv1.SecurityConfigSource
+	files := make([]string, 0, len(data))
+	for file := range data {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		defaultConfig := map[interface{}]interface{}{}
+		if defaults, err := defaultSecurityConfigFS.ReadFile(fmt.Sprintf("securityconfigdefaults/%s", file)); err == nil {
+			if err := yaml.Unmarshal(defaults, &defaultConfig); err != nil {
+				return nil, err
+			}
+		}
+		userConfig := map[interface{}]interface{}{}
+		if content, ok := userData[file]; ok {
+			if err := yaml.Unmarshal(content, &userConfig); err != nil {
+				return nil, err
+			}
+		}
+		delete(defaultConfig, "_meta")
+		delete(userConfig, "_meta")
+		sources = append(sources, configSources(file, "", defaultConfig, userConfig)...)
+	}
+	return sources, nil
+}
+
+// deepMerge merges override into base. Maps are merged recursively, all other values of override replace the ones of base.
+func deepMerge(base, override map[interface{}]interface{}) map[interface{}]interface{} {
+	for key, value := range override {
+		baseMap, baseIsMap := base[key].(map[interface{}]interface{})
+		overrideMap, overrideIsMap := value.(map[interface{}]interface{})
+		if baseIsMap && overrideIsMap {
+			base[key] = deepMerge(baseMap, overrideMap)
+		} else {
+			base[key] = value
+		}
+	}
+	return base
+}
+
+// configSources returns the source that won for every key of the merged maps, descending into keys present in both
+func configSources(file, prefix string, defaults, user map[interface{}]interface{}) []opensearchv1.SecurityConfigSource {
+	keys := map[string]interface{}{}
+	for key := range defaults {
+		keys[fmt.Sprint(key)] = key
+	}
+	for key := range user {
+		keys[fmt.Sprint(key)] = key
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sources []opensearchv1.SecurityConfigSource
+	for _, name := range names {
+		key := keys[name]
+		path := strings.TrimPrefix(prefix+"."+name, ".")
+		defaultValue, inDefaults := defaults[key]
+		userValue, inUser := user[key]
+		defaultMap, defaultIsMap := defaultValue.(map[interface{}]interface{})
+		userMap, userIsMap := userValue.(map[interface{}]interface{})
+		switch {
+		case inDefaults && inUser && defaultIsMap && userIsMap:
+			sources = append(sources, configSources(file, path, defaultMap, userMap)...)
+		case inUser:
+			sources = append(sources, opensearchv1.SecurityConfigSource{File: file, Key: path, Source: SecurityConfigSourceUser})
+		default:
+			sources = append(sources, opensearchv1.SecurityConfigSource{File: file, Key: path, Source: SecurityConfigSourceDefault})
+		}
+	}
+	return sources
+}
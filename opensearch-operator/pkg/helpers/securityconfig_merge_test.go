@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Security config merge", func() {
+	parse := func(data []byte) map[interface{}]interface{} {
+		config := map[interface{}]interface{}{}
+		Expect(yaml.Unmarshal(data, &config)).To(Succeed())
+		return config
+	}
+
+	It("should deep-merge the provided files into the defaults", func() {
+		data, err := defaultSecurityconfigData()
+		Expect(err).ToNot(HaveOccurred())
+		userData := map[string][]byte{
+			"internal_users.yml": []byte(`_meta:
+  type: "internalusers"
+  config_version: 2
+admin:
+  description: "Cluster administrator"
+logstash:
+  hash: "$2y$12$abc"
+`),
+			"config.yml": []byte(`config:
+  dynamic:
+    kibana:
+      multitenancy_enabled: false
+`),
+			"roles.yml": []byte(`_meta:
+  type: "roles"
+  config_version: 2
+reader: {}
+`),
+		}
+
+		sources, err := mergeSecurityConfig(data, userData)
+		Expect(err).ToNot(HaveOccurred())
+
+		users := parse(data["internal_users.yml"])
+		admin := users["admin"].(map[interface{}]interface{})
+		Expect(admin["description"]).To(Equal("Cluster administrator"))
+		Expect(admin["reserved"]).To(BeTrue())
+		Expect(users).To(HaveKey("kibanaserver"))
+		Expect(users).To(HaveKey("logstash"))
+
+		dynamic := yamlMap(yamlMap(parse(data["config.yml"]), "config"), "dynamic")
+		Expect(dynamic).To(HaveKey("kibana"))
+		Expect(yamlMap(dynamic, "authc")).To(HaveKey(basicInternalAuthDomain))
+		Expect(data["roles.yml"]).To(Equal(userData["roles.yml"]))
+
+		Expect(sources).To(ContainElements(
+			opensearchv1.SecurityConfigSource{File: "config.yml", Key: "config.dynamic.authc", Source: SecurityConfigSourceDefault},
+			opensearchv1.SecurityConfigSource{File: "config.yml", Key: "config.dynamic.kibana", Source: SecurityConfigSourceUser},
+			opensearchv1.SecurityConfigSource{File: "internal_users.yml", Key: "admin.backend_roles", Source: SecurityConfigSourceDefault},
+			opensearchv1.SecurityConfigSource{File: "internal_users.yml", Key: "admin.description", Source: SecurityConfigSourceUser},
+			opensearchv1.SecurityConfigSource{File: "internal_users.yml", Key: "kibanaserver", Source: SecurityConfigSourceDefault},
+			opensearchv1.SecurityConfigSource{File: "internal_users.yml", Key: "logstash", Source: SecurityConfigSourceUser},
+			opensearchv1.SecurityConfigSource{File: "roles.yml", Key: "reader", Source: SecurityConfigSourceUser},
+		))
+		Expect(sources).ToNot(ContainElement(HaveField("Key", "_meta")))
+	})
+})
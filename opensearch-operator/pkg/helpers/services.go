@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"slices"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+)
+
+const (
+	// ServiceLabelPrefix prefixes the pod labels used by the client services to select their pods
+	ServiceLabelPrefix = "service.opensearch.org/"
+	// CoordinatingRole selects nodepools without roles in an additional service
+	CoordinatingRole = "coordinating"
+)
+
+// ServiceSelectorLabel returns the pod label the service with the given name selects its pods by
+func ServiceSelectorLabel(serviceName string) string {
+	return ServiceLabelPrefix + serviceName
+}
+
+// IsInClientService reports whether the pods of the nodepool are selected by the cluster service
+func IsInClientService(nodePool *opensearchv1.NodePool) bool {
+	return nodePool.ClientService == nil || *nodePool.ClientService
+}
+
+// ClientServiceFiltered reports whether any nodepool opted out of the cluster service,
+// in which case the service only selects pods carrying its selector label
+func ClientServiceFiltered(cr *opensearchv1.OpenSearchCluster) bool {
+	for i := range cr.Spec.NodePools {
+		if !IsInClientService(&cr.Spec.NodePools[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInAdditionalService reports whether the additional service selects the pods of the nodepool
+func IsInAdditionalService(service *opensearchv1.AdditionalServiceConfig, nodePool *opensearchv1.NodePool) bool {
+	if slices.Contains(service.NodePools, nodePool.Component) {
+		return true
+	}
+	if len(nodePool.Roles) == 0 {
+		return slices.Contains(service.Roles, CoordinatingRole)
+	}
+	for _, role := range nodePool.Roles {
+		if slices.Contains(service.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceLabelsForNodePool returns the pod labels that make the client services of the cluster select the nodepool
+func ServiceLabelsForNodePool(cr *opensearchv1.OpenSearchCluster, nodePool *opensearchv1.NodePool) map[string]string {
+	labels := map[string]string{}
+	if ClientServiceFiltered(cr) && IsInClientService(nodePool) {
+		labels[ServiceSelectorLabel(cr.Spec.General.ServiceName)] = "true"
+	}
+	for i := range cr.Spec.General.AdditionalServices {
+		service := &cr.Spec.General.AdditionalServices[i]
+		if IsInAdditionalService(service, nodePool) {
+			labels[ServiceSelectorLabel(service.Name)] = "true"
+		}
+	}
+	return labels
+}
@@ -69,11 +69,11 @@ func (cert *CertMock) CertData() []byte {
 	return []byte("tls.crt")
 }
 
-func (ca *CertMock) CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string, validity time.Duration) (cert tls.Cert, err error) {
+func (ca *CertMock) CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string, validity time.Duration, opts ...tls.CertOption) (cert tls.Cert, err error) {
 	return &CertMock{}, nil
 }
 
-func (pki *PkiMock) GenerateCA(name string) (ca tls.Cert, err error) {
+func (pki *PkiMock) GenerateCA(name string, opts ...tls.CertOption) (ca tls.Cert, err error) {
 	return &CertMock{}, nil
 }
 
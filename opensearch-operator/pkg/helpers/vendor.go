@@ -0,0 +1,132 @@
+package helpers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+)
+
+// Vendor describes the distribution specific parts of an OpenSearch deployment.
+// Forks and distributions that differ in image, installation directory or plugin tooling
+// can be supported by registering their own implementation with RegisterVendor.
+type Vendor interface {
+	// Name returns the canonical name of the vendor
+	Name() string
+	// DefaultRepo returns the image repository used if spec.general.defaultRepo is not set
+	DefaultRepo() string
+	// ImageName returns the name of the node image inside the repository
+	ImageName() string
+	// DefaultHome returns the installation directory inside the container
+	DefaultHome() string
+	// Entrypoint returns the command that starts a node, relative to the installation directory
+	Entrypoint() string
+	// PluginInstaller returns the plugin installation tool, relative to the installation directory
+	PluginInstaller() string
+	// SecurityPluginName returns the directory name of the security plugin
+	SecurityPluginName() string
+	// SecurityAdminScript returns the path of the tool used to apply the security config
+	SecurityAdminScript(home string) string
+	// ClusterManagerRole returns the name of the cluster manager role for the given version
+	ClusterManagerRole(version string) string
+	// MapClusterRole maps a node role to the name used by the given version
+	MapClusterRole(role string, version string) string
+}
+
+const DefaultVendorName = "opensearch"
+
+var vendors = map[string]Vendor{}
+
+// RegisterVendor makes a vendor available under its name and the given aliases (case-insensitive)
+func RegisterVendor(vendor Vendor, aliases ...string) {
+	for _, name := range append([]string{vendor.Name()}, aliases...) {
+		vendors[strings.ToLower(name)] = vendor
+	}
+}
+
+// LookupVendor returns the vendor registered for the given name, an empty name selects the default vendor
+func LookupVendor(name string) (Vendor, error) {
+	if name == "" {
+		name = DefaultVendorName
+	}
+	vendor, ok := vendors[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown vendor '%s', supported vendors are: %s", name, strings.Join(VendorNames(), ", "))
+	}
+	return vendor, nil
+}
+
+// VendorNames returns the sorted names and aliases of all registered vendors
+func VendorNames() []string {
+	names := make([]string, 0, len(vendors))
+	for name := range vendors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// VendorForCluster returns the vendor of the cluster. Unknown vendors are rejected by the webhook
+// and the cluster reconciler, so the default vendor is returned for them here.
+func VendorForCluster(cr *opensearchv1.OpenSearchCluster) Vendor {
+	vendor, err := LookupVendor(cr.Spec.General.Vendor)
+	if err != nil {
+		vendor, _ = LookupVendor(DefaultVendorName)
+	}
+	return vendor
+}
+
+// OpenSearchHome returns the installation directory of the cluster, either the configured one or the vendor default
+func OpenSearchHome(cr *opensearchv1.OpenSearchCluster) string {
+	if cr.Spec.General.OpenSearchHome != "" {
+		return strings.TrimRight(cr.Spec.General.OpenSearchHome, "/")
+	}
+	return VendorForCluster(cr).DefaultHome()
+}
+
+type openSearchVendor struct{}
+
+func (openSearchVendor) Name() string {
+	return DefaultVendorName
+}
+
+func (openSearchVendor) DefaultRepo() string {
+	return "docker.io/opensearchproject"
+}
+
+func (openSearchVendor) ImageName() string {
+	return "opensearch"
+}
+
+func (openSearchVendor) DefaultHome() string {
+	return opensearchv1.DefaultOpenSearchHome
+}
+
+func (openSearchVendor) Entrypoint() string {
+	return "./opensearch-docker-entrypoint.sh"
+}
+
+func (openSearchVendor) PluginInstaller() string {
+	return "./bin/opensearch-plugin"
+}
+
+func (openSearchVendor) SecurityPluginName() string {
+	return "opensearch-security"
+}
+
+func (v openSearchVendor) SecurityAdminScript(home string) string {
+	return fmt.Sprintf("%s/plugins/%s/tools/securityadmin.sh", home, v.SecurityPluginName())
+}
+
+func (openSearchVendor) ClusterManagerRole(version string) string {
+	return ResolveClusterManagerRole(version)
+}
+
+func (openSearchVendor) MapClusterRole(role string, version string) string {
+	return MapClusterRole(role, version)
+}
+
+func init() {
+	RegisterVendor(openSearchVendor{}, "op", "os")
+}
@@ -0,0 +1,277 @@
+package reconcilers
+
+import (
+	"fmt"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileAutoscaling evaluates the policies of the autoscaled nodepools, syncs the scale targets of nodepools and
+// records the number of replicas they should have in the status. The nodepools are then scaled one node at a time by
+// reconcileNodePool, so scale-downs are drained by the SmartScaler.
+func (r *ScalerReconciler) reconcileAutoscaling() (*ctrl.Result, error) {
+	lg := log.FromContext(r.ctx)
+	var statuses []opensearchv1.AutoscalingStatus
+	var nodesStats *responses.NodesStatsResponse
+	readNodesStats := func() (responses.NodesStatsResponse, error) {
+		if nodesStats == nil {
+			clusterClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+			if err != nil {
+				return responses.NodesStatsResponse{}, err
+			}
+			stats, err := clusterClient.NodesStats()
+			if err != nil {
+				return responses.NodesStatsResponse{}, err
+			}
+			nodesStats = &stats
+		}
+		return *nodesStats, nil
+	}
+
+	for _, nodePool := range r.instance.Spec.NodePools {
+		status, found := helpers.FindAutoscalingStatus(r.instance.Status.Autoscaling, nodePool.Component)
+		if !found {
+			status = opensearchv1.AutoscalingStatus{Component: nodePool.Component}
+		}
+		if nodePool.ScaleTarget {
+			status, err := r.reconcileScaleTarget(&nodePool, status)
+			if err != nil {
+				lg.Error(err, "failed to reconcile the scale target", "nodepool", nodePool.Component)
+				return nil, err
+			}
+			statuses = append(statuses, status)
+			continue
+		}
+		autoscaling := helpers.NodePoolAutoscaling(r.instance, &nodePool)
+		if autoscaling == nil {
+			continue
+		}
+		status.DesiredReplicas = helpers.NodePoolReplicas(r.instance, &nodePool)
+
+		if err := helpers.ValidateAutoscaling(autoscaling); err != nil {
+			status.Message = fmt.Sprintf("Invalid autoscaling configuration: %s", err)
+			statuses = append(statuses, status)
+			continue
+		}
+		currentSts, err := r.client.GetStatefulSet(builders.StsName(r.instance, &nodePool), r.instance.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if reason := r.autoscalingBlocked(&nodePool, currentSts, status.DesiredReplicas); reason != "" {
+			status.Message = reason
+			// Keep the sample of the search rejections current, so the first evaluation afterwards only sees the
+			// rejections since then. Without node stats the sample is dropped.
+			if lo.ContainsBy(autoscaling.Policies, func(policy opensearchv1.AutoscalingPolicy) bool {
+				return policy.Metric == opensearchv1.AutoscalingMetricSearchRejections
+			}) {
+				stats, err := readNodesStats()
+				if err != nil {
+					lg.V(1).Info("Unable to read node stats while autoscaling is blocked", "nodepool", nodePool.Component, "error", err.Error())
+				}
+				status.SearchRejections = searchRejectionsSample(currentSts, stats)
+			}
+			statuses = append(statuses, status)
+			continue
+		}
+		stats, err := readNodesStats()
+		if err != nil {
+			lg.Error(err, "failed to read node stats for autoscaling")
+			return nil, err
+		}
+		statuses = append(statuses, r.evaluateAutoscaling(autoscaling, currentSts, status, stats, time.Now()))
+	}
+	if err := r.deleteRemovedScaleTargets(); err != nil {
+		return nil, err
+	}
+
+	if !equality.Semantic.DeepEqual(statuses, r.instance.Status.Autoscaling) {
+		err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+			instance.Status.Autoscaling = statuses
+		})
+		if err != nil {
+			lg.Error(err, "failed to update status")
+			return nil, err
+		}
+	}
+	// The new number of replicas is applied with the next reconcile, after the TLS reconciler created the certificates
+	// of new nodes. The policies are evaluated again with the periodic reconcile of the cluster.
+	return nil, nil
+}
+
+// autoscalingBlocked returns why the autoscaler must not make a decision for the nodepool, e.g. because the nodepool
+// has not reached the number of replicas of the last decision yet
+func (r *ScalerReconciler) autoscalingBlocked(nodePool *opensearchv1.NodePool, currentSts appsv1.StatefulSet, desiredReplicas int32) string {
+	if !r.instance.Status.Initialized {
+		return "Waiting for the cluster to be initialized"
+	}
+	if helpers.IsUpgradeInProgress(r.instance.Status) {
+		return "Waiting for the upgrade to finish"
+	}
+	if helpers.IsNodePoolMigrationInProgress(r.instance.Status, nodePool.Component) {
+		return "Waiting for the migration of the nodepool to finish"
+	}
+	_, scaling := helpers.FindFirstPartial(r.instance.Status.ComponentsStatus, opensearchv1.ComponentStatus{
+		Component:   "Scaler",
+		Description: nodePool.Component,
+	}, helpers.GetByDescriptionAndComponent)
+	if scaling || *currentSts.Spec.Replicas != desiredReplicas || currentSts.Status.ReadyReplicas != desiredReplicas {
+		return fmt.Sprintf("Waiting for the nodepool to reach %d ready replicas", desiredReplicas)
+	}
+	return ""
+}
+
+// evaluateAutoscaling decides whether the nodepool is scaled by one node. A recommendation of the policies is only
+// followed after it persisted for the stabilization window and the cooldown since the last decision passed.
+func (r *ScalerReconciler) evaluateAutoscaling(
+	autoscaling *opensearchv1.NodePoolAutoscaling,
+	currentSts appsv1.StatefulSet,
+	status opensearchv1.AutoscalingStatus,
+	nodesStats responses.NodesStatsResponse,
+	now time.Time,
+) opensearchv1.AutoscalingStatus {
+	metrics := map[opensearchv1.AutoscalingMetric]bool{}
+	for _, policy := range autoscaling.Policies {
+		metrics[policy.Metric] = true
+	}
+	nodeNames := nodePoolNodeNames(currentSts)
+	values := map[opensearchv1.AutoscalingMetric]float64{}
+	counts := map[opensearchv1.AutoscalingMetric]int{}
+	for _, node := range nodesStats.Nodes {
+		if !nodeNames[node.Name] {
+			continue
+		}
+		for metric := range metrics {
+			if value, ok := helpers.NodeStatsMetric(node, metric); ok {
+				values[metric] += value
+				counts[metric]++
+			}
+		}
+	}
+	if len(counts) == 0 {
+		status.Message = "No node stats reported for the nodes of the nodepool"
+		status.SearchRejections = nil
+		return status
+	}
+	for metric, count := range counts {
+		if metric == opensearchv1.AutoscalingMetricSearchRejections {
+			continue
+		}
+		values[metric] /= float64(count)
+	}
+	previous := status.SearchRejections
+	status.SearchRejections = nil
+	if total, ok := values[opensearchv1.AutoscalingMetricSearchRejections]; ok {
+		status.SearchRejections = ptr.To(int64(total))
+		// Counters are reset when nodes restart, the increase is only known from the second sample on
+		if previous == nil || total < float64(*previous) {
+			values[opensearchv1.AutoscalingMetricSearchRejections] = 0
+		} else {
+			values[opensearchv1.AutoscalingMetricSearchRejections] = total - float64(*previous)
+		}
+	}
+
+	recommendation, message := autoscalingRecommendation(autoscaling.Policies, values)
+	if recommendation == helpers.AutoscalingScaleUp && status.DesiredReplicas >= autoscaling.MaxReplicas {
+		recommendation, message = "", message+", the nodepool already has maxReplicas"
+	}
+	if recommendation == helpers.AutoscalingScaleDown && status.DesiredReplicas <= autoscaling.MinReplicas {
+		recommendation, message = "", message+", the nodepool already has minReplicas"
+	}
+	if recommendation != status.Recommendation {
+		status.Recommendation = recommendation
+		status.RecommendationTime = nil
+		if recommendation != "" {
+			status.RecommendationTime = &metav1.Time{Time: now}
+		}
+	}
+	status.Message = message
+	if recommendation == "" {
+		return status
+	}
+
+	scaleUpWindow, scaleDownWindow, cooldown := helpers.AutoscalingWindows(autoscaling)
+	window := scaleDownWindow
+	if recommendation == helpers.AutoscalingScaleUp {
+		window = scaleUpWindow
+	}
+	if now.Sub(status.RecommendationTime.Time) < window {
+		status.Message = fmt.Sprintf("%s, waiting for the stabilization window of %s", message, window)
+		return status
+	}
+	if status.LastScaleTime != nil && now.Sub(status.LastScaleTime.Time) < cooldown {
+		status.Message = fmt.Sprintf("%s, waiting for the cooldown of %s", message, cooldown)
+		return status
+	}
+
+	from := status.DesiredReplicas
+	if recommendation == helpers.AutoscalingScaleUp {
+		status.DesiredReplicas++
+	} else {
+		status.DesiredReplicas--
+	}
+	status.LastScaleTime = &metav1.Time{Time: now}
+	status.Recommendation = ""
+	status.RecommendationTime = nil
+	status.Message = fmt.Sprintf("Scaling from %d to %d replicas: %s", from, status.DesiredReplicas, message)
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "Autoscaler", "Scaling nodepool %s from %d to %d replicas: %s", status.Component, from, status.DesiredReplicas, message)
+	return status
+}
+
+// nodePoolNodeNames returns the names of the nodes of the nodepool
+func nodePoolNodeNames(currentSts appsv1.StatefulSet) map[string]bool {
+	nodeNames := map[string]bool{}
+	for i := int32(0); i < *currentSts.Spec.Replicas; i++ {
+		nodeNames[helpers.ReplicaHostName(currentSts, i)] = true
+	}
+	return nodeNames
+}
+
+// searchRejectionsSample returns the total number of rejected search requests of the nodes of the nodepool,
+// or nil if the node stats do not report it for any node
+func searchRejectionsSample(currentSts appsv1.StatefulSet, nodesStats responses.NodesStatsResponse) *int64 {
+	nodeNames := nodePoolNodeNames(currentSts)
+	var total *int64
+	for _, node := range nodesStats.Nodes {
+		if !nodeNames[node.Name] {
+			continue
+		}
+		if value, ok := helpers.NodeStatsMetric(node, opensearchv1.AutoscalingMetricSearchRejections); ok {
+			total = ptr.To(ptr.Deref(total, 0) + int64(value))
+		}
+	}
+	return total
+}
+
+// autoscalingRecommendation returns ScaleUp if any policy reached its scale-up threshold and ScaleDown if all policies
+// are below their scale-down threshold
+func autoscalingRecommendation(policies []opensearchv1.AutoscalingPolicy, values map[opensearchv1.AutoscalingMetric]float64) (string, string) {
+	for _, policy := range policies {
+		value, ok := values[policy.Metric]
+		if !ok {
+			return "", fmt.Sprintf("%s is not reported by the node stats", policy.Metric)
+		}
+		if value >= float64(policy.ScaleUpThreshold) {
+			return helpers.AutoscalingScaleUp, fmt.Sprintf("%s reached the scale-up threshold of %d", policy.Metric, policy.ScaleUpThreshold)
+		}
+	}
+	for _, policy := range policies {
+		if policy.ScaleDownThreshold == nil || values[policy.Metric] >= float64(*policy.ScaleDownThreshold) {
+			return "", "All policies are within their thresholds"
+		}
+	}
+	return helpers.AutoscalingScaleDown, "All policies are below their scale-down thresholds"
+}
@@ -0,0 +1,149 @@
+package reconcilers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Autoscaler", func() {
+	var (
+		underTest   *ScalerReconciler
+		recorder    *record.FakeRecorder
+		autoscaling *opensearchv1.NodePoolAutoscaling
+		currentSts  appsv1.StatefulSet
+		now         time.Time
+	)
+
+	nodeStats := func(cpu ...float64) responses.NodesStatsResponse {
+		stats := responses.NodesStatsResponse{Nodes: map[string]responses.NodeStatResponse{
+			"other": {Name: "autoscaling-masters-0", Os: map[string]interface{}{"cpu": map[string]interface{}{"percent": float64(100)}}},
+		}}
+		for i, value := range cpu {
+			name := helpers.ReplicaHostName(currentSts, int32(i))
+			stats.Nodes[name] = responses.NodeStatResponse{
+				Name:       name,
+				Os:         map[string]interface{}{"cpu": map[string]interface{}{"percent": value}},
+				ThreadPool: map[string]responses.NodeStatThreadPool{"search": {Rejected: uint32(10 * (i + 1))}},
+			}
+		}
+		return stats
+	}
+
+	BeforeEach(func() {
+		spec := &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "autoscaling", Namespace: "autoscaling"},
+			Spec: opensearchv1.ClusterSpec{
+				ConfMgmt:  opensearchv1.ConfMgmt{AutoScaler: true, SmartScaler: true},
+				NodePools: []opensearchv1.NodePool{{Component: "data", Replicas: 2}},
+			},
+		}
+		underTest = newScalerReconciler(k8s.NewMockK8sClient(GinkgoT()), spec)
+		recorder = record.NewFakeRecorder(1)
+		underTest.recorder = recorder
+		autoscaling = &opensearchv1.NodePoolAutoscaling{
+			MinReplicas: 2,
+			MaxReplicas: 4,
+			Policies: []opensearchv1.AutoscalingPolicy{
+				{Metric: opensearchv1.AutoscalingMetricCpu, ScaleUpThreshold: 80, ScaleDownThreshold: ptr.To[int32](20)},
+			},
+		}
+		currentSts = appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "autoscaling-data"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](2)},
+		}
+		now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("should wait for the stabilization window before scaling up", func() {
+		status := opensearchv1.AutoscalingStatus{Component: "data", DesiredReplicas: 2}
+
+		status = underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(90, 80), now)
+		Expect(status.DesiredReplicas).To(Equal(int32(2)))
+		Expect(status.Recommendation).To(Equal(helpers.AutoscalingScaleUp))
+		Expect(status.RecommendationTime.Time).To(Equal(now))
+		Expect(status.Message).To(Equal("Cpu reached the scale-up threshold of 80, waiting for the stabilization window of 3m0s"))
+		Expect(recorder.Events).To(BeEmpty())
+
+		status = underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(90, 80), now.Add(3*time.Minute))
+		Expect(status.DesiredReplicas).To(Equal(int32(3)))
+		Expect(status.Recommendation).To(BeEmpty())
+		Expect(status.RecommendationTime).To(BeNil())
+		Expect(status.LastScaleTime.Time).To(Equal(now.Add(3 * time.Minute)))
+		Expect(status.Message).To(Equal("Scaling from 2 to 3 replicas: Cpu reached the scale-up threshold of 80"))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal Autoscaler Scaling nodepool data from 2 to 3 replicas: Cpu reached the scale-up threshold of 80")))
+	})
+
+	It("should reset the recommendation when the metrics recover", func() {
+		status := underTest.evaluateAutoscaling(autoscaling, currentSts, opensearchv1.AutoscalingStatus{Component: "data", DesiredReplicas: 2}, nodeStats(90, 80), now)
+		status = underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(50, 50), now.Add(time.Minute))
+		Expect(status.Recommendation).To(BeEmpty())
+		Expect(status.RecommendationTime).To(BeNil())
+		Expect(status.Message).To(Equal("All policies are within their thresholds"))
+	})
+
+	It("should respect the cooldown and the replica limits", func() {
+		autoscaling.ScaleUpStabilizationWindow = &metav1.Duration{}
+		status := opensearchv1.AutoscalingStatus{Component: "data", DesiredReplicas: 2, LastScaleTime: &metav1.Time{Time: now.Add(-time.Minute)}}
+		status = underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(90, 90), now)
+		Expect(status.DesiredReplicas).To(Equal(int32(2)))
+		Expect(status.Message).To(HaveSuffix("waiting for the cooldown of 10m0s"))
+
+		status.DesiredReplicas = 4
+		status = underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(90, 90), now.Add(time.Hour))
+		Expect(status.DesiredReplicas).To(Equal(int32(4)))
+		Expect(status.Message).To(Equal("Cpu reached the scale-up threshold of 80, the nodepool already has maxReplicas"))
+	})
+
+	It("should only scale down when every policy allows it", func() {
+		autoscaling.ScaleDownStabilizationWindow = &metav1.Duration{}
+		currentSts.Spec.Replicas = ptr.To[int32](3)
+		status := opensearchv1.AutoscalingStatus{Component: "data", DesiredReplicas: 3}
+
+		autoscaling.Policies = append(autoscaling.Policies, opensearchv1.AutoscalingPolicy{Metric: opensearchv1.AutoscalingMetricSearchRejections, ScaleUpThreshold: 100})
+		Expect(underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(10, 10, 10), now).DesiredReplicas).To(Equal(int32(3)))
+
+		autoscaling.Policies[1].ScaleDownThreshold = ptr.To[int32](1)
+		status = underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(10, 10, 10), now)
+		Expect(status.DesiredReplicas).To(Equal(int32(2)))
+		Expect(status.SearchRejections).To(HaveValue(Equal(int64(60))))
+		Expect(status.Message).To(Equal("Scaling from 3 to 2 replicas: All policies are below their scale-down thresholds"))
+	})
+
+	It("should scale up on new search rejections", func() {
+		autoscaling.ScaleUpStabilizationWindow = &metav1.Duration{}
+		autoscaling.Policies = []opensearchv1.AutoscalingPolicy{{Metric: opensearchv1.AutoscalingMetricSearchRejections, ScaleUpThreshold: 20}}
+		status := opensearchv1.AutoscalingStatus{Component: "data", DesiredReplicas: 2, SearchRejections: ptr.To[int64](10)}
+
+		status = underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(0, 0), now)
+		Expect(status.DesiredReplicas).To(Equal(int32(3)))
+		Expect(status.SearchRejections).To(HaveValue(Equal(int64(30))))
+	})
+
+	It("should count search rejections after a sample without rejections", func() {
+		autoscaling.ScaleUpStabilizationWindow = &metav1.Duration{}
+		autoscaling.Policies = []opensearchv1.AutoscalingPolicy{{Metric: opensearchv1.AutoscalingMetricSearchRejections, ScaleUpThreshold: 20}}
+
+		status := underTest.evaluateAutoscaling(autoscaling, currentSts, opensearchv1.AutoscalingStatus{Component: "data", DesiredReplicas: 2}, nodeStats(0, 0), now)
+		Expect(status.DesiredReplicas).To(Equal(int32(2)))
+		Expect(status.SearchRejections).To(HaveValue(Equal(int64(30))))
+
+		status.SearchRejections = ptr.To[int64](0)
+		status = underTest.evaluateAutoscaling(autoscaling, currentSts, status, nodeStats(0, 0), now)
+		Expect(status.DesiredReplicas).To(Equal(int32(3)))
+	})
+
+	It("should sample the search rejections of the nodes of the nodepool", func() {
+		Expect(searchRejectionsSample(currentSts, nodeStats(0, 0))).To(HaveValue(Equal(int64(30))))
+		Expect(searchRejectionsSample(currentSts, responses.NodesStatsResponse{})).To(BeNil())
+	})
+})
@@ -0,0 +1,330 @@
+package reconcilers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/tls"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	caRotationReason = "CaRotation"
+	// Time given to the nodes to reload changed certificates when hot reload is enabled
+	caRotationHotReloadDelay = 5 * time.Minute
+)
+
+func (r *TLSReconciler) caSecretName() string {
+	return r.instance.Name + "-ca"
+}
+
+func (r *TLSReconciler) nextCaSecretName() string {
+	return r.instance.Name + "-ca-next"
+}
+
+func (r *TLSReconciler) previousCaSecretName() string {
+	return r.instance.Name + "-ca-previous"
+}
+
+// usesGeneratedCa reports whether any interface uses certificates signed by the CA generated by the operator,
+// which is the only CA the operator can rotate
+func (r *TLSReconciler) usesGeneratedCa() bool {
+	tlsConfig := r.instance.Spec.Security.Tls
+	generated := func(generate bool, config *opensearchv1.TlsCertificateConfig) bool {
+		return generate && config.CaSecret.Name == "" && !helpers.CertManagerEnabled(config)
+	}
+	return (r.isTransportTlsEnabled(tlsConfig) && generated(tlsConfig.Transport.Generate, &tlsConfig.Transport.TlsCertificateConfig)) ||
+		(r.isHttpTlsEnabled(tlsConfig) && generated(tlsConfig.Http.Generate, &tlsConfig.Http.TlsCertificateConfig))
+}
+
+// certificatesHotReloaded reports whether the nodes reload changed certificates without a restart,
+// which requires hot reloading on every enabled TLS interface
+func (r *TLSReconciler) certificatesHotReloaded() bool {
+	tlsConfig := r.instance.Spec.Security.Tls
+	if !helpers.SupportsHotReload(r.instance) {
+		return false
+	}
+	transportEnabled := r.isTransportTlsEnabled(tlsConfig)
+	httpEnabled := r.isHttpTlsEnabled(tlsConfig)
+	if transportEnabled && !tlsConfig.Transport.EnableHotReload {
+		return false
+	}
+	if httpEnabled && !tlsConfig.Http.EnableHotReload {
+		return false
+	}
+	return transportEnabled || httpEnabled
+}
+
+// reconcileCaRotation advances the rotation of the generated CA. A rotation runs in three phases, each one waiting
+// until all nodes have loaded the changed certificates before the next one starts:
+// the new CA is added to the trust bundle, all certificates are reissued from the new CA while the old CA is still
+// trusted and finally the old CA is removed from the trust bundle.
+func (r *TLSReconciler) reconcileCaRotation() error {
+	if !r.usesGeneratedCa() || !r.instance.Status.Initialized {
+		return nil
+	}
+
+	status := lo.FromPtr(r.instance.Status.CaRotation)
+	switch status.Phase {
+	case "":
+		reason, err := r.caRotationReason(status)
+		if err != nil || reason == "" {
+			return err
+		}
+		open, err := maintenanceWindowOpen(r.client, r.instance)
+		if err != nil || !open {
+			r.logger.V(1).Info("Waiting for the next maintenance window to start the CA rotation", "reason", reason)
+			return err
+		}
+		next, err := r.readOrGenerateNextCa()
+		if err != nil {
+			return err
+		}
+		r.logger.Info("Starting CA rotation", "reason", reason)
+		r.recorder.AnnotatedEventf(r.instance, map[string]string{"cluster-name": r.instance.GetName()}, "Normal", caRotationReason, "Starting CA rotation: %s", reason)
+
+		r.additionalTrustedCa = next.CertData()
+		status.Reason = reason
+		status.LastRequest = r.instance.Annotations[helpers.RotateCaAnnotation]
+		return r.setCaRotationPhase(status, opensearchv1.CaRotationPhaseDistributingTrust)
+
+	case opensearchv1.CaRotationPhaseDistributingTrust:
+		next, err := r.client.GetSecret(r.nextCaSecretName(), r.instance.Namespace)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		// The next CA is only missing if it was already activated before the status could be updated
+		if err == nil {
+			r.additionalTrustedCa = next.Data[CaCertKey]
+			loaded, err := r.certificatesLoaded(status)
+			if err != nil || !loaded {
+				return err
+			}
+			if err := r.activateNextCa(next); err != nil {
+				return err
+			}
+		}
+		previous, err := r.getPreviousCa()
+		if err != nil {
+			return err
+		}
+		r.logger.Info("Reissuing certificates from the new CA")
+		r.additionalTrustedCa = previous.Data[CaCertKey]
+		r.reissueCertificates = true
+		return r.setCaRotationPhase(status, opensearchv1.CaRotationPhaseReissuing)
+
+	case opensearchv1.CaRotationPhaseReissuing:
+		previous, err := r.getPreviousCa()
+		if err != nil {
+			return err
+		}
+		r.additionalTrustedCa = previous.Data[CaCertKey]
+		r.reissueCertificates = true
+		loaded, err := r.certificatesLoaded(status)
+		if err != nil || !loaded {
+			return err
+		}
+
+		r.logger.Info("Removing the old CA from the trust bundle")
+		if previous.Name != "" {
+			if _, err := r.client.ReconcileResource(previous, reconciler.StateAbsent); err != nil {
+				return err
+			}
+		}
+		r.additionalTrustedCa = nil
+		return r.setCaRotationPhase(status, opensearchv1.CaRotationPhaseDroppingOldCa)
+
+	case opensearchv1.CaRotationPhaseDroppingOldCa:
+		loaded, err := r.certificatesLoaded(status)
+		if err != nil || !loaded {
+			return err
+		}
+		r.logger.Info("CA rotation completed")
+		r.recorder.AnnotatedEventf(r.instance, map[string]string{"cluster-name": r.instance.GetName()}, "Normal", caRotationReason, "CA rotation completed")
+		return r.setCaRotationPhase(status, "")
+	}
+	return nil
+}
+
+// caRotationReason returns why a rotation should be started, or an empty string if the CA does not need to be rotated
+func (r *TLSReconciler) caRotationReason(status opensearchv1.CaRotationStatus) (string, error) {
+	if request := r.instance.Annotations[helpers.RotateCaAnnotation]; request != "" && request != status.LastRequest {
+		return fmt.Sprintf("requested with annotation %s=%s", helpers.RotateCaAnnotation, request), nil
+	}
+
+	rotateDaysBeforeExpiry := r.instance.Spec.Security.Tls.RotateCaDaysBeforeExpiry
+	if rotateDaysBeforeExpiry <= 0 {
+		return "", nil
+	}
+	caSecret, err := r.client.GetSecret(r.caSecretName(), r.instance.Namespace)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	daysRemaining, err := getDaysRemainingFromCertificate(caSecret.Data[CaCertKey])
+	if err != nil {
+		r.logger.Error(err, "Failed to parse CA certificate for expiry date - not rotating")
+		return "", nil
+	}
+	if daysRemaining < rotateDaysBeforeExpiry {
+		return fmt.Sprintf("CA expires in %d days", daysRemaining), nil
+	}
+	return "", nil
+}
+
+// readOrGenerateNextCa returns the CA that replaces the current one, generating it if it does not exist yet
+func (r *TLSReconciler) readOrGenerateNextCa() (tls.Cert, error) {
+	secret, err := r.client.GetSecret(r.nextCaSecretName(), r.instance.Namespace)
+	if err == nil {
+		return r.pki.CAFromSecret(secret.Data), nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	ca, err := r.pki.GenerateCA(r.instance.Name, helpers.CertOptions(helpers.GeneratedCaCertConfig(r.instance))...)
+	if err != nil {
+		r.logger.Error(err, "Failed to create CA")
+		return nil, err
+	}
+	secret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: r.nextCaSecretName(), Namespace: r.instance.Namespace},
+		Data:       ca.SecretDataCA(),
+	}
+	if err := ctrl.SetControllerReference(r.instance, &secret, r.client.Scheme()); err != nil {
+		return nil, err
+	}
+	if _, err := r.client.CreateSecret(&secret); err != nil {
+		r.logger.Error(err, "Failed to store CA in secret")
+		return nil, err
+	}
+	return ca, nil
+}
+
+// activateNextCa makes the next CA the one used to sign certificates. The replaced CA is kept in a separate
+// secret as long as it is part of the trust bundle.
+func (r *TLSReconciler) activateNextCa(next corev1.Secret) error {
+	namespace := r.instance.Namespace
+	current, err := r.client.GetSecret(r.caSecretName(), namespace)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.client.GetSecret(r.previousCaSecretName(), namespace); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		previous := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: r.previousCaSecretName(), Namespace: namespace},
+			Data:       current.Data,
+		}
+		if err := ctrl.SetControllerReference(r.instance, &previous, r.client.Scheme()); err != nil {
+			return err
+		}
+		if _, err := r.client.CreateSecret(&previous); err != nil {
+			return err
+		}
+	}
+
+	current.Data = next.Data
+	if err := r.client.UpdateSecret(&current); err != nil {
+		return err
+	}
+	_, err = r.client.ReconcileResource(&next, reconciler.StateAbsent)
+	return err
+}
+
+// getPreviousCa returns the secret of the CA replaced by a rotation, or an empty secret if it does not exist
+func (r *TLSReconciler) getPreviousCa() (*corev1.Secret, error) {
+	previous, err := r.client.GetSecret(r.previousCaSecretName(), r.instance.Namespace)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &corev1.Secret{}, nil
+		}
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// certificatesLoaded reports whether all nodes have loaded the certificates of the current phase, either by a
+// rolling restart of all node pools or by hot reload
+func (r *TLSReconciler) certificatesLoaded(status opensearchv1.CaRotationStatus) (bool, error) {
+	if r.certificatesHotReloaded() {
+		return status.PhaseStartedAt != nil && time.Since(status.PhaseStartedAt.Time) > caRotationHotReloadDelay, nil
+	}
+
+	revision := strconv.Itoa(int(status.Revision))
+	for _, nodePool := range r.instance.Spec.NodePools {
+		sts, err := r.client.GetStatefulSet(builders.StsName(r.instance, &nodePool), r.instance.Namespace)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		replicas := lo.FromPtrOr(sts.Spec.Replicas, 1)
+		if sts.Spec.Template.Annotations[builders.CaRevisionAnnotation] != revision ||
+			sts.Status.ObservedGeneration < sts.Generation ||
+			sts.Status.UpdatedReplicas != replicas ||
+			sts.Status.ReadyReplicas != replicas {
+			r.logger.Info("Waiting for nodes to restart with the rotated certificates", "nodePool", nodePool.Component)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// setCaRotationPhase moves the rotation to the next phase. Unless hot reload is enabled the revision is increased,
+// which restarts all nodes to load the changed certificates.
+func (r *TLSReconciler) setCaRotationPhase(status opensearchv1.CaRotationStatus, phase opensearchv1.CaRotationPhase) error {
+	now := metav1.Now()
+	status.Phase = phase
+	if phase == "" {
+		status.Reason = ""
+		status.PhaseStartedAt = nil
+		status.LastCompletedAt = &now
+	} else {
+		status.PhaseStartedAt = &now
+		if !r.certificatesHotReloaded() {
+			status.Revision++
+		}
+	}
+
+	// The statefulsets are built from the instance later in this reconcile
+	r.instance.Status.CaRotation = &status
+	return r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.CaRotation = &status
+	})
+}
+
+// trustedCaData returns the CA certificates nodes should trust. While a rotation of the generated CA is in progress
+// both the old and the new CA are trusted.
+func (r *TLSReconciler) trustedCaData(ca tls.Cert, caSecret corev1.LocalObjectReference) []byte {
+	if caSecret.Name != "" || len(r.additionalTrustedCa) == 0 {
+		return ca.CertData()
+	}
+	return append(append([]byte{}, ca.CertData()...), r.additionalTrustedCa...)
+}
+
+// certSignedByOtherCa reports whether the certificate was not issued by the given CA, e.g. by the CA replaced
+// in a CA rotation
+func certSignedByOtherCa(data []byte, ca tls.Cert) bool {
+	validator, err := tls.NewCertValidater(data)
+	if err != nil {
+		return false
+	}
+	signed, err := validator.IsSignedByCA(ca)
+	return err == nil && !signed
+}
@@ -0,0 +1,194 @@
+package reconcilers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/tls"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("TLS Controller", func() {
+	Context("When rotating the generated CA", func() {
+		newSpec := func(clusterName string, status *opensearchv1.CaRotationStatus) opensearchv1.OpenSearchCluster {
+			return opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        clusterName,
+					Namespace:   clusterName,
+					UID:         "dummyuid",
+					Annotations: map[string]string{helpers.RotateCaAnnotation: "2024-01"},
+				},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{},
+					Security: &opensearchv1.Security{Tls: &opensearchv1.TlsConfig{
+						Transport: &opensearchv1.TlsConfigTransport{Generate: true},
+						Http:      &opensearchv1.TlsConfigHttp{Generate: true},
+					}},
+					NodePools: []opensearchv1.NodePool{{Component: "masters", Replicas: 3}},
+				},
+				Status: opensearchv1.ClusterStatus{Initialized: true, CaRotation: status},
+			}
+		}
+		existingCertSecret := func(name string) corev1.Secret {
+			return corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name}, Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("tls.crt"),
+				corev1.TLSPrivateKeyKey: []byte("tls.key"),
+				CaCertKey:               []byte("ca.crt"),
+			}}
+		}
+		expectCertificateSecrets := func(mockClient *k8s.MockK8sClient, clusterName string) map[string]*corev1.Secret {
+			secrets := map[string]*corev1.Secret{}
+			mockClient.EXPECT().Context().Return(context.Background())
+			mockClient.EXPECT().GetSecret(clusterName+"-transport-cert", clusterName).Return(existingCertSecret(clusterName+"-transport-cert"), nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-http-cert", clusterName).Return(existingCertSecret(clusterName+"-http-cert"), nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool {
+				return secret.Name != clusterName+"-ca-next" && secret.Name != clusterName+"-ca-previous"
+			})).
+				Run(func(args mock.Arguments) {
+					secret := args.Get(0).(*corev1.Secret)
+					secrets[secret.Name] = secret
+				}).Return(&ctrl.Result{}, nil)
+			return secrets
+		}
+		It("should generate the new CA and trust both CAs when requested with the annotation", func() {
+			clusterName := "ca-rotation-start"
+			spec := newSpec(clusterName, nil)
+
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			mockClient.EXPECT().GetSecret(clusterName+"-ca-next", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool { return secret.Name == clusterName+"-ca-next" })).Return(&ctrl.Result{}, nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-ca", clusterName).Return(corev1.Secret{Data: map[string][]byte{CaCertKey: []byte("ca.crt")}}, nil)
+			updated := spec.DeepCopy()
+			expectStatusUpdate(mockClient, &spec, updated)
+			secrets := expectCertificateSecrets(mockClient, clusterName)
+
+			_, underTest := newTLSReconciler(mockClient, &spec)
+			_, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(updated.Status.CaRotation.Phase).To(Equal(opensearchv1.CaRotationPhaseDistributingTrust))
+			Expect(updated.Status.CaRotation.Revision).To(BeEquivalentTo(1))
+			Expect(updated.Status.CaRotation.LastRequest).To(Equal("2024-01"))
+			Expect(spec.Status.CaRotation).To(Equal(updated.Status.CaRotation))
+			// The mocked CAs both return tls.crt as certificate
+			Expect(string(secrets[clusterName+"-transport-cert"].Data[CaCertKey])).To(Equal("tls.crttls.crt"))
+			Expect(string(secrets[clusterName+"-http-cert"].Data[CaCertKey])).To(Equal("tls.crttls.crt"))
+		})
+
+		It("should wait until all nodes were restarted before activating the new CA", func() {
+			clusterName := "ca-rotation-wait"
+			spec := newSpec(clusterName, &opensearchv1.CaRotationStatus{
+				Phase:       opensearchv1.CaRotationPhaseDistributingTrust,
+				Revision:    1,
+				LastRequest: "2024-01",
+			})
+
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().GetSecret(clusterName+"-ca-next", clusterName).Return(corev1.Secret{Data: map[string][]byte{CaCertKey: []byte("new-ca")}}, nil)
+			mockClient.EXPECT().GetStatefulSet(clusterName+"-masters", clusterName).Return(appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To(int32(3)),
+					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{builders.CaRevisionAnnotation: "1"}}},
+				},
+				Status: appsv1.StatefulSetStatus{UpdatedReplicas: 2, ReadyReplicas: 3},
+			}, nil)
+			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			mockClient.EXPECT().GetSecret(clusterName+"-ca", clusterName).Return(corev1.Secret{Data: map[string][]byte{CaCertKey: []byte("old-ca")}}, nil)
+			secrets := expectCertificateSecrets(mockClient, clusterName)
+
+			_, underTest := newTLSReconciler(mockClient, &spec)
+			_, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(spec.Status.CaRotation.Phase).To(Equal(opensearchv1.CaRotationPhaseDistributingTrust))
+			Expect(string(secrets[clusterName+"-http-cert"].Data[CaCertKey])).To(HaveSuffix("new-ca"))
+		})
+
+		It("should activate the new CA and keep trusting the old one once all nodes were restarted", func() {
+			clusterName := "ca-rotation-activate"
+			spec := newSpec(clusterName, &opensearchv1.CaRotationStatus{
+				Phase:       opensearchv1.CaRotationPhaseDistributingTrust,
+				Revision:    1,
+				LastRequest: "2024-01",
+			})
+			newCa := map[string][]byte{CaCertKey: []byte("new-ca"), "ca.key": []byte("new-key")}
+			oldCa := map[string][]byte{CaCertKey: []byte("old-ca"), "ca.key": []byte("old-key")}
+
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			mockClient.EXPECT().GetSecret(clusterName+"-ca-next", clusterName).Return(corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-ca-next"}, Data: newCa}, nil)
+			mockClient.EXPECT().GetStatefulSet(clusterName+"-masters", clusterName).Return(appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To(int32(3)),
+					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{builders.CaRevisionAnnotation: "1"}}},
+				},
+				Status: appsv1.StatefulSetStatus{UpdatedReplicas: 3, ReadyReplicas: 3},
+			}, nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-ca", clusterName).Return(corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-ca"}, Data: oldCa}, nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-ca-previous", clusterName).Return(corev1.Secret{}, NotFoundError()).Once()
+			mockClient.EXPECT().GetSecret(clusterName+"-ca-previous", clusterName).Return(corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-ca-previous"}, Data: oldCa}, nil)
+			var previous, activated *corev1.Secret
+			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool { return secret.Name == clusterName+"-ca-previous" })).
+				Run(func(args mock.Arguments) { previous = args.Get(0).(*corev1.Secret) }).Return(&ctrl.Result{}, nil)
+			mockClient.EXPECT().UpdateSecret(mock.Anything).Run(func(secret *corev1.Secret) { activated = secret }).Return(nil)
+			mockClient.On("ReconcileResource", mock.MatchedBy(func(secret *corev1.Secret) bool { return secret.Name == clusterName+"-ca-next" }), reconciler.StateAbsent).Return(&ctrl.Result{}, nil)
+			updated := spec.DeepCopy()
+			expectStatusUpdate(mockClient, &spec, updated)
+			secrets := expectCertificateSecrets(mockClient, clusterName)
+
+			_, underTest := newTLSReconciler(mockClient, &spec)
+			_, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(previous.Data).To(Equal(oldCa))
+			Expect(activated.Name).To(Equal(clusterName + "-ca"))
+			Expect(activated.Data).To(Equal(newCa))
+			Expect(updated.Status.CaRotation.Phase).To(Equal(opensearchv1.CaRotationPhaseReissuing))
+			Expect(updated.Status.CaRotation.Revision).To(BeEquivalentTo(2))
+			Expect(underTest.reissueCertificates).To(BeTrue())
+			Expect(string(secrets[clusterName+"-http-cert"].Data[CaCertKey])).To(HaveSuffix("old-ca"))
+			Expect(string(secrets[clusterName+"-admin-cert"].Data[CaCertKey])).To(HaveSuffix("old-ca"))
+		})
+
+		It("should detect certificates signed by the replaced CA", func() {
+			pki := tls.NewPKI()
+			oldCa, err := pki.GenerateCA("ca-rotation")
+			Expect(err).ToNot(HaveOccurred())
+			newCa, err := pki.GenerateCA("ca-rotation")
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := oldCa.CreateAndSignCertificate("node", "ca-rotation", nil, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(certSignedByOtherCa(cert.CertData(), oldCa)).To(BeFalse())
+			Expect(certSignedByOtherCa(cert.CertData(), newCa)).To(BeTrue())
+		})
+
+		It("should only rely on hot reloading when it is enabled for all TLS interfaces", func() {
+			clusterName := "ca-rotation-hotreload"
+			spec := newSpec(clusterName, nil)
+			spec.Spec.General.Version = "2.19.1"
+			spec.Spec.Security.Tls.Http.EnableHotReload = true
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			_, underTest := newTLSReconciler(mockClient, &spec)
+			Expect(underTest.certificatesHotReloaded()).To(BeFalse())
+
+			spec.Spec.Security.Tls.Transport.EnableHotReload = true
+			Expect(underTest.certificatesHotReloaded()).To(BeTrue())
+		})
+	})
+})
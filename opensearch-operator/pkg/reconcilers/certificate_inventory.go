@@ -0,0 +1,246 @@
+package reconcilers
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	certificatesComponent        = "Certificates"
+	certificatesRotationDisabled = "RotationDisabled"
+	// Generated certificates expiring within this window while their rotation is disabled are reported
+	certificateRotationWindow = 30 * 24 * time.Hour
+	// The admin certificate is renewed by the operator this long before it expires
+	adminCertRenewBefore = 5 * 24 * time.Hour
+)
+
+// certificateSource is a secret holding the certificates of one interface
+type certificateSource struct {
+	iface      string
+	secretName string
+	perNode    bool
+	// nextRotation returns when the certificate is renewed, nil if it is not renewed automatically
+	nextRotation func(cert *x509.Certificate) *metav1.Time
+	// rotationDisabled is set for generated certificates that are not renewed because RotateDaysBeforeExpiry is disabled
+	rotationDisabled bool
+}
+
+// reconcileCertificateInventory records all certificates used by the cluster in the status and warns about
+// generated certificates that are about to expire while their rotation is disabled
+func (r *TLSReconciler) reconcileCertificateInventory() error {
+	var certificates []opensearchv1.CertificateStatus
+	var expiring []string
+	for _, source := range r.certificateSources() {
+		if source.secretName == "" {
+			continue
+		}
+		secret, err := r.client.GetSecret(source.secretName, r.instance.Namespace)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		for _, certificate := range certificateStatuses(source, secret) {
+			certificates = append(certificates, certificate)
+			if source.rotationDisabled && time.Until(certificate.NotAfter.Time) < certificateRotationWindow {
+				expiring = append(expiring, fmt.Sprintf("%s expires at %s", certificateName(certificate), certificate.NotAfter.UTC().Format(time.RFC3339)))
+			}
+		}
+	}
+
+	var warning *opensearchv1.ComponentStatus
+	if len(expiring) > 0 {
+		warning = &opensearchv1.ComponentStatus{
+			Component:   certificatesComponent,
+			Status:      certificatesRotationDisabled,
+			Description: fmt.Sprintf("%d certificates expire within %d days but rotateDaysBeforeExpiry is disabled", len(expiring), int(certificateRotationWindow.Hours()/24)),
+			Conditions:  expiring,
+		}
+	}
+	current, hasWarning := helpers.FindFirstPartial(r.instance.Status.ComponentsStatus, opensearchv1.ComponentStatus{Component: certificatesComponent}, helpers.GetByComponent)
+
+	if equality.Semantic.DeepEqual(certificates, r.instance.Status.Certificates) &&
+		((warning == nil && !hasWarning) || (warning != nil && hasWarning && equality.Semantic.DeepEqual(*warning, current))) {
+		return nil
+	}
+
+	if warning != nil && !hasWarning {
+		r.recorder.AnnotatedEventf(r.instance, map[string]string{"cluster-name": r.instance.GetName()}, "Warning", certificatesRotationDisabled, "%s", warning.Description)
+	}
+
+	r.instance.Status.Certificates = certificates
+	return r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.Certificates = certificates
+		existing, found := helpers.FindFirstPartial(instance.Status.ComponentsStatus, opensearchv1.ComponentStatus{Component: certificatesComponent}, helpers.GetByComponent)
+		if found {
+			instance.Status.ComponentsStatus = helpers.RemoveIt(existing, instance.Status.ComponentsStatus)
+		}
+		if warning != nil {
+			instance.Status.ComponentsStatus = append(instance.Status.ComponentsStatus, *warning)
+		}
+	})
+}
+
+// certificateSources returns the secrets of all TLS interfaces that are enabled
+func (r *TLSReconciler) certificateSources() []certificateSource {
+	var sources []certificateSource
+	tlsConfig := r.instance.Spec.Security.Tls
+	if r.isTransportTlsEnabled(tlsConfig) {
+		sources = append(sources, interfaceCertificateSource(string(CertContextTransport), r.instance.Name+"-transport-cert", tlsConfig.Transport.PerNode, tlsConfig.Transport.Generate, tlsConfig.Transport.TlsCertificateConfig, tlsConfig.Transport.RotateDaysBeforeExpiry))
+	}
+	if r.isHttpTlsEnabled(tlsConfig) {
+		sources = append(sources, interfaceCertificateSource(string(CertContextHttp), r.instance.Name+"-http-cert", false, tlsConfig.Http.Generate, tlsConfig.Http.TlsCertificateConfig, tlsConfig.Http.RotateDaysBeforeExpiry))
+	}
+
+	if helpers.IsSecurityPluginEnabled(r.instance) {
+		if r.instance.Spec.Security.Config != nil && r.instance.Spec.Security.Config.AdminSecret.Name != "" {
+			sources = append(sources, certificateSource{iface: "admin", secretName: r.instance.Spec.Security.Config.AdminSecret.Name})
+		} else {
+			source := certificateSource{iface: "admin", secretName: r.adminSecretName(), nextRotation: renewBefore(adminCertRenewBefore)}
+			var config *opensearchv1.TlsCertificateConfig
+			if helpers.SecurityChangeVersion(r.instance) && tlsConfig.Http != nil {
+				config = &tlsConfig.Http.TlsCertificateConfig
+			} else if !helpers.SecurityChangeVersion(r.instance) && tlsConfig.Transport != nil {
+				config = &tlsConfig.Transport.TlsCertificateConfig
+			}
+			if helpers.CertManagerEnabled(config) {
+				source.nextRotation = certManagerRenewal(config.CertManager)
+			}
+			sources = append(sources, source)
+		}
+	}
+
+	dashboards := r.instance.Spec.Dashboards
+	if dashboards.Enable && dashboards.Tls != nil && dashboards.Tls.Enable {
+		source := certificateSource{iface: "dashboards", secretName: r.instance.Name + "-dashboards-cert"}
+		if helpers.CertManagerEnabled(&dashboards.Tls.TlsCertificateConfig) {
+			source.nextRotation = certManagerRenewal(dashboards.Tls.CertManager)
+		} else if !dashboards.Tls.Generate {
+			source.secretName = dashboards.Tls.Secret.Name
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+func interfaceCertificateSource(iface string, generatedSecretName string, perNode bool, generate bool, config opensearchv1.TlsCertificateConfig, rotateDaysBeforeExpiry int) certificateSource {
+	source := certificateSource{iface: iface, secretName: generatedSecretName, perNode: perNode}
+	switch {
+	case helpers.CertManagerEnabled(&config):
+		source.nextRotation = certManagerRenewal(config.CertManager)
+	case generate && rotateDaysBeforeExpiry > 0:
+		source.nextRotation = renewBefore(time.Duration(rotateDaysBeforeExpiry) * 24 * time.Hour)
+	case generate:
+		source.rotationDisabled = true
+	default:
+		source.secretName = config.Secret.Name
+	}
+	return source
+}
+
+func renewBefore(before time.Duration) func(cert *x509.Certificate) *metav1.Time {
+	return func(cert *x509.Certificate) *metav1.Time {
+		next := metav1.NewTime(cert.NotAfter.Add(-before))
+		return &next
+	}
+}
+
+// certManagerRenewal follows cert-manager, which renews certificates a third of their duration before they expire
+// unless renewBefore is set
+func certManagerRenewal(config *opensearchv1.CertManagerConfig) func(cert *x509.Certificate) *metav1.Time {
+	return func(cert *x509.Certificate) *metav1.Time {
+		before := cert.NotAfter.Sub(cert.NotBefore) / 3
+		if config.RenewBefore != nil {
+			before = config.RenewBefore.Duration
+		}
+		next := metav1.NewTime(cert.NotAfter.Add(-before).Truncate(time.Second))
+		return &next
+	}
+}
+
+// certificateStatuses parses the certificates of a secret, which are stored as <node>.crt for per node certificates
+func certificateStatuses(source certificateSource, secret corev1.Secret) []opensearchv1.CertificateStatus {
+	keys := []string{corev1.TLSCertKey}
+	if source.perNode {
+		keys = nil
+		for key := range secret.Data {
+			if strings.HasSuffix(key, ".crt") && key != CaCertKey && key != corev1.TLSCertKey {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+	}
+
+	var statuses []opensearchv1.CertificateStatus
+	for _, key := range keys {
+		cert := parseCertificate(secret.Data[key])
+		if cert == nil {
+			continue
+		}
+		status := opensearchv1.CertificateStatus{
+			Interface:         source.iface,
+			Subject:           cert.Subject.String(),
+			DnsNames:          cert.DNSNames,
+			IssuerFingerprint: issuerFingerprint(cert, secret.Data[CaCertKey]),
+			NotAfter:          metav1.NewTime(cert.NotAfter),
+		}
+		if source.perNode {
+			status.Node = strings.TrimSuffix(key, ".crt")
+		}
+		if source.nextRotation != nil {
+			status.NextRotation = source.nextRotation(cert)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func parseCertificate(data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return cert
+}
+
+// issuerFingerprint returns the SHA-256 fingerprint of the CA in the bundle that signed the certificate
+func issuerFingerprint(cert *x509.Certificate, caBundle []byte) string {
+	for block, rest := pem.Decode(caBundle); block != nil; block, rest = pem.Decode(rest) {
+		ca, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || cert.CheckSignatureFrom(ca) != nil {
+			continue
+		}
+		sum := sha256.Sum256(ca.Raw)
+		hex := make([]string, len(sum))
+		for i, b := range sum {
+			hex[i] = fmt.Sprintf("%02X", b)
+		}
+		return strings.Join(hex, ":")
+	}
+	return ""
+}
+
+func certificateName(certificate opensearchv1.CertificateStatus) string {
+	if certificate.Node == "" {
+		return certificate.Interface
+	}
+	return certificate.Interface + "/" + certificate.Node
+}
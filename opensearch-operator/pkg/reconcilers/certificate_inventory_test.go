@@ -0,0 +1,117 @@
+package reconcilers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("TLS Controller", func() {
+	Context("When collecting the certificate inventory", func() {
+		ca, err := tls.NewPKI().GenerateCA("inventory", tls.WithKey(tls.KeyAlgorithmECDSA, 256))
+		if err != nil {
+			panic(err)
+		}
+		certSecret := func(validity time.Duration, keys ...string) corev1.Secret {
+			data := map[string][]byte{CaCertKey: ca.CertData()}
+			for _, key := range keys {
+				cert, err := ca.CreateAndSignCertificate(key, "inventory", []string{key + ".inventory.svc"}, validity, tls.WithKey(tls.KeyAlgorithmECDSA, 256))
+				Expect(err).ToNot(HaveOccurred())
+				data[key+".crt"] = cert.CertData()
+			}
+			return corev1.Secret{Data: data}
+		}
+		newSpec := func(clusterName string, rotateDaysBeforeExpiry int) opensearchv1.OpenSearchCluster {
+			return opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{Version: "2.8.0"},
+					Security: &opensearchv1.Security{Tls: &opensearchv1.TlsConfig{
+						Transport: &opensearchv1.TlsConfigTransport{Generate: true, PerNode: true, RotateDaysBeforeExpiry: rotateDaysBeforeExpiry},
+						Http:      &opensearchv1.TlsConfigHttp{Generate: true, RotateDaysBeforeExpiry: 7},
+					}},
+				},
+			}
+		}
+		It("should record all certificates and warn about expiring certificates that are not rotated", func() {
+			clusterName := "inventory"
+			spec := newSpec(clusterName, -1)
+
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().GetSecret(clusterName+"-transport-cert", clusterName).Return(certSecret(10*24*time.Hour, "inventory-masters-0", "inventory-masters-1"), nil)
+			httpSecret := certSecret(90*24*time.Hour, "tls")
+			mockClient.EXPECT().GetSecret(clusterName+"-http-cert", clusterName).Return(httpSecret, nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			updated := spec.DeepCopy()
+			expectStatusUpdate(mockClient, &spec, updated)
+
+			_, underTest := newTLSReconciler(mockClient, &spec)
+			Expect(underTest.reconcileCertificateInventory()).To(Succeed())
+
+			certificates := updated.Status.Certificates
+			Expect(certificates).To(HaveLen(3))
+			Expect(certificates[0].Interface).To(Equal("transport"))
+			Expect(certificates[0].Node).To(Equal("inventory-masters-0"))
+			Expect(certificates[0].DnsNames).To(Equal([]string{"inventory-masters-0.inventory.svc"}))
+			Expect(certificates[0].NextRotation).To(BeNil())
+			Expect(certificates[0].IssuerFingerprint).To(MatchRegexp("^([0-9A-F]{2}:){31}[0-9A-F]{2}$"))
+			Expect(certificates[1].Node).To(Equal("inventory-masters-1"))
+
+			Expect(certificates[2].Interface).To(Equal("http"))
+			Expect(certificates[2].Node).To(BeEmpty())
+			Expect(certificates[2].Subject).To(ContainSubstring("CN=tls"))
+			Expect(certificates[2].IssuerFingerprint).To(Equal(certificates[0].IssuerFingerprint))
+			Expect(certificates[2].NextRotation.Time).To(Equal(certificates[2].NotAfter.Add(-7 * 24 * time.Hour)))
+
+			Expect(updated.Status.ComponentsStatus).To(HaveLen(1))
+			Expect(updated.Status.ComponentsStatus[0].Component).To(Equal(certificatesComponent))
+			Expect(updated.Status.ComponentsStatus[0].Status).To(Equal(certificatesRotationDisabled))
+			Expect(updated.Status.ComponentsStatus[0].Conditions).To(HaveLen(2))
+			Expect(updated.Status.ComponentsStatus[0].Conditions[0]).To(HavePrefix("transport/inventory-masters-0 expires at"))
+		})
+
+		It("should clear the warning once the certificates are rotated", func() {
+			clusterName := "inventory-rotated"
+			spec := newSpec(clusterName, 30)
+			spec.Status.ComponentsStatus = []opensearchv1.ComponentStatus{
+				{Component: "Restarter", Status: "InProgress"},
+				{Component: certificatesComponent, Status: certificatesRotationDisabled},
+			}
+
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().GetSecret(clusterName+"-transport-cert", clusterName).Return(certSecret(10*24*time.Hour, "inventory-masters-0"), nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-http-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			updated := spec.DeepCopy()
+			expectStatusUpdate(mockClient, &spec, updated)
+
+			_, underTest := newTLSReconciler(mockClient, &spec)
+			Expect(underTest.reconcileCertificateInventory()).To(Succeed())
+
+			Expect(updated.Status.Certificates).To(HaveLen(1))
+			Expect(updated.Status.Certificates[0].NextRotation.Time).To(Equal(updated.Status.Certificates[0].NotAfter.Add(-30 * 24 * time.Hour)))
+			Expect(updated.Status.ComponentsStatus).To(Equal([]opensearchv1.ComponentStatus{{Component: "Restarter", Status: "InProgress"}}))
+		})
+
+		It("should not update the status if the inventory did not change", func() {
+			clusterName := "inventory-unchanged"
+			spec := newSpec(clusterName, 30)
+			secret := certSecret(90*24*time.Hour, "inventory-masters-0")
+			spec.Status.Certificates = certificateStatuses(certificateSource{iface: "transport", perNode: true, nextRotation: renewBefore(30 * 24 * time.Hour)}, secret)
+
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().GetSecret(clusterName+"-transport-cert", clusterName).Return(secret, nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-http-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+
+			_, underTest := newTLSReconciler(mockClient, &spec)
+			Expect(underTest.reconcileCertificateInventory()).To(Succeed())
+		})
+	})
+})
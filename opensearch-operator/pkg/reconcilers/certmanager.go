@@ -0,0 +1,52 @@
+package reconcilers
+
+import (
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var (
+	certUsagesServer = []string{"digital signature", "key encipherment", "server auth", "client auth"}
+	certUsagesClient = []string{"digital signature", "key encipherment", "client auth"}
+)
+
+// reconcileCertManagerCertificate creates or updates the cert-manager Certificate and reports whether a certificate
+// is available in its secret, either because cert-manager reports it as ready or because the secret still holds a
+// previously issued certificate while a renewal is in progress
+func reconcileCertManagerCertificate(
+	k8sClient k8s.K8sClient,
+	instance *opensearchv1.OpenSearchCluster,
+	config *opensearchv1.TlsCertificateConfig,
+	cert builders.CertManagerCertificate,
+) (bool, error) {
+	certificate := builders.NewCertManagerCertificate(instance, config, cert)
+	if err := ctrl.SetControllerReference(instance, certificate, k8sClient.Scheme()); err != nil {
+		return false, err
+	}
+	if _, err := k8sClient.ReconcileResource(certificate, reconciler.StatePresent); err != nil {
+		return false, err
+	}
+
+	existing, err := k8sClient.GetUnstructured(builders.CertificateGVK, cert.Name, instance.Namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+	if err == nil && builders.CertManagerCertificateReady(existing) {
+		return true, nil
+	}
+
+	secret, err := k8sClient.GetSecret(cert.SecretName, instance.Namespace)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	_, ok := secret.Data[corev1.TLSCertKey]
+	return ok, nil
+}
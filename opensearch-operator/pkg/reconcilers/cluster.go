@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -67,6 +68,15 @@ func (r *ClusterReconciler) Name() string { return clusterReconcilerName }
 func (r *ClusterReconciler) Reconcile() (ctrl.Result, error) {
 	// lg := log.FromContext(r.ctx)
 	result := reconciler.CombinedResult{}
+	// Clusters created before the webhook was enabled may still reference an unknown vendor
+	if _, err := helpers.LookupVendor(r.instance.Spec.General.Vendor); err != nil {
+		r.recorder.AnnotatedEventf(r.instance, map[string]string{"cluster-name": r.instance.GetName()}, "Warning", "Vendor", "Invalid vendor: %s", err)
+		return ctrl.Result{}, err
+	}
+	// Report the current maintenance window, also while no operation is waiting for it
+	if _, err := maintenanceWindowOpen(r.client, r.instance); err != nil {
+		return ctrl.Result{}, err
+	}
 	username, password, err := helpers.UsernameAndPassword(r.client, r.instance)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -91,14 +101,24 @@ func (r *ClusterReconciler) Reconcile() (ctrl.Result, error) {
 		}
 
 	}
-	clusterService := builders.NewServiceForCR(r.instance)
-	result.CombineErr(ctrl.SetControllerReference(r.instance, clusterService, r.client.Scheme()))
-	result.Combine(r.client.ReconcileResource(clusterService, reconciler.StatePresent))
+	// The services select their pods by labels that only the operator sets on the running pods, so they are labelled
+	// first. Otherwise a changed selector would leave the services without endpoints.
+	if err := r.labelPodsForServices(); err != nil {
+		r.logger.Error(err, "Failed to label the pods for the client services")
+		result.CombineErr(err)
+	} else {
+		clusterService := builders.NewServiceForCR(r.instance)
+		result.CombineErr(ctrl.SetControllerReference(r.instance, clusterService, r.client.Scheme()))
+		result.Combine(r.client.ReconcileResource(clusterService, reconciler.StatePresent))
+		result.Combine(r.reconcileAdditionalServices())
+	}
 
 	discoveryService := builders.NewDiscoveryServiceForCR(r.instance)
 	result.CombineErr(ctrl.SetControllerReference(r.instance, discoveryService, r.client.Scheme()))
 	result.Combine(r.client.ReconcileResource(discoveryService, reconciler.StatePresent))
 
+	result.Combine(reconcileIngress(r.client, r.instance, r.instance.Spec.General.Ingress, builders.OpenSearchIngressBackend(r.instance)))
+
 	discoverRandomAdminSecret, err := helpers.DiscoverRandomAdminSecret(r.client, r.instance)
 	if err == nil {
 		result.CombineErr(ctrl.SetControllerReference(r.instance, discoverRandomAdminSecret, r.client.Scheme()))
@@ -159,6 +179,72 @@ func (r *ClusterReconciler) Reconcile() (ctrl.Result, error) {
 	return result.Result, result.Err
 }
 
+// labelPodsForServices sets the service selector labels of their nodepool on the running pods and removes the ones of
+// services that no longer select the nodepool. The labels are not part of the pod template, so changing the services
+// does not restart the pods.
+func (r *ClusterReconciler) labelPodsForServices() error {
+	for i := range r.instance.Spec.NodePools {
+		nodePool := &r.instance.Spec.NodePools[i]
+		serviceLabels := helpers.ServiceLabelsForNodePool(r.instance, nodePool)
+		pods, err := helpers.PodsForNodePool(r.client, r.instance, nodePool)
+		if err != nil {
+			return err
+		}
+		for j := range pods {
+			pod := &pods[j]
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			var staleLabels []string
+			for k := range pod.Labels {
+				if _, ok := serviceLabels[k]; !ok && strings.HasPrefix(k, helpers.ServiceLabelPrefix) {
+					staleLabels = append(staleLabels, k)
+				}
+			}
+			if len(staleLabels) > 0 {
+				sort.Strings(staleLabels)
+				r.logger.Info(fmt.Sprintf("Removing service labels from pod %s", pod.Name))
+				if err := r.client.RemovePodLabels(pod, staleLabels); err != nil {
+					return err
+				}
+			}
+			if len(serviceLabels) == 0 || labels.SelectorFromSet(serviceLabels).Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			r.logger.Info(fmt.Sprintf("Adding service labels to pod %s", pod.Name))
+			if err := r.client.UpdatePodLabels(pod, serviceLabels); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileAdditionalServices creates the services of general.additionalServices and deletes the ones removed from the spec
+func (r *ClusterReconciler) reconcileAdditionalServices() (*ctrl.Result, error) {
+	result := reconciler.CombinedResult{}
+	desired := make(map[string]bool, len(r.instance.Spec.General.AdditionalServices))
+	for i := range r.instance.Spec.General.AdditionalServices {
+		service := builders.NewAdditionalServiceForCR(r.instance, &r.instance.Spec.General.AdditionalServices[i])
+		desired[service.Name] = true
+		result.CombineErr(ctrl.SetControllerReference(r.instance, service, r.client.Scheme()))
+		result.Combine(r.client.ReconcileResource(service, reconciler.StatePresent))
+	}
+
+	existing, err := r.client.ListServices(client.InNamespace(r.instance.Namespace), client.MatchingLabels{helpers.ClusterLabel: r.instance.Name}, client.HasLabels{helpers.AdditionalServiceLabel})
+	if err != nil {
+		result.CombineErr(err)
+		return &result.Result, result.Err
+	}
+	for i := range existing.Items {
+		if !desired[existing.Items[i].Name] {
+			r.logger.Info(fmt.Sprintf("Deleting additional service %s", existing.Items[i].Name))
+			result.Combine(r.client.ReconcileResource(&existing.Items[i], reconciler.StateAbsent))
+		}
+	}
+	return &result.Result, result.Err
+}
+
 func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodePool, username string) (*ctrl.Result, error) {
 	found, nodePoolConfig := r.reconcilerContext.fetchNodePoolHash(nodePool.Component)
 
@@ -203,7 +289,7 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodeP
 		})
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      "config",
-			MountPath: r.instance.Spec.General.GetOpenSearchHome() + "/config/opensearch.yml",
+			MountPath: helpers.OpenSearchHome(r.instance) + "/config/opensearch.yml",
 			SubPath:   "opensearch.yml",
 		})
 	}
@@ -220,6 +306,24 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodeP
 		return &ctrl.Result{}, err
 	}
 
+	// While the node pool is being migrated only the shadow statefulset is updated,
+	// the old one is drained and removed by the scaler
+	if migration, found := helpers.FindNodePoolMigration(r.instance.Status, nodePool.Component); found && migration.Status != helpers.NodePoolMigrationCompleted {
+		return r.reconcileNodePoolMigrationTarget(sts, helpers.NodePoolMigrationTarget(migration))
+	}
+	if result, started, err := r.startNodePoolRename(sts, nodePool); started || err != nil {
+		return result, err
+	}
+
+	// The statefulsets are deleted to stop all nodes for a full restart, and are created again once the nodes are stopped
+	if helpers.FullRestartStoppingNodes(r.instance.Status) {
+		return nil, nil
+	}
+	// The nodes of the full restart are started at once, as they can only elect a cluster manager together
+	if helpers.FullRestartStartingNodes(r.instance.Status) {
+		sts.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+	}
+
 	// First ensure that the statefulset exists
 	result, err := r.client.ReconcileResource(sts, reconciler.StateCreated)
 	if err != nil || result != nil {
@@ -246,8 +350,22 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodeP
 		}
 	}
 
-	// Detect cluster failure and initiate parallel recovery
-	if helpers.ParallelRecoveryMode() &&
+	// Storage type, storage class and access modes of the volume claim template cannot be changed in place,
+	// so the node pool is replaced by a new statefulset instead
+	if r.storageSettingsChanged(&existing, sts) {
+		open, err := maintenanceWindowOpen(r.client, r.instance)
+		if err != nil {
+			return result, err
+		}
+		if !open {
+			r.logger.Info(fmt.Sprintf("Waiting for the next maintenance window to migrate nodePool %s", nodePool.Component))
+			return result, nil
+		}
+		return r.startNodePoolMigration(&existing, sts, nodePool)
+	}
+
+	// Detect cluster failure and initiate parallel recovery, unless the cluster is restarted on request
+	if helpers.ParallelRecoveryMode() && !helpers.FullRestartInProgress(r.instance.Status) &&
 		(nodePool.Persistence == nil || nodePool.Persistence.PVC != nil) {
 		// This logic only works if the STS uses PVCs
 		// First check if the STS already has a readable status (CurrentRevision == "" indicates the STS is newly created and the controller has not yet updated the status properly)
@@ -263,6 +381,7 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodeP
 			return result, err
 		}
 		existing.Status.ReadyReplicas = readyReplicas
+		replicas := helpers.NodePoolReplicas(r.instance, &nodePool)
 		// Check number of PVCs for nodepool
 		pvcCount, err := helpers.CountPVCsForNodePool(r.client, r.instance, &nodePool)
 		if err != nil {
@@ -271,7 +390,7 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodeP
 			// A failure is assumed if n PVCs exist but less than n-1 pods (one missing pod is allowed for rolling restart purposes)
 			// We can assume the cluster is in a failure state and cannot recover on its own
 			if !helpers.IsUpgradeInProgress(r.instance.Status) &&
-				pvcCount >= int(nodePool.Replicas) && existing.Status.ReadyReplicas < nodePool.Replicas-1 {
+				pvcCount >= int(replicas) && existing.Status.ReadyReplicas < replicas-1 {
 				r.logger.Info(fmt.Sprintf("Detected recovery situation for nodepool %s: PVC count: %d, replicas: %d. Recreating STS with parallel mode", nodePool.Component, pvcCount, existing.Status.Replicas))
 				if existing.Spec.PodManagementPolicy != appsv1.ParallelPodManagement {
 					// Switch to Parallel to jumpstart the cluster
@@ -290,7 +409,7 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodeP
 						return result, err
 					}
 					// Wait for pods to appear
-					err := helpers.WaitForSTSReplicas(r.ctx, r.client, &existing, nodePool.Replicas)
+					err := helpers.WaitForSTSReplicas(r.ctx, r.client, &existing, replicas)
 					// Abort normal logic and requeue
 					return &ctrl.Result{Requeue: true}, err
 				}
@@ -327,6 +446,27 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodeP
 	// This will allow the scaler reconciler to function correctly
 	sts.Spec.Replicas = existing.Spec.Replicas
 
+	// Keep the last restart request of nodepools that are not selected by the current one,
+	// removing or rescoping the annotation must not restart the nodes
+	request, requested := sts.Spec.Template.Annotations[helpers.RestartAtAnnotation]
+	previous, restarted := existing.Spec.Template.Annotations[helpers.RestartAtAnnotation]
+	if !requested && restarted {
+		sts.Spec.Template.Annotations[helpers.RestartAtAnnotation] = previous
+	} else if requested && request != previous {
+		annotations := map[string]string{"cluster-name": r.instance.GetName()}
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "RollingRestart", "Rolling restart of nodepool %s requested with annotation %s=%s", nodePool.Component, helpers.RestartAtAnnotation, request)
+	}
+
+	// Nodes losing the data or cluster manager role keep their roles until they are drained
+	// and excluded from the voting configuration
+	pending, err := r.roleTransitionPending(&existing, sts, nodePool)
+	if err != nil {
+		return result, err
+	}
+	if pending {
+		keepNodeRoles(&existing, sts)
+	}
+
 	// Don't update env vars on non data nodes while an upgrade is in progress
 	// as we don't want uncontrolled restarts while we're doing an upgrade
 	if r.instance.Status.Version != "" &&
@@ -335,6 +475,11 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opensearchv1.NodeP
 		sts.Spec.Template.Spec.Containers[0].Env = existing.Spec.Template.Spec.Containers[0].Env
 	}
 
+	// Keep the current image until the upgrade started, restarted pods must not pick up the new version before
+	if upgradePending(r.instance) {
+		sts.Spec.Template.Spec.Containers[0].Image = existing.Spec.Template.Spec.Containers[0].Image
+	}
+
 	// NOTE: This is needed for migration from opster.io/v1 to opensearch.org/v1. Update labels on orphaned pods to match the new StatefulSet's selector
 	// to ensure they can be adopted by the new StatefulSet and counted correctly
 	if err := r.updateOrphanedPodLabels(&existing, sts, &nodePool); err != nil {
@@ -476,7 +621,7 @@ func (r *ClusterReconciler) checkForEmptyDirRecovery() (*ctrl.Result, error) {
 	)
 
 	for _, nodePool := range r.instance.Spec.NodePools {
-		err := helpers.DeleteSTSForNodePool(r.ctx, r.client, nodePool, clusterName, clusterNamespace)
+		err := helpers.DeleteSTSForNodePool(r.ctx, r.client, nodePool, r.instance)
 		if err != nil {
 			lg.Error(err, fmt.Sprintf("Failed to delete sts for nodePool %s", nodePool.Component))
 			return &ctrl.Result{Requeue: true}, err
@@ -516,15 +661,13 @@ func (r *ClusterReconciler) checkForEmptyDirRecovery() (*ctrl.Result, error) {
 
 func (r *ClusterReconciler) collectEmptyDirPodStats() (emptyDirPodStats, error) {
 	var stats emptyDirPodStats
-	clusterName := r.instance.Name
-	clusterNamespace := r.instance.Namespace
 
 	for _, nodePool := range r.instance.Spec.NodePools {
 		if !helpers.HasDataRole(&nodePool) && !helpers.HasManagerRole(&nodePool) {
 			continue
 		}
 
-		sts, err := helpers.GetSTSForNodePool(r.client, nodePool, clusterName, clusterNamespace)
+		sts, err := helpers.GetSTSForNodePool(r.client, nodePool, r.instance)
 		if err != nil {
 			return emptyDirPodStats{}, err
 		}
@@ -621,8 +764,7 @@ func (r *ClusterReconciler) maybeUpdateVolumes(existing *appsv1.StatefulSet, nod
 
 	// Identify the PVC for each statefulset pod and patch with the new size
 	for i := 0; i < int(lo.FromPtrOr(existing.Spec.Replicas, 1)); i++ {
-		clusterName := r.instance.Name
-		claimName := fmt.Sprintf("data-%s-%s-%d", clusterName, nodePool.Component, i)
+		claimName := fmt.Sprintf("data-%s-%d", existing.Name, i)
 		pvc, err := r.client.GetPVC(claimName, existing.Namespace)
 		if err != nil {
 			r.logger.Info("Failed to get pvc" + pvc.Name)
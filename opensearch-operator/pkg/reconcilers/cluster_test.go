@@ -1,13 +1,18 @@
 package reconcilers
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -66,6 +71,84 @@ var _ = Describe("emptyDir recovery", func() {
 	})
 })
 
+var _ = Describe("Client service labels", func() {
+	It("should add the service labels to running pods before the selector changes", func() {
+		mockClient := k8s.NewMockK8sClient(GinkgoT())
+		cluster := &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "labels"},
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{ServiceName: "labels"},
+				NodePools: []opensearchv1.NodePool{
+					{Component: "masters", Roles: []string{"cluster_manager"}, ClientService: ptr.To(false)},
+					{Component: "data", Roles: []string{"data"}},
+				},
+			},
+		}
+		labelled := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "labels-data-0", Labels: map[string]string{helpers.ServiceSelectorLabel("labels"): "true"}}}
+		unlabelled := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "labels-data-1"}}
+		mockClient.EXPECT().ListPods(mock.Anything).Return(corev1.PodList{}, nil).Once()
+		mockClient.EXPECT().ListPods(mock.Anything).Return(corev1.PodList{Items: []corev1.Pod{labelled, unlabelled}}, nil).Once()
+		mockClient.EXPECT().UpdatePodLabels(&unlabelled, map[string]string{helpers.ServiceSelectorLabel("labels"): "true"}).Return(nil).Once()
+		underTest := &ClusterReconciler{client: mockClient, instance: cluster, logger: logr.Discard()}
+
+		Expect(underTest.labelPodsForServices()).To(Succeed())
+	})
+
+	It("should remove the labels of services that no longer select the pods", func() {
+		mockClient := k8s.NewMockK8sClient(GinkgoT())
+		cluster := &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "labels"},
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{ServiceName: "labels"},
+				NodePools: []opensearchv1.NodePool{
+					{Component: "data", Roles: []string{"data"}},
+				},
+			},
+		}
+		stale := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "labels-data-0", Labels: map[string]string{
+			"opster.io/opensearch-nodepool":         "data",
+			helpers.ServiceSelectorLabel("ingest"):  "true",
+			helpers.ServiceSelectorLabel("removed"): "true",
+		}}}
+		mockClient.EXPECT().ListPods(mock.Anything).Return(corev1.PodList{Items: []corev1.Pod{stale}}, nil).Once()
+		mockClient.EXPECT().RemovePodLabels(&stale, []string{helpers.ServiceSelectorLabel("ingest"), helpers.ServiceSelectorLabel("removed")}).Return(nil).Once()
+		underTest := &ClusterReconciler{client: mockClient, instance: cluster, logger: logr.Discard()}
+
+		Expect(underTest.labelPodsForServices()).To(Succeed())
+	})
+
+	It("should not change the pod template or the revision when a service is removed", func() {
+		cluster := &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "labels"},
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{
+					ServiceName:        "labels",
+					AdditionalServices: []opensearchv1.AdditionalServiceConfig{{Name: "ingest", Roles: []string{"data"}}},
+				},
+				NodePools: []opensearchv1.NodePool{
+					{Component: "data", Roles: []string{"data"}},
+				},
+			},
+		}
+		templateRevision := func() (corev1.PodTemplateSpec, string) {
+			sts := builders.NewSTSForNodePool("admin", cluster, cluster.Spec.NodePools[0], "checksum", nil, nil)
+			data, err := json.Marshal(sts.Spec.Template)
+			Expect(err).NotTo(HaveOccurred())
+			revision, err := util.GetSha1Sum(data)
+			Expect(err).NotTo(HaveOccurred())
+			return sts.Spec.Template, revision
+		}
+		template, revision := templateRevision()
+
+		cluster.Spec.General.AdditionalServices = nil
+		templateAfter, revisionAfter := templateRevision()
+
+		Expect(templateAfter).To(Equal(template))
+		Expect(revisionAfter).To(Equal(revision))
+		Expect(template.Labels).NotTo(HaveKey(helpers.ServiceSelectorLabel("ingest")))
+	})
+})
+
 var _ = Describe("Bootstrap Pod Reconciliation Fix", func() {
 	Context("Bootstrap Pod Recreation Approach", func() {
 		It("should detect when any bootstrap pod spec field has changed", func() {
@@ -74,7 +74,9 @@ func (r *ConfigurationReconciler) Reconcile() (ctrl.Result, error) {
 
 	if helpers.IsSecurityPluginEnabled(r.instance) {
 		// Add some default config for the security plugin
-		r.reconcilerContext.AddConfig("plugins.security.audit.type", "internal_opensearch")
+		for key, value := range helpers.AuditSettings(r.instance) {
+			r.reconcilerContext.AddConfig(key, value)
+		}
 		r.reconcilerContext.AddConfig("plugins.security.enable_snapshot_restore_privilege", "true")
 		r.reconcilerContext.AddConfig("plugins.security.check_snapshot_restore_write_privileges", "true")
 		r.reconcilerContext.AddConfig("plugins.security.restapi.roles_enabled", `["all_access", "security_rest_api_access"]`)
@@ -191,7 +193,7 @@ func (r *ConfigurationReconciler) Reconcile() (ctrl.Result, error) {
 
 		mount := corev1.VolumeMount{
 			Name:      "config",
-			MountPath: r.instance.Spec.General.GetOpenSearchHome() + "/config/opensearch.yml",
+			MountPath: helpers.OpenSearchHome(r.instance) + "/config/opensearch.yml",
 			SubPath:   "opensearch.yml",
 		}
 		r.reconcilerContext.VolumeMounts = append(r.reconcilerContext.VolumeMounts, mount)
@@ -30,6 +30,8 @@ type DashboardsReconciler struct {
 	instance          *opensearchv1.OpenSearchCluster
 	logger            logr.Logger
 	pki               tls.PKI
+	// certChecksum is the checksum of the generated certificate, it restarts the pods when the certificate changes
+	certChecksum string
 }
 
 func NewDashboardsReconciler(
@@ -63,6 +65,13 @@ func (r *DashboardsReconciler) Reconcile() (ctrl.Result, error) {
 		return ctrl.Result{}, err
 	}
 
+	// configure the login of the authentication backends, unless the settings are overridden with additionalConfig
+	for key, value := range helpers.DashboardsAuthenticationConfig(r.instance) {
+		if _, overridden := r.instance.Spec.Dashboards.AdditionalConfig[key]; !overridden {
+			r.reconcilerContext.AddDashboardsConfig(key, value)
+		}
+	}
+
 	// add any aditional dashboard config to the reconciler context
 	for key, value := range r.instance.Spec.Dashboards.AdditionalConfig {
 		r.reconcilerContext.AddDashboardsConfig(key, value)
@@ -109,6 +118,9 @@ func (r *DashboardsReconciler) Reconcile() (ctrl.Result, error) {
 
 		annotations[helpers.DashboardChecksumName] = sha1sum
 	}
+	if r.certChecksum != "" {
+		annotations[helpers.DashboardCertChecksumName] = r.certChecksum
+	}
 
 	deployment := builders.NewDashboardsDeploymentForCR(r.instance, volumes, volumeMounts, annotations)
 	result.CombineErr(ctrl.SetControllerReference(r.instance, deployment, r.client.Scheme()))
@@ -118,6 +130,8 @@ func (r *DashboardsReconciler) Reconcile() (ctrl.Result, error) {
 	result.CombineErr(ctrl.SetControllerReference(r.instance, svc, r.client.Scheme()))
 	result.Combine(r.client.CreateService(svc))
 
+	result.Combine(reconcileIngress(r.client, r.instance, r.instance.Spec.Dashboards.Ingress, builders.DashboardsIngressBackend(r.instance)))
+
 	return result.Result, result.Err
 }
 
@@ -133,7 +147,37 @@ func (r *DashboardsReconciler) handleTls() ([]corev1.Volume, []corev1.VolumeMoun
 	var volumes []corev1.Volume
 	var volumeMounts []corev1.VolumeMount
 
-	if tlsConfig.Generate {
+	dnsNames := []string{
+		fmt.Sprintf("%s-dashboards", clusterName),
+		fmt.Sprintf("%s-dashboards.%s", clusterName, namespace),
+		fmt.Sprintf("%s-dashboards.%s.svc", clusterName, namespace),
+		fmt.Sprintf("%s-dashboards.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
+	}
+	if ingress := r.instance.Spec.Dashboards.Ingress; ingress != nil && ingress.Enable {
+		dnsNames = append(append([]string{}, ingress.Hosts...), dnsNames...)
+	}
+
+	if helpers.CertManagerEnabled(&tlsConfig.TlsCertificateConfig) {
+		// The pods wait for the secret to be created by cert-manager, so there is no need to block here
+		available, err := reconcileCertManagerCertificate(r.client, r.instance, &tlsConfig.TlsCertificateConfig, builders.CertManagerCertificate{
+			Name:       tlsSecretName,
+			SecretName: tlsSecretName,
+			CommonName: clusterName + "-dashboards",
+			DnsNames:   dnsNames,
+			Usages:     certUsagesServer,
+		})
+		if err != nil {
+			return volumes, volumeMounts, err
+		}
+		if !available {
+			r.logger.Info("Waiting for cert-manager to issue the dashboards certificate", "certificate", tlsSecretName)
+		}
+		volume := corev1.Volume{Name: "tls-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: tlsSecretName}}}
+		volumes = append(volumes, volume)
+		dashboardsHome := r.instance.Spec.Dashboards.GetOpenSearchDashboardsHome()
+		mount := corev1.VolumeMount{Name: "tls-cert", MountPath: dashboardsHome + "/certs"}
+		volumeMounts = append(volumeMounts, mount)
+	} else if tlsConfig.Generate {
 		r.logger.Info("Generating certificates")
 		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "Security", "Starting to generating certificates for Dashboard Cluster")
 		// Take CA from TLS reconciler or generate new one
@@ -150,19 +194,15 @@ func (r *DashboardsReconciler) handleTls() ([]corev1.Volume, []corev1.VolumeMoun
 
 		// Generate cert and create secret
 		tlsSecret, err := r.client.GetSecret(tlsSecretName, namespace)
-		if err != nil {
+		// Regenerate the cert if it does not cover all hosts, e.g. after an ingress host was added,
+		// or if it was signed by another CA, e.g. during a rotation of the generated CA
+		if err != nil || certMissingDnsNames(tlsSecret.Data[corev1.TLSCertKey], dnsNames) || certSignedByOtherCa(tlsSecret.Data[corev1.TLSCertKey], ca) {
 			// Generate tls cert and put it into secret
-			dnsNames := []string{
-				fmt.Sprintf("%s-dashboards", clusterName),
-				fmt.Sprintf("%s-dashboards.%s", clusterName, namespace),
-				fmt.Sprintf("%s-dashboards.%s.svc", clusterName, namespace),
-				fmt.Sprintf("%s-dashboards.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
-			}
 			validity := 365 * 24 * time.Hour
 			if tlsConfig.Duration != nil {
 				validity = tlsConfig.Duration.Duration
 			}
-			nodeCert, err := ca.CreateAndSignCertificate(clusterName+"-dashboards", clusterName, dnsNames, validity)
+			nodeCert, err := ca.CreateAndSignCertificate(clusterName+"-dashboards", clusterName, dnsNames, validity, helpers.CertOptions(&tlsConfig.TlsCertificateConfig)...)
 			if err != nil {
 				r.logger.Error(err, "Failed to create tls certificate")
 				r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "Security", "Failed to store tls certificate for Dashboard Cluster")
@@ -179,6 +219,11 @@ func (r *DashboardsReconciler) handleTls() ([]corev1.Volume, []corev1.VolumeMoun
 				return volumes, volumeMounts, err
 			}
 		}
+		// Dashboards does not reload its certificate, so the pods are restarted when it changes
+		r.certChecksum, err = util.GetSha1Sum(tlsSecret.Data[corev1.TLSCertKey])
+		if err != nil {
+			return volumes, volumeMounts, err
+		}
 		// Mount secret
 		volume := corev1.Volume{Name: "tls-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: tlsSecretName}}}
 		volumes = append(volumes, volume)
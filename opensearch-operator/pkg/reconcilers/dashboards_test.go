@@ -3,11 +3,14 @@ package reconcilers
 import (
 	"context"
 	"strings"
+	"time"
 
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/tls"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -53,6 +56,12 @@ func setupDashboardsCredentialsSecretMocks(mockClient *k8s.MockK8sClient, cluste
 	mockClient.On("ReconcileResource", mock.AnythingOfType("*v1.Secret"), mock.Anything).Return(&ctrl.Result{}, nil)
 }
 
+// expectDashboardsIngressAbsent expects the removal of the ingress objects of the dashboards without an ingress config
+func expectDashboardsIngressAbsent(mockClient *k8s.MockK8sClient) {
+	mockClient.On("ReconcileResource", mock.AnythingOfType("*v1.Ingress"), reconciler.StateAbsent).Return(&ctrl.Result{}, nil)
+	mockClient.On("ReconcileResource", mock.AnythingOfType("*unstructured.Unstructured"), reconciler.StateAbsent).Return(&ctrl.Result{}, nil)
+}
+
 var _ = Describe("Dashboards Reconciler", func() {
 
 	When("running the dashboards reconciler with TLS enabled and an existing cert in a single secret", func() {
@@ -87,6 +96,7 @@ var _ = Describe("Dashboards Reconciler", func() {
 			mockClient.EXPECT().CreateConfigMap(mock.Anything).Return(&ctrl.Result{}, nil)
 			mockClient.EXPECT().Context().Return(context.Background())
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
 			setupDashboardsCredentialsSecretMocks(mockClient, clusterName)
 
 			_, underTest := newDashboardsReconciler(mockClient, &spec)
@@ -115,6 +125,7 @@ var _ = Describe("Dashboards Reconciler", func() {
 					},
 				}}
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
 			mockClient.EXPECT().Context().Return(context.Background())
 			mockClient.EXPECT().GetSecret(clusterName+"-ca", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.EXPECT().GetSecret(clusterName+"-dashboards-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
@@ -151,6 +162,73 @@ var _ = Describe("Dashboards Reconciler", func() {
 		})
 	})
 
+	When("running the dashboards reconciler with a generated cert signed by a replaced CA", func() {
+		It("should reissue the cert from the current CA and restart the pods", func() {
+			clusterName := "dashboards-test-rotated-ca"
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			spec := opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{ServiceName: clusterName},
+					Dashboards: opensearchv1.DashboardsConfig{
+						Enable: true,
+						Tls: &opensearchv1.DashboardsTlsConfig{
+							Enable:   true,
+							Generate: true,
+						},
+						Service: opensearchv1.DashboardsServiceSpec{Labels: map[string]string{}},
+					},
+				}}
+			pki := tls.NewPKI()
+			oldCa, err := pki.GenerateCA(clusterName)
+			Expect(err).ToNot(HaveOccurred())
+			newCa, err := pki.GenerateCA(clusterName)
+			Expect(err).ToNot(HaveOccurred())
+			dnsNames := []string{
+				clusterName + "-dashboards",
+				clusterName + "-dashboards." + clusterName,
+				clusterName + "-dashboards." + clusterName + ".svc",
+				clusterName + "-dashboards." + clusterName + ".svc." + helpers.ClusterDnsBase(),
+			}
+			oldCert, err := oldCa.CreateAndSignCertificate(clusterName+"-dashboards", clusterName, dnsNames, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
+			mockClient.EXPECT().Context().Return(context.Background())
+			mockClient.EXPECT().GetSecret(clusterName+"-ca", clusterName).Return(corev1.Secret{Data: newCa.SecretDataCA()}, nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-dashboards-cert", clusterName).Return(corev1.Secret{Data: oldCert.SecretData(oldCa)}, nil)
+			setupDashboardsCredentialsSecretMocks(mockClient, clusterName)
+			var createdDeployment *appsv1.Deployment
+			mockClient.On("CreateDeployment", mock.Anything).
+				Return(func(deployment *appsv1.Deployment) (*ctrl.Result, error) {
+					createdDeployment = deployment
+					return &ctrl.Result{}, nil
+				})
+			var createdSecret *corev1.Secret
+			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool {
+				return secret.Name == clusterName+"-dashboards-cert"
+			})).
+				Return(func(secret *corev1.Secret) (*ctrl.Result, error) {
+					createdSecret = secret
+					return &ctrl.Result{}, nil
+				})
+			mockClient.EXPECT().CreateService(mock.Anything).Return(&ctrl.Result{}, nil)
+			mockClient.EXPECT().CreateConfigMap(mock.Anything).Return(&ctrl.Result{}, nil)
+
+			_, underTest := newDashboardsReconciler(mockClient, &spec)
+			underTest.pki = pki
+			_, err = underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(createdSecret).ToNot(BeNil())
+			Expect(certSignedByOtherCa(createdSecret.Data[corev1.TLSCertKey], newCa)).To(BeFalse())
+			checksum, err := util.GetSha1Sum(createdSecret.Data[corev1.TLSCertKey])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(createdDeployment.Spec.Template.Annotations).To(HaveKeyWithValue(helpers.DashboardCertChecksumName, checksum))
+		})
+	})
+
 	When("running the dashboards reconciler with a credentials secret supplied", func() {
 		It("should provide these credentials as env vars", func() {
 			clusterName := "dashboards-creds"
@@ -167,6 +245,7 @@ var _ = Describe("Dashboards Reconciler", func() {
 					},
 				}}
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
 			mockClient.EXPECT().Context().Return(context.Background())
 			mockClient.EXPECT().CreateService(mock.Anything).Return(&ctrl.Result{}, nil)
 			mockClient.EXPECT().CreateConfigMap(mock.Anything).Return(&ctrl.Result{}, nil)
@@ -223,6 +302,7 @@ var _ = Describe("Dashboards Reconciler", func() {
 					},
 				}}
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
 			mockClient.EXPECT().Context().Return(context.Background())
 			mockClient.EXPECT().CreateService(mock.Anything).Return(&ctrl.Result{}, nil)
 			setupDashboardsCredentialsSecretMocks(mockClient, clusterName)
@@ -253,6 +333,56 @@ var _ = Describe("Dashboards Reconciler", func() {
 		})
 	})
 
+	When("running the dashboards reconciler with OpenID Connect authentication", func() {
+		It("should configure the login and pass the client secret as env var", func() {
+			clusterName := "dashboards-openid"
+			clientSecret := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oidc"}, Key: "secret"}
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			spec := opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{ServiceName: clusterName},
+					Security: &opensearchv1.Security{Authentication: &opensearchv1.AuthenticationConfig{
+						OpenID: &opensearchv1.OpenIDAuthConfig{ConnectURL: "https://idp/.well-known/openid-configuration", ClientID: "dashboards", ClientSecret: &clientSecret},
+					}},
+					Dashboards: opensearchv1.DashboardsConfig{
+						Enable: true,
+						AdditionalConfig: map[string]string{
+							"opensearch_security.openid.scope": "openid groups",
+						},
+						Service: opensearchv1.DashboardsServiceSpec{Labels: map[string]string{}},
+					},
+				}}
+			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
+			mockClient.EXPECT().Context().Return(context.Background())
+			mockClient.EXPECT().CreateService(mock.Anything).Return(&ctrl.Result{}, nil)
+			setupDashboardsCredentialsSecretMocks(mockClient, clusterName)
+			var createdDeployment *appsv1.Deployment
+			mockClient.On("CreateDeployment", mock.Anything).
+				Return(func(deployment *appsv1.Deployment) (*ctrl.Result, error) {
+					createdDeployment = deployment
+					return &ctrl.Result{}, nil
+				})
+			var createdCm *corev1.ConfigMap
+			mockClient.On("CreateConfigMap", mock.Anything).
+				Return(func(cm *corev1.ConfigMap) (*ctrl.Result, error) {
+					createdCm = cm
+					return &ctrl.Result{}, nil
+				})
+
+			_, underTest := newDashboardsReconciler(mockClient, &spec)
+			_, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			data := createdCm.Data[helpers.DashboardConfigName]
+			Expect(data).To(ContainSubstring("opensearch_security.auth.type: \"openid\"\n"))
+			Expect(data).To(ContainSubstring("opensearch_security.openid.client_secret: \"${OPENSEARCH_OPENID_CLIENT_SECRET}\"\n"))
+			Expect(data).To(ContainSubstring("opensearch_security.openid.scope: openid groups\n"))
+			Expect(createdDeployment).To(HaveMatchingContainer(HaveEnv(helpers.OpenIDClientSecretEnv, clientSecret)))
+		})
+	})
+
 	When("running the dashboards reconciler with envs supplied", func() {
 		It("should populate the dashboard env vars", func() {
 			clusterName := "dashboards-add-env"
@@ -274,6 +404,7 @@ var _ = Describe("Dashboards Reconciler", func() {
 				}}
 
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
 			mockClient.EXPECT().Context().Return(context.Background())
 			mockClient.EXPECT().CreateService(mock.Anything).Return(&ctrl.Result{}, nil)
 			mockClient.EXPECT().CreateConfigMap(mock.Anything).Return(&ctrl.Result{}, nil)
@@ -319,6 +450,7 @@ var _ = Describe("Dashboards Reconciler", func() {
 					},
 				}}
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
 			mockClient.EXPECT().Context().Return(context.Background())
 			mockClient.EXPECT().CreateService(mock.Anything).Return(&ctrl.Result{}, nil)
 			mockClient.EXPECT().CreateConfigMap(mock.Anything).Return(&ctrl.Result{}, nil)
@@ -373,6 +505,7 @@ var _ = Describe("Dashboards Reconciler", func() {
 				}}
 
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			expectDashboardsIngressAbsent(mockClient)
 			mockClient.EXPECT().Context().Return(context.Background())
 			mockClient.EXPECT().CreateService(mock.Anything).Return(&ctrl.Result{}, nil)
 			mockClient.EXPECT().CreateConfigMap(mock.Anything).Return(&ctrl.Result{}, nil)
@@ -0,0 +1,201 @@
+package reconcilers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// drainStatusUpdateInterval limits how often the status is updated while only the bytes remaining change
+const drainStatusUpdateInterval = 30 * time.Second
+
+// drainTracker reports the progress of the nodes being drained in the status of the cluster and as metrics, and
+// applies the drain timeout of the cluster
+type drainTracker struct {
+	client   k8s.K8sClient
+	osClient *services.OsClusterClient
+	instance *opensearchv1.OpenSearchCluster
+	recorder record.EventRecorder
+	logger   logr.Logger
+	now      func() time.Time
+}
+
+func newDrainTracker(
+	client k8s.K8sClient,
+	osClient *services.OsClusterClient,
+	instance *opensearchv1.OpenSearchCluster,
+	recorder record.EventRecorder,
+	logger logr.Logger,
+) *drainTracker {
+	return &drainTracker{
+		client:   client,
+		osClient: osClient,
+		instance: instance,
+		recorder: recorder,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// aborted returns true if the drain of the node was aborted after the timeout and must not be retried yet
+func (t *drainTracker) aborted(node string) bool {
+	status := t.status(node)
+	if status == nil || status.AbortedAt == nil {
+		return false
+	}
+	return t.now().Before(status.AbortedAt.Add(t.timeout()))
+}
+
+// track records the progress of the drain of the node, drained is the result of the checks of the caller.
+// It returns true if the node can be restarted or removed, either because it is drained or because the
+// timeout policy allows it.
+func (t *drainTracker) track(node string, drained bool) (bool, error) {
+	current := t.status(node)
+	if drained {
+		if current == nil {
+			return true, nil
+		}
+		helpers.DeleteDrainMetrics(t.instance, node)
+		return true, t.setStatus(node, nil)
+	}
+
+	now := t.now()
+	if current != nil && current.AbortedAt != nil {
+		if now.Before(current.AbortedAt.Add(t.timeout())) {
+			return false, nil
+		}
+		// The exclusion was removed when the drain was aborted
+		t.logger.Info(fmt.Sprintf("Retrying the drain of node %s", node))
+		if _, err := services.AppendExcludeNodeHost(t.osClient, t.logger, node); err != nil {
+			return false, err
+		}
+		current = nil
+	}
+
+	progress, err := services.GetDrainProgress(t.osClient, node)
+	if err != nil {
+		return false, err
+	}
+	status := opensearchv1.DrainStatus{
+		Node:           node,
+		StartedAt:      &metav1.Time{Time: now},
+		InitialBytes:   progress.Bytes,
+		LastUpdateTime: &metav1.Time{Time: now},
+	}
+	if current != nil {
+		status.StartedAt = current.StartedAt
+		status.InitialBytes = current.InitialBytes
+		status.LastUpdateTime = current.LastUpdateTime
+	}
+	status.ShardsRemaining = progress.Shards
+	status.RelocatingShards = progress.RelocatingShards
+	status.BytesRemaining = progress.Bytes
+	status.RelocatingBytes = progress.RelocatingBytes
+	status.EstimatedCompletionTime = estimateDrainCompletion(status, now)
+
+	if timeout := t.timeout(); timeout > 0 && now.Sub(status.StartedAt.Time) >= timeout {
+		policy := t.timeoutPolicy()
+		helpers.DrainTimeouts.With(prometheus.Labels{
+			"namespace":          t.instance.Namespace,
+			"opensearch_cluster": t.instance.Name,
+			"policy":             string(policy),
+		}).Inc()
+		if policy == opensearchv1.DrainTimeoutPolicyProceedIfReplicas && progress.ShardsWithoutCopy == 0 {
+			t.recorder.Eventf(t.instance, "Warning", "DrainTimeout",
+				"Node %s was not drained within %s, proceeding as the %d remaining shards have copies on other nodes", node, timeout, progress.Shards)
+			helpers.DeleteDrainMetrics(t.instance, node)
+			return true, t.setStatus(node, nil)
+		}
+		if _, err := services.RemoveExcludeNodeHost(t.osClient, t.logger, node); err != nil {
+			return false, err
+		}
+		status.AbortedAt = &metav1.Time{Time: now}
+		status.Message = fmt.Sprintf("Drain aborted after %s with %d shards remaining, %d of them without a copy on another node", timeout, progress.Shards, progress.ShardsWithoutCopy)
+		t.recorder.Eventf(t.instance, "Warning", "DrainTimeout", "Node %s was not drained within %s, aborting the drain and retrying in %s", node, timeout, timeout)
+	}
+
+	helpers.UpdateDrainMetrics(t.instance, status, now)
+	if !drainStatusChanged(current, status, now) {
+		return false, nil
+	}
+	status.LastUpdateTime = &metav1.Time{Time: now}
+	return false, t.setStatus(node, &status)
+}
+
+func (t *drainTracker) timeout() time.Duration {
+	if t.instance.Spec.General.Drain == nil || t.instance.Spec.General.Drain.Timeout == nil {
+		return 0
+	}
+	return t.instance.Spec.General.Drain.Timeout.Duration
+}
+
+func (t *drainTracker) timeoutPolicy() opensearchv1.DrainTimeoutPolicy {
+	if t.instance.Spec.General.Drain == nil || t.instance.Spec.General.Drain.TimeoutPolicy == "" {
+		return opensearchv1.DrainTimeoutPolicyAbort
+	}
+	return t.instance.Spec.General.Drain.TimeoutPolicy
+}
+
+func (t *drainTracker) status(node string) *opensearchv1.DrainStatus {
+	for i := range t.instance.Status.Drains {
+		if t.instance.Status.Drains[i].Node == node {
+			return t.instance.Status.Drains[i].DeepCopy()
+		}
+	}
+	return nil
+}
+
+// setStatus replaces the drain status of the node, nil removes it
+func (t *drainTracker) setStatus(node string, status *opensearchv1.DrainStatus) error {
+	t.instance.Status.Drains = replaceDrainStatus(t.instance.Status.Drains, node, status)
+	return t.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(t.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.Drains = replaceDrainStatus(instance.Status.Drains, node, status)
+	})
+}
+
+func replaceDrainStatus(drains []opensearchv1.DrainStatus, node string, status *opensearchv1.DrainStatus) []opensearchv1.DrainStatus {
+	var result []opensearchv1.DrainStatus
+	for _, drain := range drains {
+		if drain.Node != node {
+			result = append(result, drain)
+		}
+	}
+	if status != nil {
+		result = append(result, *status)
+	}
+	return result
+}
+
+// estimateDrainCompletion extrapolates the rate bytes were moved off the node since the drain was started
+func estimateDrainCompletion(status opensearchv1.DrainStatus, now time.Time) *metav1.Time {
+	moved := status.InitialBytes - status.BytesRemaining
+	elapsed := now.Sub(status.StartedAt.Time)
+	if moved <= 0 || elapsed <= 0 {
+		return nil
+	}
+	remaining := time.Duration(float64(elapsed) * float64(status.BytesRemaining) / float64(moved))
+	return &metav1.Time{Time: now.Add(remaining)}
+}
+
+// drainStatusChanged returns true if the status has to be updated. Changes of the bytes remaining are only
+// reported every drainStatusUpdateInterval as every status update triggers a reconcile.
+func drainStatusChanged(current *opensearchv1.DrainStatus, status opensearchv1.DrainStatus, now time.Time) bool {
+	if current == nil {
+		return true
+	}
+	if current.ShardsRemaining != status.ShardsRemaining || current.RelocatingShards != status.RelocatingShards ||
+		!current.AbortedAt.Equal(status.AbortedAt) {
+		return true
+	}
+	return current.BytesRemaining != status.BytesRemaining &&
+		(current.LastUpdateTime == nil || now.Sub(current.LastUpdateTime.Time) >= drainStatusUpdateInterval)
+}
@@ -0,0 +1,206 @@
+package reconcilers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jarcoal/httpmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Drain tracker", func() {
+	const node = "drain-data-1"
+	var (
+		transport  *httpmock.MockTransport
+		mockClient *k8s.MockK8sClient
+		recorder   *record.FakeRecorder
+		cluster    *opensearchv1.OpenSearchCluster
+		underTest  *drainTracker
+		now        time.Time
+	)
+
+	withShards := func(shards ...responses.CatShardsResponse) {
+		transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cat/shards`),
+			httpmock.NewJsonResponderOrPanic(200, shards))
+	}
+	BeforeEach(func() {
+		transport = httpmock.NewMockTransport()
+		transport.RegisterNoResponder(httpmock.NewNotFoundResponder(failMessage))
+		mockClient = k8s.NewMockK8sClient(GinkgoT())
+		recorder = record.NewFakeRecorder(10)
+		cluster = &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "drain", Namespace: "drain"},
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{ServiceName: "drain", HttpPort: 9200, DrainDataNodes: true},
+			},
+		}
+		clusterUrl := fmt.Sprintf("%s/", helpers.ClusterURL(cluster))
+		transport.RegisterResponder(http.MethodGet, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+		transport.RegisterResponder(http.MethodHead, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+		now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	JustBeforeEach(func() {
+		osClient, err := services.NewOsClusterClient(helpers.ClusterURL(cluster), "admin", "admin", services.WithTransport(transport))
+		Expect(err).ToNot(HaveOccurred())
+		underTest = newDrainTracker(mockClient, osClient, cluster, recorder, logr.Discard())
+		underTest.now = func() time.Time { return now }
+	})
+
+	It("should record the shards and bytes remaining on the node", func() {
+		withShards(
+			responses.CatShardsResponse{Index: "a", Shard: "0", PrimaryOrReplica: "p", State: "RELOCATING", Store: "2000", NodeName: node + " -> 10.0.0.1 xyz drain-data-0"},
+			responses.CatShardsResponse{Index: "b", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "1000", NodeName: node},
+		)
+		updated := cluster.DeepCopy()
+		expectStatusUpdate(mockClient, cluster, updated).Once()
+
+		drained, err := underTest.track(node, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drained).To(BeFalse())
+		Expect(updated.Status.Drains).To(HaveLen(1))
+		status := updated.Status.Drains[0]
+		Expect(status.Node).To(Equal(node))
+		Expect(status.StartedAt.Time).To(Equal(now))
+		Expect(status.ShardsRemaining).To(Equal(int32(2)))
+		Expect(status.RelocatingShards).To(Equal(int32(1)))
+		Expect(status.InitialBytes).To(Equal(int64(3000)))
+		Expect(status.BytesRemaining).To(Equal(int64(3000)))
+		Expect(status.RelocatingBytes).To(Equal(int64(2000)))
+		Expect(status.EstimatedCompletionTime).To(BeNil())
+		Expect(cluster.Status.Drains).To(Equal(updated.Status.Drains))
+	})
+
+	It("should estimate the completion time from the bytes moved so far", func() {
+		cluster.Status.Drains = []opensearchv1.DrainStatus{{
+			Node:            node,
+			StartedAt:       &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			LastUpdateTime:  &metav1.Time{Time: now.Add(-time.Minute)},
+			ShardsRemaining: 2,
+			InitialBytes:    3000,
+			BytesRemaining:  3000,
+		}}
+		withShards(responses.CatShardsResponse{Index: "b", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "1000", NodeName: node})
+		updated := cluster.DeepCopy()
+		expectStatusUpdate(mockClient, cluster, updated).Once()
+
+		drained, err := underTest.track(node, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drained).To(BeFalse())
+		status := updated.Status.Drains[0]
+		Expect(status.ShardsRemaining).To(Equal(int32(1)))
+		Expect(status.StartedAt.Time).To(Equal(now.Add(-10 * time.Minute)))
+		// 2000 bytes in 10 minutes, 1000 bytes left
+		Expect(status.EstimatedCompletionTime.Time).To(Equal(now.Add(5 * time.Minute)))
+	})
+
+	It("should not update the status if only the bytes changed recently", func() {
+		cluster.Status.Drains = []opensearchv1.DrainStatus{{
+			Node:             node,
+			StartedAt:        &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			LastUpdateTime:   &metav1.Time{Time: now.Add(-10 * time.Second)},
+			ShardsRemaining:  1,
+			RelocatingShards: 1,
+			InitialBytes:     3000,
+			BytesRemaining:   1500,
+		}}
+		withShards(responses.CatShardsResponse{Index: "b", Shard: "0", PrimaryOrReplica: "p", State: "RELOCATING", Store: "1000", NodeName: node})
+
+		drained, err := underTest.track(node, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drained).To(BeFalse())
+		Expect(cluster.Status.Drains[0].BytesRemaining).To(Equal(int64(1500)))
+	})
+
+	It("should remove the status once the node is drained", func() {
+		cluster.Status.Drains = []opensearchv1.DrainStatus{{Node: node, StartedAt: &metav1.Time{Time: now}}}
+		updated := cluster.DeepCopy()
+		expectStatusUpdate(mockClient, cluster, updated).Once()
+
+		drained, err := underTest.track(node, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drained).To(BeTrue())
+		Expect(updated.Status.Drains).To(BeEmpty())
+	})
+
+	When("the drain timeout expired", func() {
+		BeforeEach(func() {
+			cluster.Spec.General.Drain = &opensearchv1.DrainConfig{Timeout: &metav1.Duration{Duration: time.Hour}}
+			cluster.Status.Drains = []opensearchv1.DrainStatus{{
+				Node:            node,
+				StartedAt:       &metav1.Time{Time: now.Add(-2 * time.Hour)},
+				LastUpdateTime:  &metav1.Time{Time: now.Add(-time.Minute)},
+				ShardsRemaining: 1,
+				InitialBytes:    1000,
+				BytesRemaining:  1000,
+			}}
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/settings`),
+				httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{Transient: map[string]interface{}{
+					"cluster": map[string]interface{}{"routing": map[string]interface{}{"allocation": map[string]interface{}{"exclude": map[string]interface{}{"_name": node}}}},
+				}}))
+			transport.RegisterRegexpResponder(http.MethodPut, regexp.MustCompile(`/_cluster/settings`),
+				httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{}))
+		})
+
+		It("should abort the drain and wait for another timeout", func() {
+			withShards(
+				responses.CatShardsResponse{Index: "b", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "1000", NodeName: node},
+				responses.CatShardsResponse{Index: "b", Shard: "0", PrimaryOrReplica: "r", State: "STARTED", Store: "1000", NodeName: "drain-data-0"},
+			)
+			updated := cluster.DeepCopy()
+			expectStatusUpdate(mockClient, cluster, updated).Once()
+
+			drained, err := underTest.track(node, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drained).To(BeFalse())
+			Expect(updated.Status.Drains[0].AbortedAt.Time).To(Equal(now))
+			Expect(updated.Status.Drains[0].Message).To(ContainSubstring("Drain aborted after 1h0m0s"))
+			Expect(transport.GetCallCountInfo()["PUT =~/_cluster/settings"]).To(Equal(1))
+			Expect(recorder.Events).To(Receive(ContainSubstring("aborting the drain")))
+
+			Expect(underTest.aborted(node)).To(BeTrue())
+			now = now.Add(time.Hour)
+			Expect(underTest.aborted(node)).To(BeFalse())
+		})
+
+		It("should proceed if every shard has a copy on another node", func() {
+			cluster.Spec.General.Drain.TimeoutPolicy = opensearchv1.DrainTimeoutPolicyProceedIfReplicas
+			withShards(
+				responses.CatShardsResponse{Index: "b", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "1000", NodeName: node},
+				responses.CatShardsResponse{Index: "b", Shard: "0", PrimaryOrReplica: "r", State: "STARTED", Store: "1000", NodeName: "drain-data-0"},
+			)
+			updated := cluster.DeepCopy()
+			expectStatusUpdate(mockClient, cluster, updated).Once()
+
+			drained, err := underTest.track(node, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drained).To(BeTrue())
+			Expect(updated.Status.Drains).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring("proceeding")))
+		})
+
+		It("should abort if a shard has no copy on another node", func() {
+			cluster.Spec.General.Drain.TimeoutPolicy = opensearchv1.DrainTimeoutPolicyProceedIfReplicas
+			withShards(responses.CatShardsResponse{Index: "b", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", Store: "1000", NodeName: node})
+			updated := cluster.DeepCopy()
+			expectStatusUpdate(mockClient, cluster, updated).Once()
+
+			drained, err := underTest.track(node, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drained).To(BeFalse())
+			Expect(updated.Status.Drains[0].AbortedAt).ToNot(BeNil())
+			Expect(updated.Status.Drains[0].Message).To(ContainSubstring("1 of them without a copy"))
+		})
+	})
+})
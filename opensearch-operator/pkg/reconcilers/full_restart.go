@@ -0,0 +1,356 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	fullRestartReconcilerName = "fullrestart"
+	fullRestartRequeueAfter   = 10 * time.Second
+	// fullRestartRecoveryGracePeriod is how long the shards are given to start recovering after the shard allocation
+	// was restored, before a recovery without initializing shards is considered stalled
+	fullRestartRecoveryGracePeriod = time.Minute
+)
+
+// FullRestartReconciler stops all nodes of the cluster at once and starts them again in parallel, as requested with
+// the full-restart-at annotation. This recovers clusters that cannot recover with a rolling restart, e.g. because
+// they lost the quorum of cluster manager nodes.
+type FullRestartReconciler struct {
+	client   k8s.K8sClient
+	ctx      context.Context
+	recorder record.EventRecorder
+	instance *opensearchv1.OpenSearchCluster
+	logger   logr.Logger
+	ReconcilerOptions
+}
+
+func NewFullRestartReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opensearchv1.OpenSearchCluster,
+	opts ...ReconcilerOption,
+) *FullRestartReconciler {
+	options := ReconcilerOptions{}
+	options.apply(opts...)
+	return &FullRestartReconciler{
+		client:            k8s.NewK8sClient(client, ctx, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", fullRestartReconcilerName))),
+		ctx:               ctx,
+		recorder:          recorder,
+		instance:          instance,
+		logger:            log.FromContext(ctx).WithValues("reconciler", fullRestartReconcilerName),
+		ReconcilerOptions: options,
+	}
+}
+
+func (r *FullRestartReconciler) Name() string { return fullRestartReconcilerName }
+
+// Reconcile runs the current step of the full restart. While the restart is in progress the reconcilers after this
+// one are not run, so the nodes are not scaled, upgraded or restarted in the meantime.
+func (r *FullRestartReconciler) Reconcile() (ctrl.Result, error) {
+	if !helpers.FullRestartInProgress(r.instance.Status) {
+		if request := helpers.FullRestartRequest(r.instance); request != "" {
+			return r.start(request)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	switch r.instance.Status.FullRestart.Step {
+	case opensearchv1.FullRestartStepDisablingAllocation:
+		return r.disableAllocation()
+	case opensearchv1.FullRestartStepFlushing:
+		return r.flush()
+	case opensearchv1.FullRestartStepStoppingNodes:
+		return r.stopNodes()
+	case opensearchv1.FullRestartStepStartingNodes:
+		return r.startNodes()
+	case opensearchv1.FullRestartStepRestoringAllocation:
+		return r.restoreAllocation()
+	case opensearchv1.FullRestartStepRecoveringShards:
+		return r.recoverShards()
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *FullRestartReconciler) start(request string) (ctrl.Result, error) {
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	now := metav1.Now()
+	if reason := r.rejectReason(); reason != "" {
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "FullRestart", "Full restart requested with annotation %s=%s rejected: %s", helpers.FullRestartAtAnnotation, request, reason)
+		return ctrl.Result{}, r.updateStatus(func(status *opensearchv1.FullRestartStatus) {
+			*status = opensearchv1.FullRestartStatus{
+				Request:     request,
+				Step:        opensearchv1.FullRestartStepRejected,
+				CompletedAt: &now,
+				Message:     reason,
+			}
+		})
+	}
+
+	r.logger.Info(fmt.Sprintf("Starting full restart requested with annotation %s=%s", helpers.FullRestartAtAnnotation, request))
+	r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "FullRestart", "Starting full restart requested with annotation %s=%s", helpers.FullRestartAtAnnotation, request)
+	err := r.updateStatus(func(status *opensearchv1.FullRestartStatus) {
+		*status = opensearchv1.FullRestartStatus{
+			Request:   request,
+			Step:      opensearchv1.FullRestartStepDisablingAllocation,
+			StartedAt: &now,
+			Steps: []opensearchv1.FullRestartStepStatus{{
+				Name:      opensearchv1.FullRestartStepDisablingAllocation,
+				StartedAt: &now,
+			}},
+		}
+	})
+	return ctrl.Result{Requeue: true}, err
+}
+
+// rejectReason returns why the full restart cannot be run, an empty string if it can
+func (r *FullRestartReconciler) rejectReason() string {
+	for _, nodePool := range r.instance.Spec.NodePools {
+		if nodePool.Persistence != nil && nodePool.Persistence.EmptyDir != nil {
+			return fmt.Sprintf("nodePool %s stores its data in an emptyDir that would be lost", nodePool.Component)
+		}
+	}
+	if helpers.AnyNodePoolMigrationInProgress(r.instance.Status) {
+		return "a nodePool is being migrated"
+	}
+	return ""
+}
+
+func (r *FullRestartReconciler) disableAllocation() (ctrl.Result, error) {
+	osClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+	if err != nil {
+		return r.nextStep(opensearchv1.FullRestartStepFlushing, fmt.Sprintf("Cluster not reachable, shard allocation not changed: %s", err))
+	}
+	previous, err := services.GetClusterShardAllocation(osClient)
+	if err != nil {
+		return r.nextStep(opensearchv1.FullRestartStepFlushing, fmt.Sprintf("Failed to read the shard allocation, not changed: %s", err))
+	}
+	if err := services.SetClusterShardAllocation(osClient, services.ClusterSettingsAllocationPrimaries); err != nil {
+		return r.nextStep(opensearchv1.FullRestartStepFlushing, fmt.Sprintf("Failed to limit the shard allocation to primaries: %s", err))
+	}
+	err = r.updateStatus(func(status *opensearchv1.FullRestartStatus) {
+		status.AllocationDisabled = true
+		status.PreviousAllocation = previous
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return r.nextStep(opensearchv1.FullRestartStepFlushing, "Shard allocation limited to primaries")
+}
+
+func (r *FullRestartReconciler) flush() (ctrl.Result, error) {
+	osClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+	if err != nil {
+		return r.nextStep(opensearchv1.FullRestartStepStoppingNodes, fmt.Sprintf("Cluster not reachable, indices not flushed: %s", err))
+	}
+	// A failed flush only makes the recovery of the shards take longer
+	if err := osClient.Flush(); err != nil {
+		return r.nextStep(opensearchv1.FullRestartStepStoppingNodes, fmt.Sprintf("Failed to flush the indices: %s", err))
+	}
+	return r.nextStep(opensearchv1.FullRestartStepStoppingNodes, "Flushed all indices")
+}
+
+// stopNodes deletes the StatefulSets and their pods. The StatefulSets are deleted with orphan propagation, so PVCs
+// of nodepools with the retention policy whenDeleted=Delete are not garbage collected together with them, and the
+// orphaned pods are deleted one by one. The ClusterReconciler creates the StatefulSets again with parallel pod
+// management once all pods are gone.
+func (r *FullRestartReconciler) stopNodes() (ctrl.Result, error) {
+	remainingPods := 0
+	remainingStatefulSets := 0
+	for _, nodePool := range r.instance.Spec.NodePools {
+		sts, err := r.client.GetStatefulSet(builders.StsName(r.instance, &nodePool), r.instance.Namespace)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		if err == nil {
+			remainingStatefulSets++
+			if sts.DeletionTimestamp == nil {
+				r.logger.Info(fmt.Sprintf("Stopping the nodes of nodePool %s", nodePool.Component))
+				if err := r.client.DeleteStatefulSet(&sts, true); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			// The pods are only deleted once the StatefulSet is gone, otherwise it would recreate them
+			continue
+		}
+		pods, err := helpers.PodsForNodePool(r.client, r.instance, &nodePool)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for i := range pods {
+			remainingPods++
+			if pods[i].DeletionTimestamp != nil {
+				continue
+			}
+			if err := r.client.DeletePod(&pods[i]); err != nil && !k8serrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	if remainingStatefulSets > 0 {
+		return r.waiting(fmt.Sprintf("Waiting for %d statefulsets to be deleted", remainingStatefulSets))
+	}
+	if remainingPods > 0 {
+		return r.waiting(fmt.Sprintf("Waiting for %d pods to stop", remainingPods))
+	}
+	return r.nextStep(opensearchv1.FullRestartStepStartingNodes, "All nodes stopped")
+}
+
+func (r *FullRestartReconciler) startNodes() (ctrl.Result, error) {
+	var replicas, ready int32
+	for _, nodePool := range r.instance.Spec.NodePools {
+		readyReplicas, err := helpers.ReadyReplicasForNodePool(r.client, r.instance, &nodePool)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		replicas += helpers.NodePoolReplicas(r.instance, &nodePool)
+		ready += readyReplicas
+	}
+	if ready < replicas {
+		return r.waiting(fmt.Sprintf("%d of %d pods ready", ready, replicas))
+	}
+	osClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+	if err != nil {
+		return r.waiting("Waiting for the cluster to respond")
+	}
+	health, err := osClient.GetHealth()
+	if err != nil {
+		return r.waiting("Waiting for the cluster to elect a cluster manager")
+	}
+	if int32(health.NumberOfNodes) < replicas {
+		return r.waiting(fmt.Sprintf("%d of %d nodes joined the cluster", health.NumberOfNodes, replicas))
+	}
+	return r.nextStep(opensearchv1.FullRestartStepRestoringAllocation, fmt.Sprintf("All %d nodes joined the cluster", replicas))
+}
+
+// restoreAllocation waits for the primary shards to recover before the shard allocation is restored, so the replicas
+// are recovered from the primaries on the nodes that still have their data
+func (r *FullRestartReconciler) restoreAllocation() (ctrl.Result, error) {
+	osClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+	if err != nil {
+		return r.waiting("Waiting for the cluster to respond")
+	}
+	health, err := osClient.GetHealth()
+	if err != nil {
+		return r.waiting("Waiting for the cluster health")
+	}
+	if health.Status == "red" && health.InitializingShards > 0 {
+		return r.waiting(fmt.Sprintf("Waiting for the primary shards to recover, %d shards initializing, %d unassigned", health.InitializingShards, health.UnassignedShards))
+	}
+
+	status := r.instance.Status.FullRestart
+	if !status.AllocationDisabled {
+		return r.nextStep(opensearchv1.FullRestartStepRecoveringShards, "Shard allocation was not changed")
+	}
+	if err := services.RestoreClusterShardAllocation(osClient, status.PreviousAllocation); err != nil {
+		r.logger.Error(err, "failed to restore the shard allocation")
+		return ctrl.Result{}, err
+	}
+	restored := status.PreviousAllocation
+	if restored == "" {
+		restored = "its persistent or default value"
+	}
+	return r.nextStep(opensearchv1.FullRestartStepRecoveringShards, fmt.Sprintf("Shard allocation restored to %s", restored))
+}
+
+// recoverShards waits until all shards are recovered. If shards stay unassigned without any shard initializing, the
+// restart is completed with a warning, as they cannot be recovered by waiting longer.
+func (r *FullRestartReconciler) recoverShards() (ctrl.Result, error) {
+	osClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+	if err != nil {
+		return r.waiting("Waiting for the cluster to respond")
+	}
+	health, err := osClient.GetHealth()
+	if err != nil {
+		return r.waiting("Waiting for the cluster health")
+	}
+	if health.Status == "green" {
+		return r.nextStep(opensearchv1.FullRestartStepCompleted, "All shards recovered")
+	}
+	message := fmt.Sprintf("Cluster is %s, %d shards initializing, %d relocating, %d unassigned", health.Status, health.InitializingShards, health.RelocatingShards, health.UnassignedShards)
+	if health.InitializingShards > 0 || health.RelocatingShards > 0 || r.currentStepDuration() < fullRestartRecoveryGracePeriod {
+		return r.waiting(message)
+	}
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "FullRestart", "Shards did not recover after the full restart: %s", message)
+	return r.nextStep(opensearchv1.FullRestartStepCompleted, message)
+}
+
+// nextStep completes the current step with the given outcome and starts the next one
+func (r *FullRestartReconciler) nextStep(next opensearchv1.FullRestartStep, outcome string) (ctrl.Result, error) {
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	current := r.instance.Status.FullRestart.Step
+	r.logger.Info(fmt.Sprintf("Full restart step %s done: %s", current, outcome))
+	r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "FullRestart", "%s: %s", current, outcome)
+	now := metav1.Now()
+	err := r.updateStatus(func(status *opensearchv1.FullRestartStatus) {
+		if len(status.Steps) > 0 {
+			status.Steps[len(status.Steps)-1].CompletedAt = &now
+			status.Steps[len(status.Steps)-1].Message = outcome
+		}
+		status.Step = next
+		status.Message = ""
+		status.LastUpdateTime = &now
+		if next == opensearchv1.FullRestartStepCompleted {
+			status.CompletedAt = &now
+			return
+		}
+		status.Steps = append(status.Steps, opensearchv1.FullRestartStepStatus{Name: next, StartedAt: &now})
+	})
+	return ctrl.Result{Requeue: true}, err
+}
+
+// waiting reports the progress of the current step. The status is only updated if the progress changed, and at most
+// every fullRestartRequeueAfter as every status update triggers a reconcile.
+func (r *FullRestartReconciler) waiting(message string) (ctrl.Result, error) {
+	result := ctrl.Result{Requeue: true, RequeueAfter: fullRestartRequeueAfter}
+	status := r.instance.Status.FullRestart
+	if status.Message == message ||
+		(status.LastUpdateTime != nil && time.Since(status.LastUpdateTime.Time) < fullRestartRequeueAfter) {
+		return result, nil
+	}
+	r.logger.Info(message)
+	now := metav1.Now()
+	return result, r.updateStatus(func(status *opensearchv1.FullRestartStatus) {
+		status.Message = message
+		status.LastUpdateTime = &now
+	})
+}
+
+func (r *FullRestartReconciler) currentStepDuration() time.Duration {
+	steps := r.instance.Status.FullRestart.Steps
+	if len(steps) == 0 || steps[len(steps)-1].StartedAt == nil {
+		return 0
+	}
+	return time.Since(steps[len(steps)-1].StartedAt.Time)
+}
+
+// updateStatus records the progress of the full restart in the status of the cluster
+func (r *FullRestartReconciler) updateStatus(update func(status *opensearchv1.FullRestartStatus)) error {
+	status := opensearchv1.FullRestartStatus{}
+	if r.instance.Status.FullRestart != nil {
+		status = *r.instance.Status.FullRestart.DeepCopy()
+	}
+	update(&status)
+	r.instance.Status.FullRestart = &status
+	return r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.FullRestart = &status
+	})
+}
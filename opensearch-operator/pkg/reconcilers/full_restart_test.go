@@ -0,0 +1,220 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jarcoal/httpmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Full restart", func() {
+	const request = "2024-06-01T10:00:00Z"
+	var (
+		transport  *httpmock.MockTransport
+		mockClient *k8s.MockK8sClient
+		recorder   *record.FakeRecorder
+		cluster    *opensearchv1.OpenSearchCluster
+		underTest  *FullRestartReconciler
+	)
+
+	withStep := func(step opensearchv1.FullRestartStep, startedAt time.Time) {
+		cluster.Status.FullRestart = &opensearchv1.FullRestartStatus{
+			Request: request,
+			Step:    step,
+			Steps:   []opensearchv1.FullRestartStepStatus{{Name: step, StartedAt: &metav1.Time{Time: startedAt}}},
+		}
+	}
+	withHealth := func(health responses.ClusterHealthResponse) {
+		transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/health`),
+			httpmock.NewJsonResponderOrPanic(200, health))
+	}
+	BeforeEach(func() {
+		transport = httpmock.NewMockTransport()
+		transport.RegisterNoResponder(httpmock.NewNotFoundResponder(failMessage))
+		mockClient = k8s.NewMockK8sClient(GinkgoT())
+		recorder = record.NewFakeRecorder(10)
+		cluster = &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "restart",
+				Namespace:   "restart",
+				Annotations: map[string]string{helpers.FullRestartAtAnnotation: request},
+			},
+			Spec: opensearchv1.ClusterSpec{
+				General:   opensearchv1.GeneralConfig{ServiceName: "restart", HttpPort: 9200},
+				NodePools: []opensearchv1.NodePool{{Component: "nodes", Replicas: 3}},
+			},
+		}
+		clusterUrl := fmt.Sprintf("%s/", helpers.ClusterURL(cluster))
+		transport.RegisterResponder(http.MethodGet, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+		transport.RegisterResponder(http.MethodHead, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+		transport.RegisterRegexpResponder(http.MethodPut, regexp.MustCompile(`/_cluster/settings`),
+			httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{}))
+		mockClient.On("GetSecret", "restart-admin-password", "restart").Return(corev1.Secret{
+			Data: map[string][]byte{"username": []byte("admin"), "password": []byte("admin")},
+		}, nil).Maybe()
+	})
+
+	JustBeforeEach(func() {
+		underTest = &FullRestartReconciler{
+			client:   mockClient,
+			ctx:      context.Background(),
+			recorder: recorder,
+			instance: cluster,
+			logger:   logr.Discard(),
+		}
+		underTest.apply(WithOSClientTransport(transport))
+	})
+
+	It("should reject a full restart of a cluster storing data in an emptyDir", func() {
+		cluster.Spec.NodePools[0].Persistence = &opensearchv1.PersistenceConfig{
+			PersistenceSource: opensearchv1.PersistenceSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		result, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+		Expect(cluster.Status.FullRestart.Step).To(Equal(opensearchv1.FullRestartStepRejected))
+		Expect(cluster.Status.FullRestart.Request).To(Equal(request))
+		Expect(recorder.Events).To(Receive(ContainSubstring("rejected")))
+		Expect(helpers.FullRestartRequest(cluster)).To(BeEmpty())
+	})
+
+	It("should start a requested full restart by disabling the shard allocation", func() {
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		result, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Requeue).To(BeTrue())
+		Expect(cluster.Status.FullRestart.Step).To(Equal(opensearchv1.FullRestartStepDisablingAllocation))
+		Expect(cluster.Status.FullRestart.Steps).To(HaveLen(1))
+		Expect(cluster.Status.FullRestart.StartedAt).ToNot(BeNil())
+	})
+
+	It("should limit the shard allocation to primaries and remember the previous value", func() {
+		withStep(opensearchv1.FullRestartStepDisablingAllocation, time.Now())
+		transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/settings`),
+			httpmock.NewJsonResponderOrPanic(200, responses.FlatClusterSettingsResponse{
+				Transient: responses.Settings{ClusterRoutingAllocationEnable: "all"},
+			}))
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		_, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		status := cluster.Status.FullRestart
+		Expect(status.AllocationDisabled).To(BeTrue())
+		Expect(status.PreviousAllocation).To(Equal("all"))
+		Expect(status.Step).To(Equal(opensearchv1.FullRestartStepFlushing))
+		Expect(status.Steps).To(HaveLen(2))
+		Expect(status.Steps[0].CompletedAt).ToNot(BeNil())
+		Expect(transport.GetCallCountInfo()["PUT =~/_cluster/settings"]).To(Equal(1))
+	})
+
+	It("should stop the nodes even if the flush failed", func() {
+		withStep(opensearchv1.FullRestartStepFlushing, time.Now())
+		transport.RegisterRegexpResponder(http.MethodPost, regexp.MustCompile(`/_flush`),
+			httpmock.NewStringResponder(500, "failed"))
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		_, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.FullRestart.Step).To(Equal(opensearchv1.FullRestartStepStoppingNodes))
+		Expect(cluster.Status.FullRestart.Steps[0].Message).To(ContainSubstring("Failed to flush"))
+	})
+
+	It("should keep the PVCs when deleting the StatefulSets", func() {
+		cluster.Spec.NodePools[0].PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+			WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		}
+		withStep(opensearchv1.FullRestartStepStoppingNodes, time.Now())
+		sts := appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "restart-nodes", Namespace: "restart"}}
+		mockClient.EXPECT().GetStatefulSet("restart-nodes", "restart").Return(sts, nil)
+		// Orphan propagation keeps the PVCs owned by the StatefulSet
+		mockClient.EXPECT().DeleteStatefulSet(&sts, true).Return(nil).Once()
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		result, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(fullRestartRequeueAfter))
+		Expect(cluster.Status.FullRestart.Step).To(Equal(opensearchv1.FullRestartStepStoppingNodes))
+		Expect(cluster.Status.FullRestart.Message).To(Equal("Waiting for 1 statefulsets to be deleted"))
+	})
+
+	It("should delete the orphaned pods and wait for them to stop", func() {
+		withStep(opensearchv1.FullRestartStepStoppingNodes, time.Now())
+		mockClient.EXPECT().GetStatefulSet("restart-nodes", "restart").Return(appsv1.StatefulSet{}, NotFoundError())
+		terminating := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "restart-nodes-0", DeletionTimestamp: &metav1.Time{Time: time.Now()}}}
+		running := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "restart-nodes-1"}}
+		mockClient.EXPECT().ListPods(mock.Anything).Return(corev1.PodList{Items: []corev1.Pod{terminating, running}}, nil)
+		mockClient.EXPECT().DeletePod(&running).Return(nil).Once()
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		result, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(fullRestartRequeueAfter))
+		Expect(cluster.Status.FullRestart.Message).To(Equal("Waiting for 2 pods to stop"))
+	})
+
+	It("should restore the previous shard allocation once the primaries recovered", func() {
+		withStep(opensearchv1.FullRestartStepRestoringAllocation, time.Now())
+		cluster.Status.FullRestart.AllocationDisabled = true
+		cluster.Status.FullRestart.PreviousAllocation = "all"
+		withHealth(responses.ClusterHealthResponse{Status: "yellow", UnassignedShards: 3})
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		_, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.FullRestart.Step).To(Equal(opensearchv1.FullRestartStepRecoveringShards))
+		Expect(cluster.Status.FullRestart.Steps[0].Message).To(Equal("Shard allocation restored to all"))
+		Expect(transport.GetCallCountInfo()["PUT =~/_cluster/settings"]).To(Equal(1))
+	})
+
+	It("should complete the full restart once all shards recovered", func() {
+		withStep(opensearchv1.FullRestartStepRecoveringShards, time.Now())
+		withHealth(responses.ClusterHealthResponse{Status: "green"})
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		_, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.FullRestart.Step).To(Equal(opensearchv1.FullRestartStepCompleted))
+		Expect(cluster.Status.FullRestart.CompletedAt).ToNot(BeNil())
+		Expect(helpers.FullRestartInProgress(cluster.Status)).To(BeFalse())
+	})
+
+	It("should wait for the shards to start recovering", func() {
+		withStep(opensearchv1.FullRestartStepRecoveringShards, time.Now())
+		withHealth(responses.ClusterHealthResponse{Status: "yellow", UnassignedShards: 3})
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		result, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(fullRestartRequeueAfter))
+		Expect(cluster.Status.FullRestart.Step).To(Equal(opensearchv1.FullRestartStepRecoveringShards))
+	})
+
+	It("should complete with a warning if the shards do not recover", func() {
+		withStep(opensearchv1.FullRestartStepRecoveringShards, time.Now().Add(-2*fullRestartRecoveryGracePeriod))
+		withHealth(responses.ClusterHealthResponse{Status: "yellow", UnassignedShards: 3})
+		expectStatusUpdate(mockClient, cluster, cluster)
+
+		_, err := underTest.Reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.FullRestart.Step).To(Equal(opensearchv1.FullRestartStepCompleted))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Shards did not recover")))
+	})
+})
@@ -145,6 +145,18 @@ func (r *IndexTemplateReconciler) Reconcile() (result ctrl.Result, err error) {
 		return
 	}
 
+	// Check cluster is not paused
+	if helpers.ClusterPaused(r.cluster) {
+		r.logger.Info("opensearch cluster is paused, requeueing")
+		reason = "waiting for opensearch cluster to be resumed"
+		r.recorder.Event(r.instance, "Normal", opensearchPending, reason)
+		result = ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: 10 * time.Second,
+		}
+		return
+	}
+
 	r.osClient, err = util.CreateClientForCluster(r.client, r.ctx, r.cluster, r.osClientTransport)
 	if err != nil {
 		reason = "error creating opensearch client"
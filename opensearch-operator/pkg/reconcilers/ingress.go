@@ -0,0 +1,53 @@
+package reconcilers
+
+import (
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// reconcileIngress creates the Ingress or Gateway API route exposing the backend and
+// deletes the objects of all other kinds, e.g. after the kind was changed or the ingress disabled.
+// A missing config is handled like a disabled one, so removing the block deletes the objects as well.
+func reconcileIngress(
+	k8sClient k8s.K8sClient,
+	instance *opensearchv1.OpenSearchCluster,
+	config *opensearchv1.IngressConfig,
+	backend builders.IngressBackend,
+) (*ctrl.Result, error) {
+	result := reconciler.CombinedResult{}
+
+	kind := ""
+	if config != nil && config.Enable {
+		kind = config.Kind
+		if kind == "" {
+			kind = builders.IngressKindIngress
+		}
+	}
+
+	if kind == builders.IngressKindIngress {
+		ingress := builders.NewIngress(config, backend)
+		result.CombineErr(ctrl.SetControllerReference(instance, ingress, k8sClient.Scheme()))
+		result.Combine(k8sClient.ReconcileResource(ingress, reconciler.StatePresent))
+	} else {
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: backend.Name, Namespace: backend.Namespace}}
+		result.Combine(k8sClient.ReconcileResource(ingress, reconciler.StateAbsent))
+	}
+
+	for _, gvk := range []schema.GroupVersionKind{builders.HTTPRouteGVK, builders.TLSRouteGVK} {
+		if kind == gvk.Kind {
+			route := builders.NewGatewayRoute(config, backend)
+			result.CombineErr(ctrl.SetControllerReference(instance, route, k8sClient.Scheme()))
+			result.Combine(k8sClient.ReconcileResource(route, reconciler.StatePresent))
+		} else {
+			result.Combine(k8sClient.ReconcileResource(builders.NewEmptyGatewayRoute(gvk, backend), reconciler.StateAbsent))
+		}
+	}
+
+	return &result.Result, result.Err
+}
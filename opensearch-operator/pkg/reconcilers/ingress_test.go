@@ -0,0 +1,103 @@
+package reconcilers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/stretchr/testify/mock"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("Ingress reconciliation", func() {
+	const clusterName = "ingress"
+
+	var (
+		mockClient *k8s.MockK8sClient
+		instance   *opensearchv1.OpenSearchCluster
+		backend    builders.IngressBackend
+		states     map[string]reconciler.DesiredState
+	)
+
+	BeforeEach(func() {
+		mockClient = k8s.NewMockK8sClient(GinkgoT())
+		instance = &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "uid"},
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{ServiceName: clusterName, HttpPort: 9200},
+			},
+		}
+		backend = builders.OpenSearchIngressBackend(instance)
+		states = map[string]reconciler.DesiredState{}
+		mockClient.EXPECT().Scheme().Return(scheme.Scheme).Maybe()
+		mockClient.EXPECT().ReconcileResource(mock.Anything, mock.Anything).RunAndReturn(func(obj runtime.Object, state reconciler.DesiredState) (*ctrl.Result, error) {
+			switch o := obj.(type) {
+			case *networkingv1.Ingress:
+				Expect(o.Name).To(Equal(backend.Name))
+				states[builders.IngressKindIngress] = state
+			case *unstructured.Unstructured:
+				Expect(o.GetName()).To(Equal(backend.Name))
+				states[o.GetKind()] = state
+			}
+			return &ctrl.Result{}, nil
+		})
+	})
+
+	It("should create the ingress when it is enabled", func() {
+		config := &opensearchv1.IngressConfig{Enable: true, Hosts: []string{"opensearch.example.com"}}
+
+		_, err := reconcileIngress(mockClient, instance, config, backend)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(states).To(Equal(map[string]reconciler.DesiredState{
+			builders.IngressKindIngress:   reconciler.StatePresent,
+			builders.IngressKindHTTPRoute: reconciler.StateAbsent,
+			builders.IngressKindTLSRoute:  reconciler.StateAbsent,
+		}))
+	})
+
+	It("should delete all objects when the ingress is disabled", func() {
+		config := &opensearchv1.IngressConfig{Enable: false, Hosts: []string{"opensearch.example.com"}}
+
+		_, err := reconcileIngress(mockClient, instance, config, backend)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(states).To(Equal(map[string]reconciler.DesiredState{
+			builders.IngressKindIngress:   reconciler.StateAbsent,
+			builders.IngressKindHTTPRoute: reconciler.StateAbsent,
+			builders.IngressKindTLSRoute:  reconciler.StateAbsent,
+		}))
+	})
+
+	It("should delete the objects of the previous kind when the kind is switched", func() {
+		config := &opensearchv1.IngressConfig{
+			Enable:     true,
+			Kind:       builders.IngressKindHTTPRoute,
+			Hosts:      []string{"opensearch.example.com"},
+			ParentRefs: []opensearchv1.GatewayParentRef{{Name: "gateway"}},
+		}
+
+		_, err := reconcileIngress(mockClient, instance, config, backend)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(states).To(Equal(map[string]reconciler.DesiredState{
+			builders.IngressKindIngress:   reconciler.StateAbsent,
+			builders.IngressKindHTTPRoute: reconciler.StatePresent,
+			builders.IngressKindTLSRoute:  reconciler.StateAbsent,
+		}))
+	})
+
+	It("should delete all objects when the ingress block is removed", func() {
+		_, err := reconcileIngress(mockClient, instance, nil, backend)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(states).To(Equal(map[string]reconciler.DesiredState{
+			builders.IngressKindIngress:   reconciler.StateAbsent,
+			builders.IngressKindHTTPRoute: reconciler.StateAbsent,
+			builders.IngressKindTLSRoute:  reconciler.StateAbsent,
+		}))
+	})
+})
@@ -14,6 +14,7 @@ import (
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/requests"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
@@ -154,6 +155,17 @@ func (r *IsmPolicyReconciler) Reconcile() (retResult ctrl.Result, retErr error)
 		}, nil
 	}
 
+	// Check cluster is not paused
+	if helpers.ClusterPaused(r.cluster) {
+		r.logger.Info("opensearch cluster is paused, requeueing")
+		reason = "waiting for opensearch cluster to be resumed"
+		r.recorder.Event(r.instance, "Normal", opensearchPending, reason)
+		return ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: opensearchClusterRequeueAfter,
+		}, nil
+	}
+
 	r.osClient, retErr = util.CreateClientForCluster(r.client, r.ctx, r.cluster, r.osClientTransport)
 	if retErr != nil {
 		reason = "error creating opensearch client"
@@ -12,7 +12,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -39,8 +41,11 @@ type K8sClient interface {
 	DeleteDeployment(deployment *appsv1.Deployment, orphan bool) error
 	GetService(name, namespace string) (corev1.Service, error)
 	CreateService(svc *corev1.Service) (*ctrl.Result, error)
+	ListServices(listOptions ...client.ListOption) (corev1.ServiceList, error)
 	GetOpenSearchCluster(name, namespace string) (opensearchv1.OpenSearchCluster, error)
 	UpdateOpenSearchClusterStatus(key client.ObjectKey, f func(*opensearchv1.OpenSearchCluster)) error
+	GetOpenSearchNodePool(name, namespace string) (opensearchv1.OpenSearchNodePool, error)
+	ListOpenSearchNodePools(listOptions ...client.ListOption) (opensearchv1.OpenSearchNodePoolList, error)
 	UdateObjectStatus(instance client.Object, f func(client.Object)) error
 	ReconcileResource(runtime.Object, reconciler.DesiredState) (*ctrl.Result, error)
 	GetPod(name, namespace string) (corev1.Pod, error)
@@ -48,9 +53,12 @@ type K8sClient interface {
 	ListPods(listOptions *client.ListOptions) (corev1.PodList, error)
 	WaitForPodDeletion(podName, namespace string) error
 	UpdatePodLabels(pod *corev1.Pod, newLabels map[string]string) error
+	RemovePodLabels(pod *corev1.Pod, keys []string) error
 	GetPVC(name, namespace string) (corev1.PersistentVolumeClaim, error)
 	UpdatePVC(pvc *corev1.PersistentVolumeClaim) error
+	DeletePVC(pvc *corev1.PersistentVolumeClaim) error
 	ListPVCs(listOptions *client.ListOptions) (corev1.PersistentVolumeClaimList, error)
+	GetUnstructured(gvk schema.GroupVersionKind, name, namespace string) (*unstructured.Unstructured, error)
 	Scheme() *runtime.Scheme
 	Context() context.Context
 }
@@ -176,6 +184,12 @@ func (c K8sClientImpl) CreateService(svc *corev1.Service) (*ctrl.Result, error)
 	return c.ReconcileResource(svc, reconciler.StatePresent)
 }
 
+func (c K8sClientImpl) ListServices(listOptions ...client.ListOption) (corev1.ServiceList, error) {
+	list := corev1.ServiceList{}
+	err := c.List(c.ctx, &list, listOptions...)
+	return list, err
+}
+
 func (c K8sClientImpl) GetOpenSearchCluster(name, namespace string) (opensearchv1.OpenSearchCluster, error) {
 	cluster := opensearchv1.OpenSearchCluster{}
 	err := c.Get(c.ctx, client.ObjectKey{Name: name, Namespace: namespace}, &cluster)
@@ -194,6 +208,18 @@ func (c K8sClientImpl) UpdateOpenSearchClusterStatus(key client.ObjectKey, f fun
 	})
 }
 
+func (c K8sClientImpl) GetOpenSearchNodePool(name, namespace string) (opensearchv1.OpenSearchNodePool, error) {
+	nodePool := opensearchv1.OpenSearchNodePool{}
+	err := c.Get(c.ctx, client.ObjectKey{Name: name, Namespace: namespace}, &nodePool)
+	return nodePool, err
+}
+
+func (c K8sClientImpl) ListOpenSearchNodePools(listOptions ...client.ListOption) (opensearchv1.OpenSearchNodePoolList, error) {
+	list := opensearchv1.OpenSearchNodePoolList{}
+	err := c.List(c.ctx, &list, listOptions...)
+	return list, err
+}
+
 // UpdateStatus for a generic kubernetes object. f should cast to specific type (e.g. `role := instance.(OpenSearchRole)`)
 func (c K8sClientImpl) UdateObjectStatus(instance client.Object, f func(client.Object)) error {
 	key := client.ObjectKeyFromObject(instance)
@@ -226,6 +252,14 @@ func (c K8sClientImpl) ListPods(listOptions *client.ListOptions) (corev1.PodList
 	return list, err
 }
 
+// GetUnstructured fetches an object of a kind the operator has no go types for, e.g. cert-manager Certificates
+func (c K8sClientImpl) GetUnstructured(gvk schema.GroupVersionKind, name, namespace string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	err := c.Get(c.ctx, client.ObjectKey{Name: name, Namespace: namespace}, obj)
+	return obj, err
+}
+
 func (c K8sClientImpl) GetPVC(name, namespace string) (corev1.PersistentVolumeClaim, error) {
 	pvc := corev1.PersistentVolumeClaim{}
 	err := c.Get(c.ctx, client.ObjectKey{Name: name, Namespace: namespace}, &pvc)
@@ -236,6 +270,10 @@ func (c K8sClientImpl) UpdatePVC(pvc *corev1.PersistentVolumeClaim) error {
 	return c.Update(c.ctx, pvc)
 }
 
+func (c K8sClientImpl) DeletePVC(pvc *corev1.PersistentVolumeClaim) error {
+	return c.Delete(c.ctx, pvc)
+}
+
 func (c K8sClientImpl) ListPVCs(listOptions *client.ListOptions) (corev1.PersistentVolumeClaimList, error) {
 	list := corev1.PersistentVolumeClaimList{}
 	err := c.List(c.ctx, &list, listOptions)
@@ -292,5 +330,18 @@ func (c K8sClientImpl) UpdatePodLabels(pod *corev1.Pod, newLabels map[string]str
 	return c.Update(c.ctx, podCopy)
 }
 
+// RemovePodLabels removes the labels with the provided keys from a pod and refreshes the pod with the updated object
+func (c K8sClientImpl) RemovePodLabels(pod *corev1.Pod, keys []string) error {
+	podCopy := pod.DeepCopy()
+	for _, k := range keys {
+		delete(podCopy.Labels, k)
+	}
+	if err := c.Update(c.ctx, podCopy); err != nil {
+		return err
+	}
+	podCopy.DeepCopyInto(pod)
+	return nil
+}
+
 // Validate K8sClientImpl implements the interface
 var _ K8sClient = (*K8sClientImpl)(nil)
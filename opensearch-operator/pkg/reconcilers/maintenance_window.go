@@ -0,0 +1,47 @@
+package reconcilers
+
+import (
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maintenanceWindowOpen returns true if disruptive operations can be started now and reports the open or next
+// maintenance window in the status of the cluster. If the windows cannot be evaluated no operation is started.
+func maintenanceWindowOpen(k8sClient k8s.K8sClient, instance *opensearchv1.OpenSearchCluster) (bool, error) {
+	var status *opensearchv1.MaintenanceWindowStatus
+	open := true
+	if len(instance.Spec.MaintenanceWindows) > 0 {
+		status = &opensearchv1.MaintenanceWindowStatus{}
+		var start, end time.Time
+		var err error
+		open, start, end, err = helpers.MaintenanceWindowAt(instance.Spec.MaintenanceWindows, time.Now())
+		if err != nil {
+			status.Message = err.Error()
+		}
+		status.Open = open
+		if !start.IsZero() {
+			status.Start = &metav1.Time{Time: start}
+			status.End = &metav1.Time{Time: end}
+		}
+	}
+
+	if maintenanceWindowStatusEqual(instance.Status.MaintenanceWindow, status) {
+		return open, nil
+	}
+	instance.Status.MaintenanceWindow = status
+	return open, k8sClient.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.MaintenanceWindow = status
+	})
+}
+
+func maintenanceWindowStatusEqual(a, b *opensearchv1.MaintenanceWindowStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Open == b.Open && a.Message == b.Message && a.Start.Equal(b.Start) && a.End.Equal(b.End)
+}
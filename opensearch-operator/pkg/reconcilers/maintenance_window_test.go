@@ -0,0 +1,104 @@
+package reconcilers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Maintenance windows", func() {
+	var (
+		mockClient *k8s.MockK8sClient
+		spec       *opensearchv1.OpenSearchCluster
+	)
+
+	withWindow := func(expression string, duration time.Duration) {
+		spec.Spec.MaintenanceWindows = []opensearchv1.MaintenanceWindow{{
+			Cron:     opensearchv1.CronExpression{Expression: expression, Timezone: "UTC"},
+			Duration: metav1.Duration{Duration: duration},
+		}}
+	}
+	BeforeEach(func() {
+		spec = &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "maintenance"},
+		}
+		mockClient = k8s.NewMockK8sClient(GinkgoT())
+	})
+
+	It("should keep the current version until the upgrade started", func() {
+		spec.Spec.General.Version = "2.19.0"
+		spec.Status.Version = "2.18.0"
+		Expect(upgradePending(spec)).To(BeTrue())
+
+		spec.Status.Phase = opensearchv1.PhaseUpgrading
+		Expect(upgradePending(spec)).To(BeFalse())
+
+		spec.Status.Phase = opensearchv1.PhaseRunning
+		spec.Status.Version = "2.19.0"
+		Expect(upgradePending(spec)).To(BeFalse())
+	})
+
+	It("should allow operations without maintenance windows", func() {
+		open, err := maintenanceWindowOpen(mockClient, spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+	})
+
+	It("should report the open window", func() {
+		withWindow("* * * * *", 2*time.Minute)
+		updated := spec.DeepCopy()
+		expectStatusUpdate(mockClient, spec, updated)
+
+		open, err := maintenanceWindowOpen(mockClient, spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+		Expect(updated.Status.MaintenanceWindow.Open).To(BeTrue())
+		Expect(updated.Status.MaintenanceWindow.Start.Time).To(BeTemporally("<=", time.Now()))
+		Expect(updated.Status.MaintenanceWindow.End.Time).To(BeTemporally(">", time.Now()))
+		Expect(spec.Status.MaintenanceWindow).To(Equal(updated.Status.MaintenanceWindow))
+	})
+
+	It("should not update an unchanged status", func() {
+		withWindow("* * * * *", 2*time.Minute)
+		expectStatusUpdate(mockClient, spec, spec.DeepCopy())
+		_, err := maintenanceWindowOpen(mockClient, spec)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Serialized times lose the timezone
+		spec.Status.MaintenanceWindow.Start = &metav1.Time{Time: spec.Status.MaintenanceWindow.Start.Local()}
+		open, err := maintenanceWindowOpen(mockClient, spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+	})
+
+	It("should wait for the next window", func() {
+		// Starts on the 1st of January only
+		withWindow("0 0 1 1 *", time.Hour)
+		updated := spec.DeepCopy()
+		expectStatusUpdate(mockClient, spec, updated)
+
+		open, err := maintenanceWindowOpen(mockClient, spec)
+		Expect(err).ToNot(HaveOccurred())
+		if time.Now().UTC().YearDay() == 1 && time.Now().UTC().Hour() == 0 {
+			Skip("the maintenance window is open")
+		}
+		Expect(open).To(BeFalse())
+		Expect(updated.Status.MaintenanceWindow.Open).To(BeFalse())
+		Expect(updated.Status.MaintenanceWindow.Start.Time).To(BeTemporally(">", time.Now()))
+	})
+
+	It("should not start operations if the windows are invalid", func() {
+		withWindow("0 0 1", time.Hour)
+		updated := spec.DeepCopy()
+		expectStatusUpdate(mockClient, spec, updated)
+
+		open, err := maintenanceWindowOpen(mockClient, spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeFalse())
+		Expect(updated.Status.MaintenanceWindow.Message).To(ContainSubstring("invalid cron expression"))
+	})
+})
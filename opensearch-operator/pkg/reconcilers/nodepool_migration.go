@@ -0,0 +1,265 @@
+package reconcilers
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const nodePoolMigrationRequeueAfter = 15 * time.Second
+
+// storageSettingsChanged reports whether the storage of the desired StatefulSet differs from the existing one in a
+// way that cannot be applied in place (storage type, storage class or access modes).
+func (r *ClusterReconciler) storageSettingsChanged(existing, desired *appsv1.StatefulSet) bool {
+	if !r.instance.Status.Initialized {
+		return false
+	}
+	existingType := helpers.StatefulSetStorageType(existing)
+	desiredType := helpers.StatefulSetStorageType(desired)
+	if existingType != desiredType {
+		return existingType != ""
+	}
+	if desiredType != helpers.StorageTypePVC {
+		return false
+	}
+	existingSpec := existing.Spec.VolumeClaimTemplates[0].Spec
+	desiredSpec := desired.Spec.VolumeClaimTemplates[0].Spec
+	return lo.FromPtr(existingSpec.StorageClassName) != lo.FromPtr(desiredSpec.StorageClassName) ||
+		!slices.Equal(existingSpec.AccessModes, desiredSpec.AccessModes)
+}
+
+// startNodePoolMigration records a new migration for the node pool and creates the shadow StatefulSet.
+// The remaining steps (drain, delete old pool) are driven by the ScalerReconciler.
+func (r *ClusterReconciler) startNodePoolMigration(existing, sts *appsv1.StatefulSet, nodePool opensearchv1.NodePool) (*ctrl.Result, error) {
+	storageType := helpers.StatefulSetStorageType(sts)
+	var storageClass string
+	var accessModes []corev1.PersistentVolumeAccessMode
+	if storageType == helpers.StorageTypePVC {
+		claimSpec := sts.Spec.VolumeClaimTemplates[0].Spec
+		storageClass = lo.FromPtr(claimSpec.StorageClassName)
+		accessModes = claimSpec.AccessModes
+	}
+	target := helpers.NodePoolMigrationTargetName(r.instance, nodePool.Component, storageType, storageClass, accessModes)
+
+	currentStatus, _ := helpers.FindNodePoolMigration(r.instance.Status, nodePool.Component)
+	componentStatus := opensearchv1.ComponentStatus{
+		Component:   helpers.NodePoolMigrationComponent,
+		Status:      helpers.NodePoolMigrationCreatingTarget,
+		Description: nodePool.Component,
+		Conditions:  helpers.NodePoolMigrationConditions(existing.Name, target),
+	}
+	err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.ComponentsStatus = helpers.Replace(currentStatus, componentStatus, instance.Status.ComponentsStatus)
+	})
+	if err != nil {
+		r.logger.Error(err, "Failed to update node pool migration status")
+		return &ctrl.Result{Requeue: true}, err
+	}
+
+	r.logger.Info(fmt.Sprintf("Storage settings changed for nodePool %s, replacing statefulset %s with %s", nodePool.Component, existing.Name, target))
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "NodePoolMigration", "Starting migration of nodePool %s from statefulset %s to %s (storage type '%s', storage class '%s')", nodePool.Component, existing.Name, target, storageType, storageClass)
+
+	return r.reconcileNodePoolMigrationTarget(sts, target)
+}
+
+// startNodePoolRename starts a migration from the StatefulSet of the node pool given in renamedFrom to the StatefulSet
+// of the node pool, if the former still exists. It returns false if there is nothing to migrate.
+func (r *ClusterReconciler) startNodePoolRename(sts *appsv1.StatefulSet, nodePool opensearchv1.NodePool) (*ctrl.Result, bool, error) {
+	if !r.instance.Status.Initialized || nodePool.RenamedFrom == "" {
+		return nil, false, nil
+	}
+	if _, found := helpers.FindNodePoolMigration(r.instance.Status, nodePool.Component); found {
+		return nil, false, nil
+	}
+	source, err := r.client.GetStatefulSet(helpers.NodePoolStsName(r.instance, nodePool.RenamedFrom), r.instance.Namespace)
+	if k8serrors.IsNotFound(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	componentStatus := opensearchv1.ComponentStatus{
+		Component:   helpers.NodePoolMigrationComponent,
+		Status:      helpers.NodePoolMigrationCreatingTarget,
+		Description: nodePool.Component,
+		Conditions:  helpers.NodePoolMigrationConditions(source.Name, sts.Name),
+	}
+	err = r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.ComponentsStatus = append(instance.Status.ComponentsStatus, componentStatus)
+	})
+	if err != nil {
+		r.logger.Error(err, "Failed to update node pool migration status")
+		return &ctrl.Result{Requeue: true}, true, err
+	}
+
+	r.logger.Info(fmt.Sprintf("NodePool %s was renamed to %s, replacing statefulset %s with %s", nodePool.RenamedFrom, nodePool.Component, source.Name, sts.Name))
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "NodePoolMigration", "Starting migration of nodePool %s from statefulset %s to %s (renamed from %s)", nodePool.Component, source.Name, sts.Name, nodePool.RenamedFrom)
+
+	result, err := r.reconcileNodePoolMigrationTarget(sts, sts.Name)
+	return result, true, err
+}
+
+// reconcileNodePoolMigrationTarget applies the desired StatefulSet under the shadow name.
+// Its pods carry the same node pool labels, so services and the PDB cover both StatefulSets during the migration.
+func (r *ClusterReconciler) reconcileNodePoolMigrationTarget(sts *appsv1.StatefulSet, target string) (*ctrl.Result, error) {
+	sts.Name = target
+	return r.client.ReconcileResource(sts, reconciler.StatePresent)
+}
+
+// reconcileNodePoolMigration moves a node pool onto its shadow StatefulSet:
+// wait for the shadow pods, exclude and drain the old nodes, then scale the old StatefulSet down one node at a time
+// and delete it once all of its pods are gone.
+func (r *ScalerReconciler) reconcileNodePoolMigration(nodePool *opensearchv1.NodePool) (*ctrl.Result, error) {
+	lg := log.FromContext(r.ctx)
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	currentStatus, _ := helpers.FindNodePoolMigration(r.instance.Status, nodePool.Component)
+	source := helpers.NodePoolMigrationSource(currentStatus)
+	target := helpers.NodePoolMigrationTarget(currentStatus)
+
+	switch currentStatus.Status {
+	case helpers.NodePoolMigrationCreatingTarget:
+		targetSts, err := r.client.GetStatefulSet(target, r.instance.Namespace)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+			}
+			return nil, err
+		}
+		if targetSts.Status.ReadyReplicas < nodePool.Replicas {
+			lg.Info(fmt.Sprintf("Group: %s, Waiting for statefulset %s to become ready", nodePool.Component, target))
+			return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+		}
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "NodePoolMigration", "Statefulset %s is ready, draining nodes of %s", target, source)
+		return &ctrl.Result{Requeue: true}, r.updateNodePoolMigrationStatus(currentStatus, helpers.NodePoolMigrationDraining)
+
+	case helpers.NodePoolMigrationDraining:
+		sourceSts, err := r.client.GetStatefulSet(source, r.instance.Namespace)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return &ctrl.Result{Requeue: true}, r.updateNodePoolMigrationStatus(currentStatus, helpers.NodePoolMigrationDeletingSource)
+			}
+			return nil, err
+		}
+		clusterClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+		if err != nil {
+			lg.Error(err, "failed to create os client")
+			return nil, err
+		}
+		tracker := newDrainTracker(r.client, clusterClient, r.instance, r.recorder, lg)
+		drained := true
+		for ordinal := int32(0); ordinal < ptr.Deref(sourceSts.Spec.Replicas, 1); ordinal++ {
+			nodeName := helpers.ReplicaHostName(sourceSts, ordinal)
+			if tracker.aborted(nodeName) {
+				drained = false
+				continue
+			}
+			// Exclusions are re-applied on every pass as other reconcilers may clear them (e.g. after a restart)
+			if _, err := services.AppendExcludeNodeHost(clusterClient, lg, nodeName); err != nil {
+				lg.Error(err, fmt.Sprintf("failed to exclude node %s", nodeName))
+				return nil, err
+			}
+			nodeNotEmpty, err := services.HasShardsOnNode(clusterClient, nodeName)
+			if err != nil {
+				lg.Error(err, "failed to check shards on node")
+				return nil, err
+			}
+			nodeDrained, err := tracker.track(nodeName, !nodeNotEmpty)
+			if err != nil {
+				return nil, err
+			}
+			if !nodeDrained {
+				drained = false
+			}
+		}
+		if !drained {
+			lg.Info(fmt.Sprintf("Group: %s, Waiting for nodes of statefulset %s to drain", nodePool.Component, source))
+			return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+		}
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "NodePoolMigration", "Nodes of statefulset %s are drained, removing it", source)
+		return &ctrl.Result{Requeue: true}, r.updateNodePoolMigrationStatus(currentStatus, helpers.NodePoolMigrationDeletingSource)
+
+	case helpers.NodePoolMigrationDeletingSource:
+		sourceSts, err := r.client.GetStatefulSet(source, r.instance.Namespace)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return r.completeNodePoolMigration(currentStatus, nodePool.Component)
+			}
+			return nil, err
+		}
+		clusterClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+		if err != nil {
+			lg.Error(err, "failed to create os client")
+			return nil, err
+		}
+
+		replicas := ptr.Deref(sourceSts.Spec.Replicas, 1)
+		if sourceSts.Status.Replicas > replicas {
+			lg.Info(fmt.Sprintf("Group: %s, Waiting for the removed node of statefulset %s to stop", nodePool.Component, source))
+			return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+		}
+		// The node removed in the previous pass is gone, so its shards may not return to it
+		if _, err := services.RemoveExcludeNodeHost(clusterClient, lg, helpers.ReplicaHostName(sourceSts, replicas)); err != nil {
+			lg.Error(err, fmt.Sprintf("failed to remove node exclusion for %s", helpers.ReplicaHostName(sourceSts, replicas)))
+		}
+		if replicas > 0 {
+			// Every node is removed by scaling down, so cluster manager nodes are excluded from voting first
+			nodeName := helpers.ReplicaHostName(sourceSts, replicas-1)
+			if helpers.StatefulSetHasManagerRole(&sourceSts) {
+				excluded, err := r.excludeFromVoting(clusterClient, nodeName)
+				if err != nil {
+					return nil, err
+				}
+				if !excluded {
+					return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+				}
+			}
+			sourceSts.Spec.Replicas = ptr.To(replicas - 1)
+			if result, err := r.client.ReconcileResource(&sourceSts, reconciler.StatePresent); err != nil {
+				return result, err
+			}
+			return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+		}
+		if result, err := r.client.ReconcileResource(&sourceSts, reconciler.StateAbsent); err != nil {
+			return result, err
+		}
+		return r.completeNodePoolMigration(currentStatus, nodePool.Component)
+	}
+	return &ctrl.Result{}, nil
+}
+
+func (r *ScalerReconciler) completeNodePoolMigration(currentStatus opensearchv1.ComponentStatus, component string) (*ctrl.Result, error) {
+	if err := r.updateNodePoolMigrationStatus(currentStatus, helpers.NodePoolMigrationCompleted); err != nil {
+		return &ctrl.Result{Requeue: true}, err
+	}
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "NodePoolMigration", "Finished migration of nodePool %s to statefulset %s", component, helpers.NodePoolMigrationTarget(currentStatus))
+	return &ctrl.Result{Requeue: true}, nil
+}
+
+func (r *ScalerReconciler) updateNodePoolMigrationStatus(currentStatus opensearchv1.ComponentStatus, phase string) error {
+	componentStatus := currentStatus
+	componentStatus.Status = phase
+	err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.ComponentsStatus = helpers.Replace(currentStatus, componentStatus, instance.Status.ComponentsStatus)
+	})
+	if err != nil {
+		log.FromContext(r.ctx).Error(err, "failed to update node pool migration status")
+	}
+	return err
+}
@@ -0,0 +1,298 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/jarcoal/httpmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func newMigrationTestCluster(phase string) opensearchv1.OpenSearchCluster {
+	return opensearchv1.OpenSearchCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "test-namespace",
+			UID:       "dummyuid",
+		},
+		Spec: opensearchv1.ClusterSpec{
+			NodePools: []opensearchv1.NodePool{
+				{
+					Component: "data",
+					Replicas:  3,
+				},
+			},
+		},
+		Status: opensearchv1.ClusterStatus{
+			Initialized: true,
+			ComponentsStatus: []opensearchv1.ComponentStatus{
+				{
+					Component:   helpers.NodePoolMigrationComponent,
+					Status:      phase,
+					Description: "data",
+					Conditions:  helpers.NodePoolMigrationConditions("test-cluster-data", "test-cluster-data-abcde"),
+				},
+			},
+		},
+	}
+}
+
+func newStsWithStorageClass(name string, storageClass string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					Spec: corev1.PersistentVolumeClaimSpec{
+						StorageClassName: ptr.To(storageClass),
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("Node pool migration", func() {
+	Context("When detecting storage changes", func() {
+		It("Should detect a changed storage class", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationCompleted)
+			underTest := &ClusterReconciler{instance: &spec}
+			existing := newStsWithStorageClass("test-cluster-data", "standard")
+			desired := newStsWithStorageClass("test-cluster-data", "premium")
+			Expect(underTest.storageSettingsChanged(existing, desired)).To(BeTrue())
+		})
+
+		It("Should ignore unchanged storage settings", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationCompleted)
+			underTest := &ClusterReconciler{instance: &spec}
+			existing := newStsWithStorageClass("test-cluster-data", "standard")
+			desired := newStsWithStorageClass("test-cluster-data", "standard")
+			Expect(underTest.storageSettingsChanged(existing, desired)).To(BeFalse())
+		})
+
+		It("Should detect a changed storage type", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationCompleted)
+			underTest := &ClusterReconciler{instance: &spec}
+			existing := newStsWithStorageClass("test-cluster-data", "standard")
+			desired := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/data"}}}},
+			}}}}
+			Expect(underTest.storageSettingsChanged(existing, desired)).To(BeTrue())
+		})
+
+		It("Should not migrate a cluster that is not initialized", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationCompleted)
+			spec.Status.Initialized = false
+			underTest := &ClusterReconciler{instance: &spec}
+			existing := newStsWithStorageClass("test-cluster-data", "standard")
+			desired := newStsWithStorageClass("test-cluster-data", "premium")
+			Expect(underTest.storageSettingsChanged(existing, desired)).To(BeFalse())
+		})
+	})
+
+	Context("When the shadow statefulset is being created", func() {
+		It("Should wait until all replicas are ready", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationCreatingTarget)
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.On("GetStatefulSet", "test-cluster-data-abcde", "test-namespace").Return(appsv1.StatefulSet{
+				Status: appsv1.StatefulSetStatus{ReadyReplicas: 1},
+			}, nil)
+
+			underTest := newScalerReconciler(mockClient, &spec)
+			result, err := underTest.reconcileNodePoolMigration(&spec.Spec.NodePools[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(nodePoolMigrationRequeueAfter))
+			mockClient.AssertExpectations(GinkgoT())
+		})
+
+		It("Should start draining once the shadow statefulset is ready", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationCreatingTarget)
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.On("GetStatefulSet", "test-cluster-data-abcde", "test-namespace").Return(appsv1.StatefulSet{
+				Status: appsv1.StatefulSetStatus{ReadyReplicas: 3},
+			}, nil)
+			mockClient.On("UpdateOpenSearchClusterStatus", client.ObjectKeyFromObject(&spec), mock.AnythingOfType("func(*v1.OpenSearchCluster)")).Run(func(args mock.Arguments) {
+				updateFn := args.Get(1).(func(*opensearchv1.OpenSearchCluster))
+				updateFn(&spec)
+			}).Return(nil)
+
+			underTest := &ScalerReconciler{
+				client:   mockClient,
+				ctx:      log.IntoContext(context.Background(), log.Log),
+				recorder: record.NewFakeRecorder(10),
+				instance: &spec,
+			}
+			_, err := underTest.reconcileNodePoolMigration(&spec.Spec.NodePools[0])
+			Expect(err).ToNot(HaveOccurred())
+			migration, found := helpers.FindNodePoolMigration(spec.Status, "data")
+			Expect(found).To(BeTrue())
+			Expect(migration.Status).To(Equal(helpers.NodePoolMigrationDraining))
+			mockClient.AssertExpectations(GinkgoT())
+		})
+	})
+
+	Context("When a node pool is renamed", func() {
+		It("Should migrate the statefulset of the former node pool", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationCompleted)
+			spec.Status.ComponentsStatus = nil
+			spec.Spec.NodePools[0] = opensearchv1.NodePool{Component: "hot", Replicas: 3, RenamedFrom: "data"}
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().GetStatefulSet("test-cluster-data", "test-namespace").Return(appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-data"},
+			}, nil)
+			mockClient.On("UpdateOpenSearchClusterStatus", client.ObjectKeyFromObject(&spec), mock.AnythingOfType("func(*v1.OpenSearchCluster)")).Run(func(args mock.Arguments) {
+				updateFn := args.Get(1).(func(*opensearchv1.OpenSearchCluster))
+				updateFn(&spec)
+			}).Return(nil)
+			sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-hot"}}
+			mockClient.EXPECT().ReconcileResource(sts, reconciler.StatePresent).Return(nil, nil)
+
+			underTest := &ClusterReconciler{client: mockClient, instance: &spec, recorder: record.NewFakeRecorder(10), logger: log.Log}
+			_, started, err := underTest.startNodePoolRename(sts, spec.Spec.NodePools[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+			migration, found := helpers.FindNodePoolMigration(spec.Status, "hot")
+			Expect(found).To(BeTrue())
+			Expect(migration.Status).To(Equal(helpers.NodePoolMigrationCreatingTarget))
+			Expect(helpers.NodePoolMigrationSource(migration)).To(Equal("test-cluster-data"))
+			Expect(helpers.NodePoolMigrationTarget(migration)).To(Equal("test-cluster-hot"))
+		})
+
+		It("Should not migrate once the former node pool is gone", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationCompleted)
+			spec.Status.ComponentsStatus = nil
+			spec.Spec.NodePools[0] = opensearchv1.NodePool{Component: "hot", Replicas: 3, RenamedFrom: "data"}
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().GetStatefulSet("test-cluster-data", "test-namespace").Return(appsv1.StatefulSet{},
+				k8serrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "statefulsets"}, "test-cluster-data"))
+
+			underTest := &ClusterReconciler{client: mockClient, instance: &spec, logger: log.Log}
+			_, started, err := underTest.startNodePoolRename(&appsv1.StatefulSet{}, spec.Spec.NodePools[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeFalse())
+		})
+	})
+
+	Context("When removing the old statefulset", func() {
+		var (
+			transport  *httpmock.MockTransport
+			mockClient *k8s.MockK8sClient
+			spec       opensearchv1.OpenSearchCluster
+			sourceSts  appsv1.StatefulSet
+			underTest  *ScalerReconciler
+		)
+
+		BeforeEach(func() {
+			transport = httpmock.NewMockTransport()
+			transport.RegisterNoResponder(httpmock.NewNotFoundResponder(failMessage))
+			mockClient = k8s.NewMockK8sClient(GinkgoT())
+			spec = newMigrationTestCluster(helpers.NodePoolMigrationDeletingSource)
+			spec.Spec.General = opensearchv1.GeneralConfig{ServiceName: "test-cluster", HttpPort: 9200}
+			clusterUrl := fmt.Sprintf("%s/", helpers.ClusterURL(&spec))
+			transport.RegisterResponder(http.MethodGet, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+			transport.RegisterResponder(http.MethodHead, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/settings`),
+				httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{}))
+			mockClient.On("GetSecret", "test-cluster-admin-password", "test-namespace").Return(corev1.Secret{
+				Data: map[string][]byte{"username": []byte("admin"), "password": []byte("admin")},
+			}, nil).Maybe()
+			sourceSts = *newStsWithRoles("cluster_manager", "cluster_manager")
+			sourceSts.Name = "test-cluster-data"
+			sourceSts.Spec.Replicas = ptr.To(int32(1))
+			sourceSts.Status.Replicas = 1
+
+			underTest = newScalerReconciler(mockClient, &spec)
+			underTest.apply(WithOSClientTransport(transport))
+		})
+
+		It("Should exclude cluster manager nodes from voting before removing them", func() {
+			coordination := responses.ClusterStateResponse{}
+			coordination.Metadata.ClusterCoordination.LastCommittedConfig = []string{"id-0"}
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/state/metadata`),
+				httpmock.NewJsonResponderOrPanic(200, coordination))
+			transport.RegisterRegexpResponder(http.MethodPost, regexp.MustCompile(`/_cluster/voting_config_exclusions`),
+				httpmock.NewStringResponder(200, "{}"))
+			mockClient.EXPECT().GetStatefulSet("test-cluster-data", "test-namespace").Return(sourceSts, nil)
+
+			result, err := underTest.reconcileNodePoolMigration(&spec.Spec.NodePools[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(nodePoolMigrationRequeueAfter))
+			Expect(transport.GetCallCountInfo()["POST =~/_cluster/voting_config_exclusions"]).To(Equal(1))
+		})
+
+		It("Should scale the last node down before deleting the statefulset", func() {
+			sourceSts.Spec.Template.Spec.Containers[0].Env[0].Value = "data"
+			mockClient.EXPECT().GetStatefulSet("test-cluster-data", "test-namespace").Return(sourceSts, nil)
+			mockClient.EXPECT().ReconcileResource(mock.Anything, reconciler.StatePresent).RunAndReturn(func(obj runtime.Object, _ reconciler.DesiredState) (*ctrl.Result, error) {
+				Expect(*obj.(*appsv1.StatefulSet).Spec.Replicas).To(Equal(int32(0)))
+				return &ctrl.Result{}, nil
+			}).Once()
+
+			result, err := underTest.reconcileNodePoolMigration(&spec.Spec.NodePools[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(nodePoolMigrationRequeueAfter))
+			Expect(helpers.IsNodePoolMigrationInProgress(spec.Status, "data")).To(BeTrue())
+		})
+
+		It("Should delete the statefulset once its pods are gone", func() {
+			sourceSts.Spec.Replicas = ptr.To(int32(0))
+			sourceSts.Status.Replicas = 0
+			mockClient.EXPECT().GetStatefulSet("test-cluster-data", "test-namespace").Return(sourceSts, nil)
+			mockClient.EXPECT().ReconcileResource(&sourceSts, reconciler.StateAbsent).Return(&ctrl.Result{}, nil).Once()
+			mockClient.On("UpdateOpenSearchClusterStatus", client.ObjectKeyFromObject(&spec), mock.AnythingOfType("func(*v1.OpenSearchCluster)")).Run(func(args mock.Arguments) {
+				updateFn := args.Get(1).(func(*opensearchv1.OpenSearchCluster))
+				updateFn(&spec)
+			}).Return(nil)
+
+			_, err := underTest.reconcileNodePoolMigration(&spec.Spec.NodePools[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(helpers.IsNodePoolMigrationInProgress(spec.Status, "data")).To(BeFalse())
+		})
+	})
+
+	Context("When the old statefulset is gone", func() {
+		It("Should complete the migration and switch the node pool to the shadow statefulset", func() {
+			spec := newMigrationTestCluster(helpers.NodePoolMigrationDeletingSource)
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.On("GetStatefulSet", "test-cluster-data", "test-namespace").Return(appsv1.StatefulSet{},
+				k8serrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "statefulsets"}, "test-cluster-data"))
+			mockClient.On("UpdateOpenSearchClusterStatus", client.ObjectKeyFromObject(&spec), mock.AnythingOfType("func(*v1.OpenSearchCluster)")).Run(func(args mock.Arguments) {
+				updateFn := args.Get(1).(func(*opensearchv1.OpenSearchCluster))
+				updateFn(&spec)
+			}).Return(nil)
+
+			underTest := &ScalerReconciler{
+				client:   mockClient,
+				ctx:      log.IntoContext(context.Background(), log.Log),
+				recorder: record.NewFakeRecorder(10),
+				instance: &spec,
+			}
+			_, err := underTest.reconcileNodePoolMigration(&spec.Spec.NodePools[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(helpers.IsNodePoolMigrationInProgress(spec.Status, "data")).To(BeFalse())
+			Expect(helpers.NodePoolStsName(&spec, "data")).To(Equal("test-cluster-data-abcde"))
+			mockClient.AssertExpectations(GinkgoT())
+		})
+	})
+})
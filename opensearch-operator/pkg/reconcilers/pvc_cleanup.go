@@ -0,0 +1,165 @@
+package reconcilers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// orphanedPVCAnnotation records when a PVC was first seen without a statefulset replica using it
+	orphanedPVCAnnotation         = "opensearch.org/orphaned-since"
+	defaultOrphanedPVCGracePeriod = 24 * time.Hour
+	dataPVCPrefix                 = "data-"
+)
+
+// cleanupOrphanedPVCs deletes data PVCs of the cluster that no statefulset replica uses anymore.
+// A PVC is only deleted after it has been unused for the configured grace period and once the
+// corresponding node has left the cluster and is no longer referenced in the allocation exclusions.
+func (r *ScalerReconciler) cleanupOrphanedPVCs() (*ctrl.Result, error) {
+	cleanup := r.instance.Spec.ConfMgmt.OrphanedPVCCleanup
+	if cleanup == nil || !cleanup.Enable {
+		return nil, nil
+	}
+	gracePeriod := defaultOrphanedPVCGracePeriod
+	if cleanup.GracePeriod != nil {
+		gracePeriod = cleanup.GracePeriod.Duration
+	}
+
+	lg := log.FromContext(r.ctx)
+	namespace := r.instance.Namespace
+	pvcList, err := r.client.ListPVCs(&client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{helpers.ClusterLabel: r.instance.Name}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	stsList, err := r.client.ListStatefulSets(client.InNamespace(namespace), client.MatchingLabels{helpers.ClusterLabel: r.instance.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	inUse := make(map[string]bool)
+	for _, sts := range stsList.Items {
+		for ordinal := int32(0); ordinal < ptr.Deref(sts.Spec.Replicas, 1); ordinal++ {
+			inUse[dataPVCPrefix+helpers.ReplicaHostName(sts, ordinal)] = true
+		}
+	}
+
+	var candidates []corev1.PersistentVolumeClaim
+	for _, pvc := range pvcList.Items {
+		// Only consider node data PVCs, the bootstrap PVC has no nodepool label
+		if _, ok := pvc.Labels[helpers.NodePoolLabel]; !ok || !strings.HasPrefix(pvc.Name, dataPVCPrefix) || pvc.DeletionTimestamp != nil {
+			continue
+		}
+		if inUse[pvc.Name] {
+			if _, marked := pvc.Annotations[orphanedPVCAnnotation]; marked {
+				delete(pvc.Annotations, orphanedPVCAnnotation)
+				if err := r.client.UpdatePVC(&pvc); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		candidates = append(candidates, pvc)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	referenced, err := r.referencedNodeNames()
+	if err != nil {
+		lg.Error(err, "Failed to determine cluster nodes, skipping orphaned PVC cleanup")
+		return nil, err
+	}
+
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	now := time.Now().UTC()
+	var requeueAfter time.Duration
+	for _, pvc := range candidates {
+		nodeName := strings.TrimPrefix(pvc.Name, dataPVCPrefix)
+		if referenced[nodeName] {
+			continue
+		}
+		if _, err := r.client.GetPod(nodeName, namespace); err == nil {
+			continue
+		} else if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		orphanedSince, err := time.Parse(time.RFC3339, pvc.Annotations[orphanedPVCAnnotation])
+		if err != nil {
+			if pvc.Annotations == nil {
+				pvc.Annotations = map[string]string{}
+			}
+			pvc.Annotations[orphanedPVCAnnotation] = now.Format(time.RFC3339)
+			if err := r.client.UpdatePVC(&pvc); err != nil {
+				return nil, err
+			}
+			lg.Info(fmt.Sprintf("PVC %s is no longer used, deleting it after %s", pvc.Name, gracePeriod))
+			requeueAfter = minRequeue(requeueAfter, gracePeriod)
+			continue
+		}
+
+		if remaining := gracePeriod - now.Sub(orphanedSince); remaining > 0 {
+			requeueAfter = minRequeue(requeueAfter, remaining)
+			continue
+		}
+
+		lg.Info(fmt.Sprintf("Deleting orphaned PVC %s", pvc.Name))
+		if err := r.client.DeletePVC(&pvc); err != nil && !k8serrors.IsNotFound(err) {
+			r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "PVC", "Failed to delete orphaned PVC %s/%s", namespace, pvc.Name)
+			return nil, err
+		}
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "PVC", "Deleted orphaned PVC %s/%s", namespace, pvc.Name)
+	}
+
+	if requeueAfter > 0 {
+		return &ctrl.Result{Requeue: true, RequeueAfter: requeueAfter}, nil
+	}
+	return nil, nil
+}
+
+// referencedNodeNames returns the names of all nodes that are part of the cluster or excluded from allocation
+func (r *ScalerReconciler) referencedNodeNames() (map[string]bool, error) {
+	clusterClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := clusterClient.CatNodes()
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := services.GetExcludedNodeNames(clusterClient)
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool, len(nodes)+len(excluded))
+	for _, node := range nodes {
+		referenced[node.Name] = true
+	}
+	for _, name := range excluded {
+		referenced[name] = true
+	}
+	return referenced, nil
+}
+
+func minRequeue(current, candidate time.Duration) time.Duration {
+	if current == 0 || candidate < current {
+		return candidate
+	}
+	return current
+}
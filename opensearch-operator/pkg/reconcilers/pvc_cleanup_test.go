@@ -0,0 +1,162 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Orphaned PVC cleanup", func() {
+	var (
+		transport  *httpmock.MockTransport
+		mockClient *k8s.MockK8sClient
+		cluster    *opensearchv1.OpenSearchCluster
+		underTest  *ScalerReconciler
+	)
+
+	dataPVC := func(name string, annotations map[string]string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "test-ns",
+				Annotations: annotations,
+				Labels: map[string]string{
+					helpers.ClusterLabel:  "test-cluster",
+					helpers.NodePoolLabel: "data",
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		mockClient = k8s.NewMockK8sClient(GinkgoT())
+		transport = httpmock.NewMockTransport()
+		transport.RegisterNoResponder(httpmock.NewNotFoundResponder(failMessage))
+		cluster = &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "test-ns"},
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{ServiceName: "test-cluster", HttpPort: 9200},
+				ConfMgmt: opensearchv1.ConfMgmt{
+					OrphanedPVCCleanup: &opensearchv1.OrphanedPVCCleanupConfig{
+						Enable:      true,
+						GracePeriod: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+				NodePools: []opensearchv1.NodePool{{Component: "data", Replicas: 2}},
+			},
+		}
+
+		clusterUrl := fmt.Sprintf("%s/", helpers.ClusterURL(cluster))
+		transport.RegisterResponder(http.MethodGet, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+		transport.RegisterResponder(http.MethodHead, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+		transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cat/nodes`),
+			httpmock.NewJsonResponderOrPanic(200, []responses.CatNodesResponse{{Name: "test-cluster-data-0"}, {Name: "test-cluster-data-1"}}))
+		transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/settings`),
+			httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{}))
+
+		mockClient.On("GetSecret", "test-cluster-admin-password", "test-ns").Return(corev1.Secret{
+			Data: map[string][]byte{"username": []byte("admin"), "password": []byte("admin")},
+		}, nil).Maybe()
+		mockClient.On("ListStatefulSets", mock.Anything, mock.Anything).Return(appsv1.StatefulSetList{
+			Items: []appsv1.StatefulSet{{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-data", Namespace: "test-ns"},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(2))},
+			}},
+		}, nil).Maybe()
+	})
+
+	JustBeforeEach(func() {
+		options := ReconcilerOptions{}
+		options.apply(WithOSClientTransport(transport))
+		underTest = &ScalerReconciler{
+			client:            mockClient,
+			ctx:               context.Background(),
+			recorder:          record.NewFakeRecorder(10),
+			instance:          cluster,
+			ReconcilerOptions: options,
+		}
+	})
+
+	It("should do nothing when disabled", func() {
+		cluster.Spec.ConfMgmt.OrphanedPVCCleanup = nil
+		result, err := underTest.cleanupOrphanedPVCs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("should mark an unused PVC before deleting it", func() {
+		mockClient.On("ListPVCs", mock.Anything).Return(corev1.PersistentVolumeClaimList{
+			Items: []corev1.PersistentVolumeClaim{dataPVC("data-test-cluster-data-0", nil), dataPVC("data-test-cluster-data-2", nil)},
+		}, nil)
+		mockClient.On("GetPod", "test-cluster-data-2", "test-ns").Return(corev1.Pod{}, NotFoundError())
+		var marked *corev1.PersistentVolumeClaim
+		mockClient.On("UpdatePVC", mock.Anything).Run(func(args mock.Arguments) {
+			marked = args.Get(0).(*corev1.PersistentVolumeClaim)
+		}).Return(nil)
+
+		result, err := underTest.cleanupOrphanedPVCs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+		Expect(marked.Name).To(Equal("data-test-cluster-data-2"))
+		Expect(marked.Annotations).To(HaveKey(orphanedPVCAnnotation))
+	})
+
+	It("should delete a PVC once the grace period has passed", func() {
+		orphanedSince := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+		mockClient.On("ListPVCs", mock.Anything).Return(corev1.PersistentVolumeClaimList{
+			Items: []corev1.PersistentVolumeClaim{dataPVC("data-test-cluster-data-2", map[string]string{orphanedPVCAnnotation: orphanedSince})},
+		}, nil)
+		mockClient.On("GetPod", "test-cluster-data-2", "test-ns").Return(corev1.Pod{}, NotFoundError())
+		mockClient.On("DeletePVC", mock.MatchedBy(func(pvc *corev1.PersistentVolumeClaim) bool {
+			return pvc.Name == "data-test-cluster-data-2"
+		})).Return(nil)
+
+		result, err := underTest.cleanupOrphanedPVCs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("should keep PVCs of nodes that are still part of the cluster", func() {
+		orphanedSince := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+		transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cat/nodes`),
+			httpmock.NewJsonResponderOrPanic(200, []responses.CatNodesResponse{{Name: "test-cluster-data-2"}}))
+		mockClient.On("ListPVCs", mock.Anything).Return(corev1.PersistentVolumeClaimList{
+			Items: []corev1.PersistentVolumeClaim{dataPVC("data-test-cluster-data-2", map[string]string{orphanedPVCAnnotation: orphanedSince})},
+		}, nil)
+
+		result, err := underTest.cleanupOrphanedPVCs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+		mockClient.AssertNotCalled(GinkgoT(), "DeletePVC", mock.Anything)
+	})
+
+	It("should unmark a PVC that is used again", func() {
+		mockClient.On("ListPVCs", mock.Anything).Return(corev1.PersistentVolumeClaimList{
+			Items: []corev1.PersistentVolumeClaim{dataPVC("data-test-cluster-data-1", map[string]string{orphanedPVCAnnotation: "2024-01-01T00:00:00Z"})},
+		}, nil)
+		mockClient.On("UpdatePVC", mock.MatchedBy(func(pvc *corev1.PersistentVolumeClaim) bool {
+			_, marked := pvc.Annotations[orphanedPVCAnnotation]
+			return pvc.Name == "data-test-cluster-data-1" && !marked
+		})).Return(nil)
+
+		result, err := underTest.cleanupOrphanedPVCs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+})
@@ -188,6 +188,30 @@ var _ = Describe("roles reconciler", func() {
 		})
 	})
 
+	When("cluster is paused", func() {
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(1)
+			cluster.Status.Phase = opensearchv1.PhaseRunning
+			cluster.Annotations = map[string]string{helpers.PausedAnnotation: "true"}
+			mockClient.EXPECT().GetOpenSearchCluster(mock.Anything, mock.Anything).Return(*cluster, nil)
+		})
+		It("should wait for the cluster to be resumed", func() {
+			go func() {
+				defer GinkgoRecover()
+				defer close(recorder.Events)
+				result, err := reconciler.Reconcile()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeTrue())
+			}()
+			var events []string
+			for msg := range recorder.Events {
+				events = append(events, msg)
+			}
+			Expect(len(events)).To(Equal(1))
+			Expect(events[0]).To(Equal(fmt.Sprintf("Normal %s waiting for opensearch cluster to be resumed", opensearchPending)))
+		})
+	})
+
 	Context("cluster is ready", func() {
 		extraContextCalls := 1
 		BeforeEach(func() {
@@ -0,0 +1,225 @@
+package reconcilers
+
+import (
+	"fmt"
+	"strings"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// roleTransitionPending returns true if the nodes of the node pool have to keep their current roles, because they
+// lose the data or cluster manager role and are not yet prepared for it. A new role transition is recorded in the
+// status, the nodes are then prepared by the ScalerReconciler.
+func (r *ClusterReconciler) roleTransitionPending(existing, sts *appsv1.StatefulSet, nodePool opensearchv1.NodePool) (bool, error) {
+	if !r.instance.Status.Initialized {
+		return false, nil
+	}
+	if transition, found := helpers.FindRoleTransition(r.instance.Status, nodePool.Component); found {
+		return transition.Status != helpers.RoleTransitionRestarting, nil
+	}
+	removed := helpers.RemovedRoles(helpers.StatefulSetRoles(existing), helpers.StatefulSetRoles(sts))
+	if len(removed) == 0 {
+		return false, nil
+	}
+
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	componentStatus := opensearchv1.ComponentStatus{
+		Component:   helpers.RoleTransitionComponent,
+		Status:      helpers.RoleTransitionDraining,
+		Description: nodePool.Component,
+		Conditions:  removed,
+	}
+	if !helpers.RoleTransitionRemovesData(componentStatus) {
+		componentStatus.Status = helpers.RoleTransitionExcludingVotes
+	}
+	if helpers.RoleTransitionRemovesManager(componentStatus) && !r.otherManagerNodesExist(nodePool.Component) {
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "RoleTransition", "Cannot remove the cluster manager role from nodePool %s as no other nodePool has cluster manager nodes", nodePool.Component)
+		return true, nil
+	}
+	if helpers.RoleTransitionRemovesData(componentStatus) && !r.otherDataNodesExist(nodePool.Component) {
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "RoleTransition", "Cannot remove the data role from nodePool %s as no other nodePool has data nodes", nodePool.Component)
+		return true, nil
+	}
+	err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.ComponentsStatus = append(instance.Status.ComponentsStatus, componentStatus)
+	})
+	if err != nil {
+		r.logger.Error(err, "Failed to update role transition status")
+		return true, err
+	}
+	r.logger.Info(fmt.Sprintf("Roles %s removed from nodePool %s, preparing the nodes before restarting them", strings.Join(removed, ","), nodePool.Component))
+	r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "RoleTransition", "Starting removal of roles %s from nodePool %s", strings.Join(removed, ","), nodePool.Component)
+	return true, nil
+}
+
+// otherManagerNodesExist returns true if a node pool other than the given one has cluster manager nodes
+func (r *ClusterReconciler) otherManagerNodesExist(component string) bool {
+	for _, nodePool := range r.instance.Spec.NodePools {
+		if nodePool.Component != component && helpers.HasManagerRole(&nodePool) && helpers.NodePoolReplicas(r.instance, &nodePool) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// otherDataNodesExist returns true if a node pool other than the given one has data nodes the shards can be moved to
+func (r *ClusterReconciler) otherDataNodesExist(component string) bool {
+	for _, nodePool := range r.instance.Spec.NodePools {
+		if nodePool.Component != component && helpers.HasDataRole(&nodePool) && helpers.NodePoolReplicas(r.instance, &nodePool) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// keepNodeRoles starts the pods of the desired StatefulSet with the roles of the existing one
+func keepNodeRoles(existing, sts *appsv1.StatefulSet) {
+	for _, env := range existing.Spec.Template.Spec.Containers[0].Env {
+		if env.Name != "node.roles" {
+			continue
+		}
+		for i := range sts.Spec.Template.Spec.Containers[0].Env {
+			if sts.Spec.Template.Spec.Containers[0].Env[i].Name == "node.roles" {
+				sts.Spec.Template.Spec.Containers[0].Env[i].Value = env.Value
+			}
+		}
+	}
+	if role, ok := existing.Spec.Template.Labels["opensearch.role"]; ok {
+		sts.Spec.Template.Labels["opensearch.role"] = role
+	} else {
+		delete(sts.Spec.Template.Labels, "opensearch.role")
+	}
+}
+
+// reconcileRoleTransition prepares the nodes of a node pool for losing roles: drain them if they lose the data role,
+// exclude them from the voting configuration if they lose the cluster manager role, then wait for the restart with
+// the new roles and remove the exclusions.
+func (r *ScalerReconciler) reconcileRoleTransition(nodePool *opensearchv1.NodePool) (*ctrl.Result, error) {
+	lg := log.FromContext(r.ctx)
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	transition, _ := helpers.FindRoleTransition(r.instance.Status, nodePool.Component)
+
+	sts, err := r.client.GetStatefulSet(builders.StsName(r.instance, nodePool), r.instance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusterClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+	if err != nil {
+		lg.Error(err, "failed to create os client")
+		return nil, err
+	}
+	var nodeNames []string
+	for ordinal := int32(0); ordinal < ptr.Deref(sts.Spec.Replicas, 1); ordinal++ {
+		nodeNames = append(nodeNames, helpers.ReplicaHostName(sts, ordinal))
+	}
+
+	switch transition.Status {
+	case helpers.RoleTransitionDraining:
+		tracker := newDrainTracker(r.client, clusterClient, r.instance, r.recorder, lg)
+		drained := true
+		for _, nodeName := range nodeNames {
+			if tracker.aborted(nodeName) {
+				drained = false
+				continue
+			}
+			// Exclusions are re-applied on every pass as other reconcilers may clear them (e.g. after a restart)
+			if _, err := services.AppendExcludeNodeHost(clusterClient, lg, nodeName); err != nil {
+				lg.Error(err, fmt.Sprintf("failed to exclude node %s", nodeName))
+				return nil, err
+			}
+			nodeNotEmpty, err := services.HasShardsOnNode(clusterClient, nodeName)
+			if err != nil {
+				lg.Error(err, "failed to check shards on node")
+				return nil, err
+			}
+			nodeDrained, err := tracker.track(nodeName, !nodeNotEmpty)
+			if err != nil {
+				return nil, err
+			}
+			if !nodeDrained {
+				drained = false
+			}
+		}
+		if !drained {
+			lg.Info(fmt.Sprintf("Group: %s, Waiting for nodes to drain before removing the data role", nodePool.Component))
+			return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+		}
+		next := helpers.RoleTransitionRestarting
+		if helpers.RoleTransitionRemovesManager(transition) {
+			next = helpers.RoleTransitionExcludingVotes
+		}
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "RoleTransition", "Nodes of nodePool %s are drained", nodePool.Component)
+		return &ctrl.Result{Requeue: true}, r.updateRoleTransitionStatus(transition, next)
+
+	case helpers.RoleTransitionExcludingVotes:
+		if err := services.AppendVotingConfigExclusions(clusterClient, lg, nodeNames); err != nil {
+			lg.Error(err, "failed to exclude nodes from the voting configuration")
+			return nil, err
+		}
+		applied, err := services.VotingConfigExclusionsApplied(clusterClient, nodeNames)
+		if err != nil {
+			return nil, err
+		}
+		if !applied {
+			lg.Info(fmt.Sprintf("Group: %s, Waiting for the nodes to be removed from the voting configuration", nodePool.Component))
+			return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+		}
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "RoleTransition", "Nodes of nodePool %s are excluded from the voting configuration", nodePool.Component)
+		return &ctrl.Result{Requeue: true}, r.updateRoleTransitionStatus(transition, helpers.RoleTransitionRestarting)
+
+	case helpers.RoleTransitionRestarting:
+		replicas := ptr.Deref(sts.Spec.Replicas, 1)
+		if !helpers.RoleTransitionApplied(transition, helpers.StatefulSetRoles(&sts)) ||
+			sts.Status.ObservedGeneration < sts.Generation ||
+			sts.Status.UpdatedReplicas != replicas ||
+			sts.Status.ReadyReplicas != replicas {
+			lg.Info(fmt.Sprintf("Group: %s, Waiting for the nodes to restart with the new roles", nodePool.Component))
+			return &ctrl.Result{Requeue: true, RequeueAfter: nodePoolMigrationRequeueAfter}, nil
+		}
+		if helpers.RoleTransitionRemovesData(transition) {
+			for _, nodeName := range nodeNames {
+				if _, err := services.RemoveExcludeNodeHost(clusterClient, lg, nodeName); err != nil {
+					lg.Error(err, fmt.Sprintf("failed to remove node exclusion for %s", nodeName))
+					return nil, err
+				}
+			}
+		}
+		if helpers.RoleTransitionRemovesManager(transition) {
+			if err := services.ClearVotingConfigExclusions(clusterClient, lg); err != nil {
+				lg.Error(err, "failed to clear the voting configuration exclusions")
+				return nil, err
+			}
+		}
+		err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+			instance.Status.ComponentsStatus = helpers.RemoveIt(transition, instance.Status.ComponentsStatus)
+		})
+		if err != nil {
+			lg.Error(err, "failed to update role transition status")
+			return &ctrl.Result{Requeue: true}, err
+		}
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "RoleTransition", "Finished removal of roles %s from nodePool %s", strings.Join(transition.Conditions, ","), nodePool.Component)
+		return &ctrl.Result{Requeue: true}, nil
+	}
+	return &ctrl.Result{}, nil
+}
+
+func (r *ScalerReconciler) updateRoleTransitionStatus(currentStatus opensearchv1.ComponentStatus, phase string) error {
+	componentStatus := currentStatus
+	componentStatus.Status = phase
+	err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.ComponentsStatus = helpers.Replace(currentStatus, componentStatus, instance.Status.ComponentsStatus)
+	})
+	if err != nil {
+		log.FromContext(r.ctx).Error(err, "failed to update role transition status")
+	}
+	return err
+}
@@ -0,0 +1,235 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/go-logr/logr"
+	"github.com/jarcoal/httpmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+)
+
+func newStsWithRoles(roles string, role string) *appsv1.StatefulSet {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "roles-data", Namespace: "roles"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(2)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Env: []corev1.EnvVar{{Name: "node.roles", Value: roles}},
+				}}},
+			},
+		},
+	}
+	if role != "" {
+		sts.Spec.Template.Labels["opensearch.role"] = role
+	}
+	return sts
+}
+
+var _ = Describe("Role transitions", func() {
+	var (
+		mockClient *k8s.MockK8sClient
+		recorder   *record.FakeRecorder
+		cluster    *opensearchv1.OpenSearchCluster
+	)
+
+	withTransition := func(phase string, removed ...string) {
+		cluster.Status.ComponentsStatus = []opensearchv1.ComponentStatus{{
+			Component:   helpers.RoleTransitionComponent,
+			Status:      phase,
+			Description: "data",
+			Conditions:  removed,
+		}}
+	}
+	BeforeEach(func() {
+		mockClient = k8s.NewMockK8sClient(GinkgoT())
+		recorder = record.NewFakeRecorder(10)
+		cluster = &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "roles", Namespace: "roles"},
+			Spec: opensearchv1.ClusterSpec{
+				General: opensearchv1.GeneralConfig{ServiceName: "roles", HttpPort: 9200},
+				NodePools: []opensearchv1.NodePool{
+					{Component: "masters", Replicas: 3, Roles: []string{"cluster_manager"}},
+					{Component: "data", Replicas: 2, Roles: []string{"ingest"}},
+				},
+			},
+			Status: opensearchv1.ClusterStatus{Initialized: true},
+		}
+	})
+
+	Context("When the roles of a node pool change", func() {
+		var underTest *ClusterReconciler
+
+		BeforeEach(func() {
+			underTest = &ClusterReconciler{
+				client:   mockClient,
+				recorder: recorder,
+				instance: cluster,
+				logger:   logr.Discard(),
+			}
+		})
+
+		It("should drain the nodes before removing the data role", func() {
+			cluster.Spec.NodePools = append(cluster.Spec.NodePools, opensearchv1.NodePool{Component: "hot", Replicas: 2, Roles: []string{"data"}})
+			expectStatusUpdate(mockClient, cluster, cluster)
+			pending, err := underTest.roleTransitionPending(newStsWithRoles("data,cluster_manager", "cluster_manager"), newStsWithRoles("ingest", ""), cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeTrue())
+			transition, found := helpers.FindRoleTransition(cluster.Status, "data")
+			Expect(found).To(BeTrue())
+			Expect(transition.Status).To(Equal(helpers.RoleTransitionDraining))
+			Expect(transition.Conditions).To(Equal([]string{"data", "cluster_manager"}))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Starting removal of roles data,cluster_manager")))
+		})
+
+		It("should only exclude the nodes from voting when removing the cluster manager role", func() {
+			expectStatusUpdate(mockClient, cluster, cluster)
+			pending, err := underTest.roleTransitionPending(newStsWithRoles("master,ingest", "master"), newStsWithRoles("ingest", ""), cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeTrue())
+			transition, _ := helpers.FindRoleTransition(cluster.Status, "data")
+			Expect(transition.Status).To(Equal(helpers.RoleTransitionExcludingVotes))
+		})
+
+		It("should not remove the cluster manager role from the last cluster manager nodes", func() {
+			cluster.Spec.NodePools[0].Replicas = 0
+			pending, err := underTest.roleTransitionPending(newStsWithRoles("cluster_manager,data", "cluster_manager"), newStsWithRoles("data", ""), cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeTrue())
+			Expect(cluster.Status.ComponentsStatus).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring("Cannot remove the cluster manager role")))
+		})
+
+		It("should not remove the data role from the last data nodes", func() {
+			pending, err := underTest.roleTransitionPending(newStsWithRoles("data,ingest", ""), newStsWithRoles("ingest", ""), cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeTrue())
+			Expect(cluster.Status.ComponentsStatus).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring("Cannot remove the data role")))
+		})
+
+		It("should apply added roles and renamed cluster manager roles right away", func() {
+			pending, err := underTest.roleTransitionPending(newStsWithRoles("master", "master"), newStsWithRoles("cluster_manager,data", "cluster_manager"), cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeFalse())
+		})
+
+		It("should keep the roles until the nodes are restarted", func() {
+			withTransition(helpers.RoleTransitionDraining, "data")
+			pending, err := underTest.roleTransitionPending(newStsWithRoles("data", ""), newStsWithRoles("ingest", ""), cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeTrue())
+
+			withTransition(helpers.RoleTransitionRestarting, "data")
+			pending, err = underTest.roleTransitionPending(newStsWithRoles("data", ""), newStsWithRoles("ingest", ""), cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeFalse())
+		})
+
+		It("should start the pods with the existing roles", func() {
+			desired := newStsWithRoles("ingest", "")
+			keepNodeRoles(newStsWithRoles("cluster_manager,data", "cluster_manager"), desired)
+			Expect(helpers.StatefulSetRoles(desired)).To(Equal([]string{"cluster_manager", "data"}))
+			Expect(desired.Spec.Template.Labels).To(HaveKeyWithValue("opensearch.role", "cluster_manager"))
+		})
+	})
+
+	Context("When preparing the nodes", func() {
+		var (
+			transport *httpmock.MockTransport
+			underTest *ScalerReconciler
+			sts       *appsv1.StatefulSet
+		)
+
+		BeforeEach(func() {
+			transport = httpmock.NewMockTransport()
+			transport.RegisterNoResponder(httpmock.NewNotFoundResponder(failMessage))
+			clusterUrl := fmt.Sprintf("%s/", helpers.ClusterURL(cluster))
+			transport.RegisterResponder(http.MethodGet, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+			transport.RegisterResponder(http.MethodHead, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/settings`),
+				httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{Transient: map[string]interface{}{
+					"cluster": map[string]interface{}{"routing": map[string]interface{}{"allocation": map[string]interface{}{"exclude": map[string]interface{}{"_name": "roles-data-0,roles-data-1"}}}},
+				}}))
+			transport.RegisterRegexpResponder(http.MethodPut, regexp.MustCompile(`/_cluster/settings`),
+				httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{}))
+
+			mockClient.On("GetSecret", "roles-admin-password", "roles").Return(corev1.Secret{
+				Data: map[string][]byte{"username": []byte("admin"), "password": []byte("admin")},
+			}, nil).Maybe()
+			sts = newStsWithRoles("ingest", "")
+			sts.Generation = 2
+			sts.Status = appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 2, ReadyReplicas: 2}
+			mockClient.EXPECT().GetStatefulSet("roles-data", "roles").RunAndReturn(func(string, string) (appsv1.StatefulSet, error) {
+				return *sts, nil
+			})
+
+			options := ReconcilerOptions{}
+			options.apply(WithOSClientTransport(transport))
+			underTest = &ScalerReconciler{
+				client:            mockClient,
+				ctx:               context.Background(),
+				recorder:          recorder,
+				instance:          cluster,
+				ReconcilerOptions: options,
+			}
+		})
+
+		It("should wait until the nodes are removed from the voting configuration", func() {
+			withTransition(helpers.RoleTransitionExcludingVotes, "cluster_manager")
+			coordination := responses.ClusterStateResponse{}
+			coordination.Metadata.ClusterCoordination.LastCommittedConfig = []string{"id-0", "id-1"}
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/state/metadata`),
+				httpmock.NewJsonResponderOrPanic(200, coordination))
+			transport.RegisterRegexpResponder(http.MethodPost, regexp.MustCompile(`/_cluster/voting_config_exclusions`),
+				httpmock.NewStringResponder(200, ""))
+
+			result, err := underTest.reconcileRoleTransition(&cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(nodePoolMigrationRequeueAfter))
+			Expect(transport.GetCallCountInfo()["POST =~/_cluster/voting_config_exclusions"]).To(Equal(1))
+		})
+
+		It("should wait for the nodes to restart with the new roles", func() {
+			withTransition(helpers.RoleTransitionRestarting, "data")
+			sts.Status.UpdatedReplicas = 1
+
+			result, err := underTest.reconcileRoleTransition(&cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(nodePoolMigrationRequeueAfter))
+			Expect(cluster.Status.ComponentsStatus).To(HaveLen(1))
+		})
+
+		It("should remove the exclusions once the nodes run with the new roles", func() {
+			withTransition(helpers.RoleTransitionRestarting, "data", "cluster_manager")
+			coordination := responses.ClusterStateResponse{}
+			coordination.Metadata.ClusterCoordination.VotingConfigExclusions = []responses.VotingConfigExclusion{{NodeId: "id-0", NodeName: "roles-data-0"}}
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/state/metadata`),
+				httpmock.NewJsonResponderOrPanic(200, coordination))
+			transport.RegisterRegexpResponder(http.MethodDelete, regexp.MustCompile(`/_cluster/voting_config_exclusions`),
+				httpmock.NewStringResponder(200, ""))
+			expectStatusUpdate(mockClient, cluster, cluster)
+
+			_, err := underTest.reconcileRoleTransition(&cluster.Spec.NodePools[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cluster.Status.ComponentsStatus).To(BeEmpty())
+			Expect(transport.GetCallCountInfo()["PUT =~/_cluster/settings"]).To(Equal(2))
+			Expect(transport.GetCallCountInfo()["DELETE =~/_cluster/voting_config_exclusions"]).To(Equal(1))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Finished removal of roles data,cluster_manager")))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package reconcilers
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -16,9 +17,11 @@ import (
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -142,6 +145,13 @@ func (r *RollingRestartReconciler) Reconcile() (ctrl.Result, error) {
 			if err = r.updateStatus(statusFinished); err != nil {
 				return ctrl.Result{Requeue: true}, err
 			}
+			err = r.updateProgress(func(progress *opensearchv1.RollingRestartStatus) {
+				progress.PendingPods = 0
+				progress.CompletedAt = &metav1.Time{Time: time.Now()}
+			})
+			if err != nil {
+				return ctrl.Result{Requeue: true}, err
+			}
 		}
 		r.logger.V(1).Info("No pods pending restart")
 		return ctrl.Result{}, nil
@@ -155,6 +165,26 @@ func (r *RollingRestartReconciler) Reconcile() (ctrl.Result, error) {
 		}, nil
 	}
 
+	if status == nil || status.Status != statusInProgress {
+		open, err := maintenanceWindowOpen(r.client, r.instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		// The restarts of a running CA rotation complete the rotation that was started within a window
+		if !open && !caRotationInProgress(r.instance) {
+			r.logger.Info("Waiting for the next maintenance window to start the rolling restart")
+			return ctrl.Result{}, nil
+		}
+		err = r.updateProgress(func(progress *opensearchv1.RollingRestartStatus) {
+			*progress = opensearchv1.RollingRestartStatus{
+				Request:   r.instance.Annotations[helpers.RestartAtAnnotation],
+				StartedAt: &metav1.Time{Time: time.Now()},
+			}
+		})
+		if err != nil {
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
 	if err := r.updateStatus(statusInProgress); err != nil {
 		return ctrl.Result{Requeue: true}, err
 	}
@@ -173,7 +203,7 @@ func (r *RollingRestartReconciler) Reconcile() (ctrl.Result, error) {
 }
 
 // globalCandidateRollingRestart aggregates candidates across all StatefulSets,
-// orders them and restarts at most one pod per reconciliation.
+// orders them and restarts a batch of pods of one nodepool or zone per reconciliation.
 func (r *RollingRestartReconciler) globalCandidateRollingRestart() (ctrl.Result, error) {
 	r.logger.Info("Starting global candidate rolling restart")
 
@@ -209,32 +239,34 @@ func (r *RollingRestartReconciler) globalCandidateRollingRestart() (ctrl.Result,
 			continue
 		}
 
-		pod, err := helpers.GetPodWithOlderRevision(r.client, &sts)
+		pods, err := helpers.GetPodsWithOlderRevision(r.client, &sts)
 		if err != nil {
-			r.logger.Error(err, "Failed to get pod with older revision", "nodePool", np.Component)
+			r.logger.Error(err, "Failed to get pods with older revision", "nodePool", np.Component)
 			return ctrl.Result{}, err
 		}
-		if pod == nil {
+		if len(pods) == 0 {
 			r.logger.V(1).Info("No pod with older revision found", "nodePool", np.Component)
 			continue
 		}
 
-		ord := parseOrdinalFromName(pod.Name)
 		isMaster := helpers.HasManagerRole(&np)
-		r.logger.Info("Found candidate pod",
-			"pod", pod.Name,
-			"nodePool", np.Component,
-			"isMaster", isMaster,
-			"ordinal", ord)
-
-		candidates = append(candidates, candidate{
-			podName:  pod.Name,
-			podNS:    pod.Namespace,
-			sts:      sts,
-			nodePool: np,
-			isMaster: isMaster,
-			ordinal:  ord,
-		})
+		for _, pod := range pods {
+			ord := parseOrdinalFromName(pod.Name)
+			r.logger.Info("Found candidate pod",
+				"pod", pod.Name,
+				"nodePool", np.Component,
+				"isMaster", isMaster,
+				"ordinal", ord)
+
+			candidates = append(candidates, candidate{
+				podName:  pod.Name,
+				podNS:    pod.Namespace,
+				sts:      sts,
+				nodePool: np,
+				isMaster: isMaster,
+				ordinal:  ord,
+			})
+		}
 	}
 
 	r.logger.Info("Found candidates for rolling restart", "count", len(candidates))
@@ -263,61 +295,170 @@ func (r *RollingRestartReconciler) globalCandidateRollingRestart() (ctrl.Result,
 		return names
 	}())
 
-	// Enforce master quorum: if next is master, ensure safe to disrupt
-	next := candidates[0]
-	r.logger.Info("Selected candidate for restart",
-		"pod", next.podName,
-		"nodePool", next.nodePool.Component,
-		"isMaster", next.isMaster,
-		"ordinal", next.ordinal)
-
-	if next.isMaster {
+	// Enforce master quorum: masters are only restarted while the remaining masters keep the quorum
+	var masterBudget int32
+	if lo.ContainsBy(candidates, func(c candidate) bool { return c.isMaster }) {
 		totalMasters, readyMasters, err := r.countMasters()
 		if err != nil {
 			r.logger.Error(err, "Failed to count masters")
 			return ctrl.Result{}, err
 		}
 		minRequired := (totalMasters + 1) / 2
+		masterBudget = readyMasters - minRequired
 		r.logger.Info("Checking master quorum",
 			"totalMasters", totalMasters,
 			"readyMasters", readyMasters,
 			"minRequired", minRequired)
+	}
 
-		if readyMasters <= minRequired {
-			r.logger.Info("Master quorum unsafe, looking for non-master candidate")
-			// Try to pick the first non-master candidate instead, if any
-			for _, c := range candidates {
-				if !c.isMaster {
-					next = c
-					r.logger.Info("Switched to non-master candidate",
-						"pod", next.podName,
-						"nodePool", next.nodePool.Component)
-					break
-				}
-			}
-			// If still master and unsafe, requeue and wait
-			if next.isMaster && readyMasters <= minRequired {
-				r.logger.Info("No safe non-master candidates, requeuing to wait for quorum")
-				return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
-			}
-		} else {
-			r.logger.Info("Master quorum is safe, proceeding with restart")
-		}
+	batch, err := r.selectRestartBatch(candidates, masterBudget)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
+	if len(batch) == 0 {
+		r.logger.Info("No safe non-master candidates, requeuing to wait for quorum")
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+	}
+	r.logger.Info("Selected candidates for restart", "pods", lo.Map(batch, func(c candidate, _ int) string { return c.podName }))
 
-	// Restart exactly this candidate pod
-	res, err := r.restartSpecificPod(next)
+	// Restart exactly the selected candidate pods
+	res, err := r.restartPods(batch)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	if res.Requeue {
-		// restartSpecificPod needs to wait (cluster not ready, pod not ready, etc.)
+		// restartPods needs to wait (cluster not ready, pod not ready, etc.)
 		return res, nil
 	}
-	// Pod deleted successfully, continue to next reconciliation to check for more candidates
+	err = r.updateProgress(func(progress *opensearchv1.RollingRestartStatus) {
+		progress.RestartedPods += int32(len(batch))
+		progress.PendingPods = int32(len(candidates) - len(batch))
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// Pods deleted successfully, continue to next reconciliation to check for more candidates
 	return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 }
 
+// restartBatch selects the sorted candidates that are restarted at the same time. If zones are given all candidates
+// in the zone of the first candidate are restarted, otherwise the candidates of one nodepool up to its
+// restartMaxUnavailable. Masters are only selected as long as the master budget allows it.
+func restartBatch(candidates []candidate, masterBudget int32, zones map[string]string) []candidate {
+	var batch []candidate
+	selectable := func(c candidate) bool {
+		return !c.isMaster || masterBudget > int32(lo.CountBy(batch, func(b candidate) bool { return b.isMaster }))
+	}
+
+	first, found := lo.Find(candidates, selectable)
+	if !found {
+		return nil
+	}
+	if zone, ok := zones[first.podName]; ok && zone != "" {
+		for _, c := range candidates {
+			if zones[c.podName] == zone && selectable(c) {
+				batch = append(batch, c)
+			}
+		}
+		return batch
+	}
+
+	maxUnavailable := restartMaxUnavailable(&first.nodePool, ptr.Deref(first.sts.Spec.Replicas, 1))
+	for _, c := range candidates {
+		if len(batch) >= maxUnavailable {
+			break
+		}
+		if c.sts.Name == first.sts.Name && selectable(c) {
+			batch = append(batch, c)
+		}
+	}
+	return batch
+}
+
+// selectRestartBatch selects the pods restarted next. A zone is only restarted at once if no shard has all its started
+// copies in the zone, otherwise the pods are restarted by nodepool.
+func (r *RollingRestartReconciler) selectRestartBatch(candidates []candidate, masterBudget int32) ([]candidate, error) {
+	zones, err := r.restartZones()
+	if err != nil {
+		return nil, err
+	}
+	batch := restartBatch(candidates, masterBudget, zones)
+	if zones == nil || len(batch) <= 1 {
+		return batch, nil
+	}
+	holdAllCopies, err := services.NodesHoldAllShardCopies(r.osClient, lo.Map(batch, func(c candidate, _ int) string { return c.podName }))
+	if err != nil {
+		return nil, err
+	}
+	if holdAllCopies {
+		r.logger.Info("The pods of the zone hold all copies of a shard, restarting pods by nodepool instead of by zone")
+		return restartBatch(candidates, masterBudget, nil), nil
+	}
+	return batch, nil
+}
+
+// restartMaxUnavailable returns the number of pods of the nodepool that can be restarted at the same time
+func restartMaxUnavailable(nodePool *opensearchv1.NodePool, replicas int32) int {
+	if nodePool.RestartMaxUnavailable == nil {
+		return 1
+	}
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(nodePool.RestartMaxUnavailable, int(replicas), false)
+	if err != nil {
+		return 1
+	}
+	return max(maxUnavailable, 1)
+}
+
+func (r *RollingRestartReconciler) zoneAttribute() string {
+	if r.instance.Spec.General.RollingRestart == nil {
+		return ""
+	}
+	return r.instance.Spec.General.RollingRestart.ZoneAttribute
+}
+
+// restartZones returns the value of the zone attribute of every node, or nil if the pods cannot be restarted zone by
+// zone. Restarting a zone is only safe if the cluster is green, every index has a replica and the zone attribute is an
+// allocation awareness attribute, so the replicas are allocated in another zone.
+func (r *RollingRestartReconciler) restartZones() (map[string]string, error) {
+	zoneAttribute := r.zoneAttribute()
+	if zoneAttribute == "" {
+		return nil, nil
+	}
+	health, err := r.osClient.GetHealth()
+	if err != nil {
+		return nil, err
+	}
+	if health.Status != "green" {
+		r.logger.Info("Cluster is not green, restarting pods by nodepool instead of by zone")
+		return nil, nil
+	}
+	noReplicas, err := services.HasIndicesWithNoReplica(r.osClient)
+	if err != nil {
+		return nil, err
+	}
+	if noReplicas {
+		r.logger.Info("Indices without replicas found, restarting pods by nodepool instead of by zone")
+		return nil, nil
+	}
+	awarenessAttributes, err := services.AllocationAwarenessAttributes(r.osClient)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(awarenessAttributes, zoneAttribute) {
+		r.logger.Info("Zone attribute is not an allocation awareness attribute, restarting pods by nodepool instead of by zone", "zoneAttribute", zoneAttribute)
+		return nil, nil
+	}
+	stats, err := r.osClient.NodesStats()
+	if err != nil {
+		return nil, err
+	}
+	zones := make(map[string]string, len(stats.Nodes))
+	for _, node := range stats.Nodes {
+		zones[node.Name] = node.Attributes[zoneAttribute]
+	}
+	return zones, nil
+}
+
 // cleanStaleExclusionList delegates to the shared CleanStaleExclusionList.
 func (r *RollingRestartReconciler) cleanStaleExclusionList() (ctrl.Result, error) {
 	return util.CleanStaleExclusionList(r.client, r.instance, r.osClient, r.logger)
@@ -358,49 +499,74 @@ func (r *RollingRestartReconciler) countMasters() (int32, int32, error) {
 	return total, ready, nil
 }
 
-// restartSpecificPod performs the prechecks and deletes a specific pod
-func (r *RollingRestartReconciler) restartSpecificPod(cand interface{}) (ctrl.Result, error) {
-	c := cand.(candidate)
-
+// restartPods performs the prechecks and deletes the given pods
+func (r *RollingRestartReconciler) restartPods(batch []candidate) (ctrl.Result, error) {
 	dataCount := util.DataNodesCount(r.client, r.instance)
 	if dataCount == 2 && r.instance.Spec.General.DrainDataNodes {
 		r.logger.Info("Only 2 data nodes and drain is set, some shards may not drain")
 	}
 
-	ready, message, err := services.CheckClusterStatusForRestart(r.osClient, r.instance.Spec.General.DrainDataNodes)
+	ready, message, err := services.CheckClusterStatusForRestart(r.osClient, r.instance.Spec.General.DrainDataNodes, r.allowYellow(),
+		lo.Map(batch, func(c candidate, _ int) string { return c.podName }))
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	if !ready {
-		r.logger.Info(fmt.Sprintf("Couldn't proceed with rolling restart for Pod %s because %s", c.podName, message))
+		r.logger.Info(fmt.Sprintf("Couldn't proceed with rolling restart for Pod %s because %s", batch[0].podName, message))
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 	}
 
-	r.logger.Info(fmt.Sprintf("Preparing to restart pod %s", c.podName))
-	ready, err = services.PreparePodForDelete(r.osClient, r.logger, c.podName, r.instance.Spec.General.DrainDataNodes, dataCount)
-	if err != nil {
-		return ctrl.Result{}, err
+	tracker := newDrainTracker(r.client, r.osClient, r.instance, r.recorder, r.logger)
+	allReady := true
+	for _, c := range batch {
+		if r.instance.Spec.General.DrainDataNodes && tracker.aborted(c.podName) {
+			r.logger.Info(fmt.Sprintf("Drain of pod %s was aborted, waiting to retry", c.podName))
+			allReady = false
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Preparing to restart pod %s", c.podName))
+		ready, err = services.PreparePodForDelete(r.osClient, r.logger, c.podName, r.instance.Spec.General.DrainDataNodes, dataCount)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if r.instance.Spec.General.DrainDataNodes {
+			ready, err = tracker.track(c.podName, ready)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		allReady = allReady && ready
 	}
-	if !ready {
+	if !allReady {
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 	}
 
-	if err := r.client.DeletePod(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: c.podName, Namespace: c.podNS}}); err != nil {
-		return ctrl.Result{}, err
+	for _, c := range batch {
+		if err := r.client.DeletePod(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: c.podName, Namespace: c.podNS}}); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
 	if r.instance.Spec.General.DrainDataNodes {
-		ok, err := services.RemoveExcludeNodeHost(r.osClient, r.logger, c.podName)
-		if err != nil || !ok {
-			// If we fail to clean up the exclude list, log and requeue so we don't
-			// leave nodes permanently excluded and block subsequent restarts.
-			r.logger.Error(err, "Failed to remove allocation exclusion, will retry", "pod", c.podName)
-			return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		for _, c := range batch {
+			ok, err := services.RemoveExcludeNodeHost(r.osClient, r.logger, c.podName)
+			if err != nil || !ok {
+				// If we fail to clean up the exclude list, log and requeue so we don't
+				// leave nodes permanently excluded and block subsequent restarts.
+				r.logger.Error(err, "Failed to remove allocation exclusion, will retry", "pod", c.podName)
+				return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+			}
 		}
 	}
 	return ctrl.Result{}, nil
 }
 
+// allowYellow returns true if pods are also restarted while the cluster is yellow
+func (r *RollingRestartReconciler) allowYellow() bool {
+	config := r.instance.Spec.General.RollingRestart
+	return config != nil && config.HealthPrecondition == opensearchv1.RollingRestartHealthYellow
+}
+
 func (r *RollingRestartReconciler) updateStatus(status string) error {
 	return UpdateComponentStatus(r.client, r.instance, &opensearchv1.ComponentStatus{
 		Component:   componentName,
@@ -409,6 +575,20 @@ func (r *RollingRestartReconciler) updateStatus(status string) error {
 	})
 }
 
+func caRotationInProgress(instance *opensearchv1.OpenSearchCluster) bool {
+	return instance.Status.CaRotation != nil && instance.Status.CaRotation.Phase != ""
+}
+
+// updateProgress records the progress of the rolling restart in the status of the cluster
+func (r *RollingRestartReconciler) updateProgress(update func(progress *opensearchv1.RollingRestartStatus)) error {
+	progress := lo.FromPtr(r.instance.Status.RollingRestart)
+	update(&progress)
+	r.instance.Status.RollingRestart = &progress
+	return r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		instance.Status.RollingRestart = &progress
+	})
+}
+
 func (r *RollingRestartReconciler) findStatus() *opensearchv1.ComponentStatus {
 	comp := r.instance.Status.ComponentsStatus
 	found, ok := helpers.FindFirstPartial(comp, opensearchv1.ComponentStatus{
@@ -1,10 +1,23 @@
 package reconcilers
 
 import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/go-logr/logr"
+	"github.com/jarcoal/httpmock"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("RollingRestart Reconciler", func() {
@@ -128,4 +141,148 @@ var _ = Describe("RollingRestart Reconciler", func() {
 			})
 		})
 	})
+
+	Describe("restartBatch", func() {
+		newCandidates := func(nodePool opensearchv1.NodePool, isMaster bool, ordinals ...int) []candidate {
+			sts := appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-" + nodePool.Component},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(nodePool.Replicas)},
+			}
+			var candidates []candidate
+			for _, ordinal := range ordinals {
+				candidates = append(candidates, candidate{
+					podName:  fmt.Sprintf("cluster-%s-%d", nodePool.Component, ordinal),
+					sts:      sts,
+					nodePool: nodePool,
+					isMaster: isMaster,
+					ordinal:  ordinal,
+				})
+			}
+			return candidates
+		}
+		podNames := func(batch []candidate) []string {
+			var names []string
+			for _, c := range batch {
+				names = append(names, c.podName)
+			}
+			return names
+		}
+
+		It("should restart one pod at a time by default", func() {
+			candidates := newCandidates(opensearchv1.NodePool{Component: "data", Replicas: 6}, false, 5, 4, 3)
+			Expect(podNames(restartBatch(candidates, 0, nil))).To(Equal([]string{"cluster-data-5"}))
+		})
+
+		It("should restart up to maxUnavailable pods of one nodepool", func() {
+			data := opensearchv1.NodePool{Component: "data", Replicas: 6, RestartMaxUnavailable: ptr.To(intstr.FromString("50%"))}
+			candidates := append(newCandidates(data, false, 5, 4, 3, 2), newCandidates(opensearchv1.NodePool{Component: "ingest", Replicas: 2}, false, 1)...)
+			Expect(podNames(restartBatch(candidates, 0, nil))).To(Equal([]string{"cluster-data-5", "cluster-data-4", "cluster-data-3"}))
+		})
+
+		It("should only restart masters while the quorum is kept", func() {
+			masters := opensearchv1.NodePool{Component: "masters", Replicas: 5, RestartMaxUnavailable: ptr.To(intstr.FromInt32(3))}
+			candidates := newCandidates(masters, true, 4, 3, 2)
+			Expect(podNames(restartBatch(candidates, 2, nil))).To(Equal([]string{"cluster-masters-4", "cluster-masters-3"}))
+			Expect(restartBatch(candidates, 0, nil)).To(BeEmpty())
+		})
+
+		It("should restart all candidates of a zone at once", func() {
+			data := opensearchv1.NodePool{Component: "data", Replicas: 4}
+			candidates := append(newCandidates(data, false, 3, 2, 1, 0), newCandidates(opensearchv1.NodePool{Component: "masters", Replicas: 3}, true, 2, 1)...)
+			zones := map[string]string{
+				"cluster-data-3": "zone-b", "cluster-data-2": "zone-a", "cluster-data-1": "zone-b", "cluster-data-0": "zone-a",
+				"cluster-masters-2": "zone-b", "cluster-masters-1": "zone-b",
+			}
+			Expect(podNames(restartBatch(candidates, 1, zones))).To(Equal([]string{"cluster-data-3", "cluster-data-1", "cluster-masters-2"}))
+		})
+
+		Context("when selecting the batch with the zones of the nodes", func() {
+			var (
+				transport *httpmock.MockTransport
+				underTest *RollingRestartReconciler
+				data      opensearchv1.NodePool
+			)
+			withAwareness := func(attributes ...interface{}) {
+				transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/settings`),
+					httpmock.NewJsonResponderOrPanic(200, responses.FlatClusterSettingsWithDefaultsResponse{
+						Defaults: map[string]interface{}{"cluster.routing.allocation.awareness.attributes": attributes},
+					}))
+			}
+
+			BeforeEach(func() {
+				cluster := &opensearchv1.OpenSearchCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "cluster"},
+					Spec: opensearchv1.ClusterSpec{General: opensearchv1.GeneralConfig{
+						ServiceName:    "cluster",
+						HttpPort:       9200,
+						RollingRestart: &opensearchv1.RollingRestartConfig{ZoneAttribute: "zone"},
+					}},
+				}
+				transport = httpmock.NewMockTransport()
+				transport.RegisterNoResponder(httpmock.NewNotFoundResponder(failMessage))
+				clusterUrl := fmt.Sprintf("%s/", helpers.ClusterURL(cluster))
+				transport.RegisterResponder(http.MethodGet, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+				transport.RegisterResponder(http.MethodHead, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+				transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/health`),
+					httpmock.NewJsonResponderOrPanic(200, responses.ClusterHealthResponse{Status: "green"}))
+				transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cat/indices`),
+					httpmock.NewJsonResponderOrPanic(200, []responses.CatIndicesResponse{{Index: "idx", Rep: "1"}}))
+				transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_nodes/stats`),
+					httpmock.NewJsonResponderOrPanic(200, responses.NodesStatsResponse{Nodes: map[string]responses.NodeStatResponse{
+						"a": {Name: "cluster-data-3", Attributes: map[string]string{"zone": "zone-b"}},
+						"b": {Name: "cluster-data-2", Attributes: map[string]string{"zone": "zone-a"}},
+						"c": {Name: "cluster-data-1", Attributes: map[string]string{"zone": "zone-b"}},
+						"d": {Name: "cluster-data-0", Attributes: map[string]string{"zone": "zone-a"}},
+					}}))
+				osClient, err := services.NewOsClusterClient(helpers.ClusterURL(cluster), "admin", "admin", services.WithTransport(transport))
+				Expect(err).ToNot(HaveOccurred())
+				underTest = &RollingRestartReconciler{instance: cluster, osClient: osClient, logger: logr.Discard()}
+				data = opensearchv1.NodePool{Component: "data", Replicas: 4}
+			})
+
+			It("should restart by nodepool if the zone attribute is no allocation awareness attribute", func() {
+				withAwareness()
+				batch, err := underTest.selectRestartBatch(newCandidates(data, false, 3, 2, 1, 0), 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(podNames(batch)).To(Equal([]string{"cluster-data-3"}))
+			})
+
+			It("should restart a zone if no shard has all copies in the zone", func() {
+				withAwareness("zone")
+				transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cat/shards`),
+					httpmock.NewJsonResponderOrPanic(200, []responses.CatShardsResponse{
+						{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", NodeName: "cluster-data-3"},
+						{Index: "idx", Shard: "0", PrimaryOrReplica: "r", State: "STARTED", NodeName: "cluster-data-2"},
+					}))
+				batch, err := underTest.selectRestartBatch(newCandidates(data, false, 3, 2, 1, 0), 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(podNames(batch)).To(Equal([]string{"cluster-data-3", "cluster-data-1"}))
+			})
+
+			It("should fall back to the nodepool batch if the zone holds all copies of a shard", func() {
+				withAwareness("zone")
+				data.RestartMaxUnavailable = ptr.To(intstr.FromInt32(2))
+				transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cat/shards`),
+					httpmock.NewJsonResponderOrPanic(200, []responses.CatShardsResponse{
+						{Index: "idx", Shard: "0", PrimaryOrReplica: "p", State: "STARTED", NodeName: "cluster-data-3"},
+						{Index: "idx", Shard: "0", PrimaryOrReplica: "r", State: "STARTED", NodeName: "cluster-data-1"},
+					}))
+				batch, err := underTest.selectRestartBatch(newCandidates(data, false, 3, 2, 1, 0), 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(podNames(batch)).To(Equal([]string{"cluster-data-3", "cluster-data-2"}))
+			})
+		})
+	})
+
+	Describe("restartMaxUnavailable", func() {
+		DescribeTable("should resolve the number of pods restarted at once",
+			func(maxUnavailable *intstr.IntOrString, expected int) {
+				Expect(restartMaxUnavailable(&opensearchv1.NodePool{RestartMaxUnavailable: maxUnavailable}, 10)).To(Equal(expected))
+			},
+			Entry("default", nil, 1),
+			Entry("number", ptr.To(intstr.FromInt32(3)), 3),
+			Entry("percentage", ptr.To(intstr.FromString("25%")), 2),
+			Entry("percentage below one pod", ptr.To(intstr.FromString("5%")), 1),
+		)
+	})
 })
@@ -3,6 +3,7 @@ package reconcilers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"k8s.io/utils/ptr"
@@ -62,18 +63,35 @@ func (r *ScalerReconciler) Reconcile() (ctrl.Result, error) {
 	// Clean stale allocation exclusions (e.g. from a failed RemoveExcludeNodeHost after scale-down or upgrade).
 	// Skip cleanup when we are in the middle of a scale-down (Excluded or Drained), otherwise we would remove
 	// the node from the exclude list before drain completes and the scale-down would get stuck.
-	if r.instance.Spec.ConfMgmt.SmartScaler && !r.scalerHasExcludeOrDrainInProgress() {
+	if !r.scalerHasExcludeOrDrainInProgress() {
 		clusterClient, clientErr := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
 		if clientErr == nil {
-			if res, cleanupErr := util.CleanStaleExclusionList(r.client, r.instance, clusterClient, log.FromContext(r.ctx)); cleanupErr != nil {
-				return ctrl.Result{}, cleanupErr
-			} else if res.Requeue {
-				return res, nil
+			if r.instance.Spec.ConfMgmt.SmartScaler {
+				if res, cleanupErr := util.CleanStaleExclusionList(r.client, r.instance, clusterClient, log.FromContext(r.ctx)); cleanupErr != nil {
+					return ctrl.Result{}, cleanupErr
+				} else if res.Requeue {
+					return res, nil
+				}
+			}
+			// Voting configuration exclusions of removed cluster manager nodes are cleared once the nodes left the cluster,
+			// they are also added without the SmartScaler
+			if cleanupErr := services.ClearVotingConfigExclusionsOfRemovedNodes(clusterClient, log.FromContext(r.ctx)); cleanupErr != nil {
+				log.FromContext(r.ctx).Error(cleanupErr, "failed to clear the voting configuration exclusions")
 			}
 		}
 	}
 
+	results.Combine(r.reconcileAutoscaling())
+
 	for _, nodePool := range r.instance.Spec.NodePools {
+		if helpers.IsNodePoolMigrationInProgress(r.instance.Status, nodePool.Component) {
+			results.Combine(r.reconcileNodePoolMigration(&nodePool))
+			continue
+		}
+		if _, found := helpers.FindRoleTransition(r.instance.Status, nodePool.Component); found {
+			results.Combine(r.reconcileRoleTransition(&nodePool))
+			continue
+		}
 		requeue, err = r.reconcileNodePool(&nodePool)
 		if err != nil {
 			results.Combine(&ctrl.Result{Requeue: requeue}, err)
@@ -81,6 +99,11 @@ func (r *ScalerReconciler) Reconcile() (ctrl.Result, error) {
 	}
 	results.Combine(&ctrl.Result{Requeue: requeue}, nil)
 
+	// Do not remove old node pools while a node pool is being migrated
+	if helpers.AnyNodePoolMigrationInProgress(r.instance.Status) {
+		return results.Result, results.Err
+	}
+
 	// Check readiness of current NodePools before cleaning up old node pools
 	ready, err := r.nodePoolsReady()
 	if err != nil {
@@ -91,15 +114,20 @@ func (r *ScalerReconciler) Reconcile() (ctrl.Result, error) {
 	} else {
 		// Clean up old node pools (all current nodePools are ready)
 		r.cleanupStatefulSets(results)
+		// Remove PVCs left behind by removed node pools and nodes
+		results.Combine(r.cleanupOrphanedPVCs())
 	}
 
 	return results.Result, results.Err
 }
 
-// scalerHasExcludeOrDrainInProgress returns true if any node pool is in Excluded or Drained state,
-// i.e. we are in the middle of a scale-down and should not run CleanStaleExclusionList (would remove
-// the node we are draining from the exclude list and break the flow).
+// scalerHasExcludeOrDrainInProgress returns true if any node pool is in Excluded or Drained state, is being
+// migrated or changes its roles, i.e. we are in the middle of a drain and should not run CleanStaleExclusionList
+// (would remove the node we are draining from the exclude list and break the flow).
 func (r *ScalerReconciler) scalerHasExcludeOrDrainInProgress() bool {
+	if helpers.AnyNodePoolMigrationInProgress(r.instance.Status) || helpers.AnyRoleTransitionInProgress(r.instance.Status) {
+		return true
+	}
 	for _, cs := range r.instance.Status.ComponentsStatus {
 		if cs.Component != "Scaler" {
 			continue
@@ -135,12 +163,13 @@ func (r *ScalerReconciler) reconcileNodePool(nodePool *opensearchv1.NodePool) (b
 	comp := r.instance.Status.ComponentsStatus
 	currentStatus, found := helpers.FindFirstPartial(comp, componentStatus, helpers.GetByDescriptionAndComponent)
 
-	desireReplicaDiff := *currentSts.Spec.Replicas - nodePool.Replicas
+	replicas := helpers.NodePoolReplicas(r.instance, nodePool)
+	desireReplicaDiff := *currentSts.Spec.Replicas - replicas
 	if desireReplicaDiff == 0 {
 		// If a scaling operation was started before for this nodePool
 		if found {
 			err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
-				if currentSts.Status.ReadyReplicas != nodePool.Replicas {
+				if currentSts.Status.ReadyReplicas != replicas {
 					// Change the status to waiting while the pods are coming up or getting deleted
 					componentStatus.Status = "Waiting"
 					instance.Status.ComponentsStatus = helpers.Replace(currentStatus, componentStatus, r.instance.Status.ComponentsStatus)
@@ -175,6 +204,12 @@ func (r *ScalerReconciler) reconcileNodePool(nodePool *opensearchv1.NodePool) (b
 			r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "Scaler", "Starting to scaling")
 			if !r.instance.Spec.ConfMgmt.SmartScaler {
 				lg.Info(fmt.Sprintf("SmartScaler is disabled, removing nodes from nodegroup %s without draining", nodePool.Component))
+				if helpers.StatefulSetHasManagerRole(&currentSts) {
+					excluded, err := r.excludeNodesFromVoting(helpers.ReplicaHostName(currentSts, *currentSts.Spec.Replicas-1))
+					if !excluded {
+						return true, err
+					}
+				}
 				requeue, err := r.decreaseOneNode(currentStatus, currentSts, nodePool.Component, r.instance.Spec.ConfMgmt.SmartScaler)
 				r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "Scaler", "Notice - your SmartScaler is not enabled")
 				r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "Scaler", "Starting to decrease node")
@@ -375,10 +410,20 @@ func (r *ScalerReconciler) drainNode(currentStatus opensearchv1.ComponentStatus,
 		return err
 	}
 	nodeNotEmpty, err := services.HasShardsOnNode(clusterClient, lastReplicaNodeName)
-	if nodeNotEmpty {
+	if err != nil {
+		return err
+	}
+	drained, err := newDrainTracker(r.client, clusterClient, r.instance, r.recorder, lg).track(lastReplicaNodeName, !nodeNotEmpty)
+	if !drained {
 		lg.Info(fmt.Sprintf("Group: %s, Waiting for node %s to drain", nodePoolGroupName, lastReplicaNodeName))
 		return err
 	}
+	if helpers.StatefulSetHasManagerRole(&currentSts) {
+		excluded, err := r.excludeFromVoting(clusterClient, lastReplicaNodeName)
+		if !excluded {
+			return err
+		}
+	}
 
 	componentStatus := opensearchv1.ComponentStatus{
 		Component:   "Scaler",
@@ -398,6 +443,41 @@ func (r *ScalerReconciler) drainNode(currentStatus opensearchv1.ComponentStatus,
 	return err
 }
 
+// excludeFromVoting excludes cluster manager nodes from the voting configuration before they are removed, so the
+// cluster keeps its quorum. It returns true once the cluster committed a voting configuration without the nodes.
+// The exclusions are cleared in Reconcile after the nodes left the cluster.
+func (r *ScalerReconciler) excludeFromVoting(clusterClient *services.OsClusterClient, nodeNames ...string) (bool, error) {
+	lg := log.FromContext(r.ctx)
+	annotations := map[string]string{"cluster-name": r.instance.GetName()}
+	nodes := strings.Join(nodeNames, ",")
+	if err := services.AppendVotingConfigExclusions(clusterClient, lg, nodeNames); err != nil {
+		lg.Error(err, fmt.Sprintf("failed to exclude nodes %s from the voting configuration", nodes))
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "Scaler", "Failed to exclude nodes %s from the voting configuration", nodes)
+		return false, err
+	}
+	applied, err := services.VotingConfigExclusionsApplied(clusterClient, nodeNames)
+	if err != nil {
+		return false, err
+	}
+	if !applied {
+		lg.Info(fmt.Sprintf("Waiting for nodes %s to be removed from the voting configuration", nodes))
+		return false, nil
+	}
+	lg.Info(fmt.Sprintf("Nodes %s are excluded from the voting configuration", nodes))
+	return true, nil
+}
+
+// excludeNodesFromVoting excludes cluster manager nodes from the voting configuration if they are removed without
+// draining them first
+func (r *ScalerReconciler) excludeNodesFromVoting(nodeNames ...string) (bool, error) {
+	clusterClient, err := util.CreateClientForCluster(r.client, r.ctx, r.instance, r.osClientTransport)
+	if err != nil {
+		log.FromContext(r.ctx).Error(err, "failed to create os client")
+		return false, err
+	}
+	return r.excludeFromVoting(clusterClient, nodeNames...)
+}
+
 // nodePoolsReady checks that all StatefulSets for current NodePools are fully available.
 func (r *ScalerReconciler) nodePoolsReady() (bool, error) {
 	lg := log.FromContext(r.ctx)
@@ -435,6 +515,19 @@ func (r *ScalerReconciler) removeStatefulSet(sts appsv1.StatefulSet) (*ctrl.Resu
 	lg.Info(fmt.Sprintf("Removing statefulset: %s", sts.Name))
 
 	if !r.instance.Spec.ConfMgmt.SmartScaler {
+		if helpers.StatefulSetHasManagerRole(&sts) {
+			nodeNames := make([]string, 0, ptr.Deref(sts.Spec.Replicas, 1))
+			for ordinal := range ptr.Deref(sts.Spec.Replicas, 1) {
+				nodeNames = append(nodeNames, helpers.ReplicaHostName(sts, ordinal))
+			}
+			excluded, err := r.excludeNodesFromVoting(nodeNames...)
+			if !excluded {
+				return &ctrl.Result{
+					Requeue:      true,
+					RequeueAfter: 15 * time.Second,
+				}, err
+			}
+		}
 		return r.client.ReconcileResource(&sts, reconciler.StateAbsent)
 	}
 
@@ -450,6 +543,14 @@ func (r *ScalerReconciler) removeStatefulSet(sts appsv1.StatefulSet) (*ctrl.Resu
 
 	workingOrdinal := ptr.Deref(sts.Spec.Replicas, 1) - 1
 	lastReplicaNodeName := helpers.ReplicaHostName(sts, workingOrdinal)
+	tracker := newDrainTracker(r.client, clusterClient, r.instance, r.recorder, lg)
+	if tracker.aborted(lastReplicaNodeName) {
+		lg.Info(fmt.Sprintf("Drain of node %s was aborted, waiting to retry", lastReplicaNodeName))
+		return &ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: 15 * time.Second,
+		}, nil
+	}
 	_, err = services.AppendExcludeNodeHost(clusterClient, lg, lastReplicaNodeName)
 	if err != nil {
 		lg.Error(err, fmt.Sprintf("failed to exclude node %s", lastReplicaNodeName))
@@ -463,13 +564,29 @@ func (r *ScalerReconciler) removeStatefulSet(sts appsv1.StatefulSet) (*ctrl.Resu
 		return nil, err
 	}
 
-	if nodeNotEmpty {
+	drained, err := tracker.track(lastReplicaNodeName, !nodeNotEmpty)
+	if err != nil {
+		return nil, err
+	}
+	if !drained {
 		lg.Info(fmt.Sprintf("Waiting for shards to drain from node %s", lastReplicaNodeName))
 		return &ctrl.Result{
 			Requeue:      true,
 			RequeueAfter: 15 * time.Second,
 		}, nil
 	}
+	if helpers.StatefulSetHasManagerRole(&sts) {
+		excluded, err := r.excludeFromVoting(clusterClient, lastReplicaNodeName)
+		if err != nil {
+			return nil, err
+		}
+		if !excluded {
+			return &ctrl.Result{
+				Requeue:      true,
+				RequeueAfter: 15 * time.Second,
+			}, nil
+		}
+	}
 
 	if workingOrdinal == 0 {
 		result, err := r.client.ReconcileResource(&sts, reconciler.StateAbsent)
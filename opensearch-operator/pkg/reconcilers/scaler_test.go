@@ -3,18 +3,25 @@ package reconcilers
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 
+	"github.com/jarcoal/httpmock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/responses"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
 	"github.com/stretchr/testify/mock"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -344,4 +351,131 @@ var _ = Describe("Scaler Controller", func() {
 			Expect(currentStatus.Conditions[0]).To(Equal(targetNodeName))
 		})
 	})
+
+	Context("When removing cluster manager nodes", func() {
+		var (
+			transport  *httpmock.MockTransport
+			mockClient *k8s.MockK8sClient
+			spec       *opensearchv1.OpenSearchCluster
+			sts        *appsv1.StatefulSet
+			underTest  *ScalerReconciler
+		)
+
+		withCoordination := func(lastCommittedConfig ...string) {
+			coordination := responses.ClusterStateResponse{}
+			coordination.Metadata.ClusterCoordination.LastCommittedConfig = lastCommittedConfig
+			coordination.Metadata.ClusterCoordination.VotingConfigExclusions = []responses.VotingConfigExclusion{{NodeId: "id-1", NodeName: "roles-data-1"}}
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/state/metadata`),
+				httpmock.NewJsonResponderOrPanic(200, coordination))
+		}
+
+		BeforeEach(func() {
+			transport = httpmock.NewMockTransport()
+			transport.RegisterNoResponder(httpmock.NewNotFoundResponder(failMessage))
+			mockClient = k8s.NewMockK8sClient(GinkgoT())
+			spec = &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "roles", Namespace: "roles"},
+				Spec: opensearchv1.ClusterSpec{
+					General:  opensearchv1.GeneralConfig{ServiceName: "roles", HttpPort: 9200},
+					ConfMgmt: opensearchv1.ConfMgmt{SmartScaler: true},
+				},
+			}
+			clusterUrl := fmt.Sprintf("%s/", helpers.ClusterURL(spec))
+			transport.RegisterResponder(http.MethodGet, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+			transport.RegisterResponder(http.MethodHead, clusterUrl, httpmock.NewStringResponder(200, "OK"))
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cat/shards`),
+				httpmock.NewJsonResponderOrPanic(200, []responses.CatShardsResponse{}))
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/settings`),
+				httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{Transient: map[string]interface{}{
+					"cluster": map[string]interface{}{"routing": map[string]interface{}{"allocation": map[string]interface{}{"exclude": map[string]interface{}{"_name": "roles-data-1"}}}},
+				}}))
+			transport.RegisterRegexpResponder(http.MethodPut, regexp.MustCompile(`/_cluster/settings`),
+				httpmock.NewJsonResponderOrPanic(200, responses.ClusterSettingsResponse{}))
+			mockClient.On("GetSecret", "roles-admin-password", "roles").Return(corev1.Secret{
+				Data: map[string][]byte{"username": []byte("admin"), "password": []byte("admin")},
+			}, nil).Maybe()
+
+			sts = newStsWithRoles("cluster_manager", "cluster_manager")
+			underTest = newScalerReconciler(mockClient, spec)
+			underTest.apply(WithOSClientTransport(transport))
+		})
+
+		It("should wait for the node to be removed from the voting configuration", func() {
+			withCoordination("id-0", "id-1")
+
+			result, err := underTest.removeStatefulSet(*sts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).ToNot(BeZero())
+			Expect(transport.GetCallCountInfo()["POST =~/_cluster/voting_config_exclusions"]).To(Equal(0))
+		})
+
+		It("should exclude the node from the voting configuration before removing it", func() {
+			coordination := responses.ClusterStateResponse{}
+			coordination.Metadata.ClusterCoordination.LastCommittedConfig = []string{"id-0", "id-1"}
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/state/metadata`),
+				httpmock.NewJsonResponderOrPanic(200, coordination))
+			transport.RegisterRegexpResponder(http.MethodPost, regexp.MustCompile(`/_cluster/voting_config_exclusions`),
+				httpmock.NewStringResponder(200, ""))
+
+			result, err := underTest.removeStatefulSet(*sts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).ToNot(BeZero())
+			Expect(transport.GetCallCountInfo()["POST =~/_cluster/voting_config_exclusions"]).To(Equal(1))
+		})
+
+		It("should remove the node once it is excluded from the voting configuration", func() {
+			withCoordination("id-0")
+			mockClient.EXPECT().ReconcileResource(mock.Anything, reconciler.StatePresent).
+				RunAndReturn(func(obj runtime.Object, _ reconciler.DesiredState) (*ctrl.Result, error) {
+					Expect(*obj.(*appsv1.StatefulSet).Spec.Replicas).To(Equal(int32(1)))
+					return &ctrl.Result{}, nil
+				})
+
+			_, err := underTest.removeStatefulSet(*sts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(transport.GetCallCountInfo()["PUT =~/_cluster/settings"]).To(Equal(2))
+		})
+
+		It("should keep the node excluded from shard allocation until it is excluded from the voting configuration", func() {
+			withCoordination("id-0", "id-1")
+			currentStatus := opensearchv1.ComponentStatus{
+				Component:   "Scaler",
+				Status:      "Excluded",
+				Description: "data",
+				Conditions:  []string{"roles-data-1"},
+			}
+
+			err := underTest.drainNode(currentStatus, *sts, "data")
+			Expect(err).ToNot(HaveOccurred())
+			mockClient.AssertNotCalled(GinkgoT(), "UpdateOpenSearchClusterStatus", mock.Anything, mock.Anything)
+			Expect(transport.GetCallCountInfo()["PUT =~/_cluster/settings"]).To(Equal(0))
+		})
+
+		It("should exclude the nodes from the voting configuration without the SmartScaler", func() {
+			spec.Spec.ConfMgmt.SmartScaler = false
+			withCoordination("id-0", "id-1")
+			transport.RegisterRegexpResponder(http.MethodPost, regexp.MustCompile(`/_cluster/voting_config_exclusions`),
+				httpmock.NewStringResponder(200, ""))
+
+			result, err := underTest.removeStatefulSet(*sts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).ToNot(BeZero())
+			Expect(transport.GetCallCountInfo()["POST =~/_cluster/voting_config_exclusions"]).To(Equal(1))
+		})
+
+		It("should remove the statefulset without the SmartScaler once its nodes are excluded from voting", func() {
+			spec.Spec.ConfMgmt.SmartScaler = false
+			sts.Spec.Replicas = ptr.To[int32](1)
+			coordination := responses.ClusterStateResponse{}
+			coordination.Metadata.ClusterCoordination.LastCommittedConfig = []string{"id-1"}
+			coordination.Metadata.ClusterCoordination.VotingConfigExclusions = []responses.VotingConfigExclusion{{NodeId: "id-0", NodeName: "roles-data-0"}}
+			transport.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile(`/_cluster/state/metadata`),
+				httpmock.NewJsonResponderOrPanic(200, coordination))
+			mockClient.EXPECT().ReconcileResource(mock.Anything, reconciler.StateAbsent).Return(&ctrl.Result{}, nil)
+
+			_, err := underTest.removeStatefulSet(*sts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(transport.GetCallCountInfo()["POST =~/_cluster/voting_config_exclusions"]).To(Equal(0))
+		})
+	})
 })
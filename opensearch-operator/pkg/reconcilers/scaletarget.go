@@ -0,0 +1,104 @@
+package reconcilers
+
+import (
+	"fmt"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileScaleTarget creates the OpenSearchNodePool of a nodepool with a scale target and records the number of
+// replicas autoscalers set through its scale subresource in the status. The nodepool is then scaled one node at a time
+// by reconcileNodePool, so scale-downs are drained by the SmartScaler.
+func (r *ScalerReconciler) reconcileScaleTarget(nodePool *opensearchv1.NodePool, status opensearchv1.AutoscalingStatus) (opensearchv1.AutoscalingStatus, error) {
+	status.DesiredReplicas = helpers.NodePoolReplicas(r.instance, nodePool)
+	name := builders.OpenSearchNodePoolName(r.instance, nodePool)
+
+	scaleTarget, err := r.client.GetOpenSearchNodePool(name, r.instance.Namespace)
+	if k8serrors.IsNotFound(err) {
+		scaleTarget := builders.NewOpenSearchNodePoolForCR(r.instance, nodePool, status.DesiredReplicas)
+		if err := ctrl.SetControllerReference(r.instance, scaleTarget, r.client.Scheme()); err != nil {
+			return status, err
+		}
+		if _, err := r.client.ReconcileResource(scaleTarget, reconciler.StateCreated); err != nil {
+			return status, err
+		}
+		status.Message = fmt.Sprintf("Created OpenSearchNodePool %s", name)
+		return status, nil
+	}
+	if err != nil {
+		return status, err
+	}
+	if !metav1.IsControlledBy(&scaleTarget, r.instance) {
+		status.Message = fmt.Sprintf("OpenSearchNodePool %s is not owned by the cluster", name)
+		return status, nil
+	}
+
+	if scaleTarget.Spec.Replicas < 1 {
+		status.Message = fmt.Sprintf("Ignoring %d replicas of OpenSearchNodePool %s, at least 1 replica is required", scaleTarget.Spec.Replicas, name)
+	} else if scaleTarget.Spec.Replicas != status.DesiredReplicas {
+		from := status.DesiredReplicas
+		status.DesiredReplicas = scaleTarget.Spec.Replicas
+		status.LastScaleTime = &metav1.Time{Time: time.Now()}
+		status.Message = fmt.Sprintf("Scaling from %d to %d replicas through OpenSearchNodePool %s", from, status.DesiredReplicas, name)
+		annotations := map[string]string{"cluster-name": r.instance.GetName()}
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Normal", "Autoscaler", "Scaling nodepool %s from %d to %d replicas through OpenSearchNodePool %s", nodePool.Component, from, status.DesiredReplicas, name)
+	}
+
+	currentSts, err := r.client.GetStatefulSet(builders.StsName(r.instance, nodePool), r.instance.Namespace)
+	if err != nil {
+		return status, err
+	}
+	scaleTargetStatus := opensearchv1.OpenSearchNodePoolStatus{
+		Replicas:      currentSts.Status.Replicas,
+		ReadyReplicas: currentSts.Status.ReadyReplicas,
+		Selector:      builders.NodePoolSelector(r.instance, nodePool),
+	}
+	if scaleTarget.Status != scaleTargetStatus {
+		err := r.client.UdateObjectStatus(&scaleTarget, func(object client.Object) {
+			object.(*opensearchv1.OpenSearchNodePool).Status = scaleTargetStatus
+		})
+		if err != nil {
+			return status, err
+		}
+	}
+	return status, nil
+}
+
+// deleteRemovedScaleTargets deletes the OpenSearchNodePools of nodepools that were removed or no longer have a scale
+// target
+func (r *ScalerReconciler) deleteRemovedScaleTargets() error {
+	scaleTargets, err := r.client.ListOpenSearchNodePools(
+		client.InNamespace(r.instance.Namespace),
+		client.MatchingLabels{helpers.ClusterLabel: r.instance.Name},
+	)
+	if err != nil {
+		return err
+	}
+	for _, scaleTarget := range scaleTargets.Items {
+		if !metav1.IsControlledBy(&scaleTarget, r.instance) {
+			continue
+		}
+		inUse := false
+		for _, nodePool := range r.instance.Spec.NodePools {
+			if nodePool.ScaleTarget && builders.OpenSearchNodePoolName(r.instance, &nodePool) == scaleTarget.Name {
+				inUse = true
+				break
+			}
+		}
+		if inUse {
+			continue
+		}
+		if _, err := r.client.ReconcileResource(&scaleTarget, reconciler.StateAbsent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package reconcilers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Scale targets", func() {
+	const clusterName = "scaletarget"
+
+	var (
+		mockClient *k8s.MockK8sClient
+		spec       *opensearchv1.OpenSearchCluster
+		underTest  *ScalerReconciler
+		recorder   *record.FakeRecorder
+		status     opensearchv1.AutoscalingStatus
+	)
+
+	ownedScaleTarget := func(replicas int32, scaleTargetStatus opensearchv1.OpenSearchNodePoolStatus) opensearchv1.OpenSearchNodePool {
+		scaleTarget := opensearchv1.OpenSearchNodePool{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-data", Namespace: clusterName},
+			Spec:       opensearchv1.OpenSearchNodePoolSpec{Component: "data", Replicas: replicas},
+			Status:     scaleTargetStatus,
+		}
+		Expect(ctrl.SetControllerReference(spec, &scaleTarget, scheme.Scheme)).To(Succeed())
+		return scaleTarget
+	}
+
+	BeforeEach(func() {
+		spec = &opensearchv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+			Spec: opensearchv1.ClusterSpec{
+				NodePools: []opensearchv1.NodePool{{Component: "data", Replicas: 3, ScaleTarget: true}},
+			},
+		}
+		mockClient = k8s.NewMockK8sClient(GinkgoT())
+		underTest = newScalerReconciler(mockClient, spec)
+		recorder = record.NewFakeRecorder(1)
+		underTest.recorder = recorder
+		status = opensearchv1.AutoscalingStatus{Component: "data"}
+	})
+
+	It("should create the OpenSearchNodePool of the nodepool", func() {
+		mockClient.EXPECT().GetOpenSearchNodePool(clusterName+"-data", clusterName).
+			Return(opensearchv1.OpenSearchNodePool{}, k8serrors.NewNotFound(schema.GroupResource{}, clusterName+"-data"))
+		mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+		var created *opensearchv1.OpenSearchNodePool
+		mockClient.EXPECT().ReconcileResource(mock.Anything, reconciler.StateCreated).
+			RunAndReturn(func(object runtime.Object, _ reconciler.DesiredState) (*ctrl.Result, error) {
+				created = object.(*opensearchv1.OpenSearchNodePool)
+				return nil, nil
+			})
+
+		status, err := underTest.reconcileScaleTarget(&spec.Spec.NodePools[0], status)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.DesiredReplicas).To(Equal(int32(3)))
+		Expect(created.Name).To(Equal(clusterName + "-data"))
+		Expect(created.Labels).To(HaveKeyWithValue(helpers.NodePoolLabel, "data"))
+		Expect(created.Spec.Replicas).To(Equal(int32(3)))
+		Expect(created.Spec.OpensearchRef.Name).To(Equal(clusterName))
+		Expect(metav1.IsControlledBy(created, spec)).To(BeTrue())
+	})
+
+	It("should record the replicas set through the scale subresource and sync the status", func() {
+		scaleTarget := ownedScaleTarget(5, opensearchv1.OpenSearchNodePoolStatus{})
+		mockClient.EXPECT().GetOpenSearchNodePool(clusterName+"-data", clusterName).Return(scaleTarget, nil)
+		mockClient.EXPECT().GetStatefulSet(clusterName+"-data", clusterName).Return(appsv1.StatefulSet{
+			Spec:   appsv1.StatefulSetSpec{Replicas: ptr.To[int32](3)},
+			Status: appsv1.StatefulSetStatus{Replicas: 3, ReadyReplicas: 2},
+		}, nil)
+		var synced opensearchv1.OpenSearchNodePoolStatus
+		mockClient.EXPECT().UdateObjectStatus(mock.Anything, mock.Anything).
+			RunAndReturn(func(object client.Object, f func(client.Object)) error {
+				f(object)
+				synced = object.(*opensearchv1.OpenSearchNodePool).Status
+				return nil
+			})
+
+		status, err := underTest.reconcileScaleTarget(&spec.Spec.NodePools[0], status)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.DesiredReplicas).To(Equal(int32(5)))
+		Expect(status.LastScaleTime).ToNot(BeNil())
+		Expect(status.Message).To(Equal("Scaling from 3 to 5 replicas through OpenSearchNodePool scaletarget-data"))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal Autoscaler Scaling nodepool data from 3 to 5 replicas")))
+		Expect(synced).To(Equal(opensearchv1.OpenSearchNodePoolStatus{
+			Replicas:      3,
+			ReadyReplicas: 2,
+			Selector:      "opensearch.org/opensearch-cluster=scaletarget,opensearch.org/opensearch-nodepool=data",
+		}))
+	})
+
+	It("should ignore OpenSearchNodePools not owned by the cluster", func() {
+		mockClient.EXPECT().GetOpenSearchNodePool(clusterName+"-data", clusterName).Return(opensearchv1.OpenSearchNodePool{
+			Spec: opensearchv1.OpenSearchNodePoolSpec{Component: "data", Replicas: 5},
+		}, nil)
+
+		status, err := underTest.reconcileScaleTarget(&spec.Spec.NodePools[0], status)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.DesiredReplicas).To(Equal(int32(3)))
+		Expect(status.Message).To(Equal("OpenSearchNodePool scaletarget-data is not owned by the cluster"))
+	})
+
+	It("should delete the OpenSearchNodePools of removed nodepools", func() {
+		spec.Spec.NodePools = append(spec.Spec.NodePools, opensearchv1.NodePool{Component: "ingest", Replicas: 2})
+		removed := ownedScaleTarget(2, opensearchv1.OpenSearchNodePoolStatus{})
+		removed.Name = clusterName + "-ingest"
+		foreign := opensearchv1.OpenSearchNodePool{ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-other"}}
+		mockClient.EXPECT().ListOpenSearchNodePools(mock.Anything, mock.Anything).Return(opensearchv1.OpenSearchNodePoolList{
+			Items: []opensearchv1.OpenSearchNodePool{ownedScaleTarget(3, opensearchv1.OpenSearchNodePoolStatus{}), removed, foreign},
+		}, nil)
+		var deleted []string
+		mockClient.EXPECT().ReconcileResource(mock.Anything, reconciler.StateAbsent).
+			RunAndReturn(func(object runtime.Object, _ reconciler.DesiredState) (*ctrl.Result, error) {
+				deleted = append(deleted, object.(*opensearchv1.OpenSearchNodePool).Name)
+				return nil, nil
+			})
+
+		Expect(underTest.deleteRemovedScaleTargets()).To(Succeed())
+		Expect(deleted).To(Equal([]string{clusterName + "-ingest"}))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -48,7 +50,7 @@ const (
 	adminKey  = "/certs/tls.key"
 	caCert    = "/certs/ca.crt"
 
-	SecurityAdminBaseCmdTmpl = `ADMIN=%s/plugins/opensearch-security/tools/securityadmin.sh;
+	SecurityAdminBaseCmdTmpl = `ADMIN=%s;
 chmod +x $ADMIN;
 wait_count=0;
 until curl -k --silent https://%s:%v;
@@ -94,10 +96,12 @@ var ymlToFileType = map[string]string{
 
 type SecurityconfigReconciler struct {
 	client            k8s.K8sClient
+	ctx               context.Context
 	recorder          record.EventRecorder
 	reconcilerContext *ReconcilerContext
 	instance          *opensearchv1.OpenSearchCluster
 	logger            logr.Logger
+	osClientTransport http.RoundTripper
 }
 
 func NewSecurityconfigReconciler(
@@ -110,6 +114,7 @@ func NewSecurityconfigReconciler(
 ) *SecurityconfigReconciler {
 	return &SecurityconfigReconciler{
 		client:            k8s.NewK8sClient(client, ctx, append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", securityConfigReconcilerName)))...),
+		ctx:               ctx,
 		reconcilerContext: reconcilerContext,
 		recorder:          recorder,
 		instance:          instance,
@@ -124,6 +129,14 @@ func (r *SecurityconfigReconciler) Reconcile() (ctrl.Result, error) {
 		r.logger.Info("Security plugin is disabled, skipping securityconfig reconciliation")
 		return ctrl.Result{}, nil
 	}
+	if auth := helpers.Authentication(r.instance); auth != nil && auth.ClientCert != nil {
+		// Clients may authenticate with certificates, but do not have to
+		r.reconcilerContext.AddConfig("plugins.security.ssl.http.clientauth_mode", "OPTIONAL")
+	}
+	if r.restAPIModeEnabled() {
+		// The REST API rejects changes to config.yml unless explicitly allowed
+		r.reconcilerContext.AddConfig("plugins.security.unsupported.restapi.allow_securityconfig_modification", "true")
+	}
 	annotations := map[string]string{"cluster-name": r.instance.GetName()}
 
 	var configSecretName string
@@ -160,11 +173,20 @@ func (r *SecurityconfigReconciler) Reconcile() (ctrl.Result, error) {
 		}
 	}
 
-	generatedConfigSecret, err := helpers.BuildGeneratedSecurityConfigSecret(r.client, r.instance, adminCredentialsSecret)
+	generatedConfigSecret, sources, err := helpers.BuildGeneratedSecurityConfigSecret(r.client, r.instance, adminCredentialsSecret)
 	if err != nil {
 		r.logger.Error(err, "Unable to build generated security config secret")
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Second * 30}, err
 	}
+	if !equality.Semantic.DeepEqual(sources, r.instance.Status.SecurityConfigSources) {
+		updateErr := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+			instance.Status.SecurityConfigSources = sources
+		})
+		if updateErr != nil {
+			r.logger.Error(updateErr, "Unable to update securityconfig sources status")
+			return ctrl.Result{}, updateErr
+		}
+	}
 
 	if err := ctrl.SetControllerReference(r.instance, generatedConfigSecret, r.client.Scheme()); err != nil {
 		return ctrl.Result{}, err
@@ -203,6 +225,25 @@ func (r *SecurityconfigReconciler) Reconcile() (ctrl.Result, error) {
 	}
 	cmdArg = BuildCmdArg(r.instance, &configSecret, r.logger)
 
+	// securityadmin.sh would remove the resources managed by the OpensearchUser and OpensearchRole resources
+	if helpers.IsSecurityConfigMergeEnabled(r.instance) && !helpers.SecurityChangeVersion(r.instance) {
+		err := errors.New("merging the securityconfig requires OpenSearch 2.x")
+		r.logger.Error(err, "Skipping securityconfig reconciliation")
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "Security", "Merging the securityconfig requires OpenSearch 2.x")
+		return ctrl.Result{}, err
+	}
+	if r.applyWithRestAPI() {
+		return r.reconcileWithRestAPI(&configSecret, adminCertName, annotations)
+	}
+	if len(r.instance.Status.SecurityConfigFiles) > 0 {
+		// The files are applied by the update job again, their status is no longer maintained
+		if err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+			instance.Status.SecurityConfigFiles = nil
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	job, err := r.client.GetJob(jobName, namespace)
 	resetRetryCount := false
 	if err == nil {
@@ -240,9 +281,9 @@ func (r *SecurityconfigReconciler) Reconcile() (ctrl.Result, error) {
 	// securityconfig secret was not passed, build the command to apply all yml files
 	if !r.instance.Status.Initialized || len(cmdArg) == 0 {
 		clusterHostName := BuildClusterSvcHostName(r.instance)
-		opensearchHome := r.instance.Spec.General.GetOpenSearchHome()
+		securityAdmin := helpers.VendorForCluster(r.instance).SecurityAdminScript(helpers.OpenSearchHome(r.instance))
 		httpPort, securityConfigPort, securityconfigPath := helpers.VersionCheck(r.instance)
-		cmdArg = fmt.Sprintf(SecurityAdminBaseCmdTmpl, opensearchHome, clusterHostName, httpPort, securityConfigConnectWaitAttempts, securityConfigConnectWaitAttempts) +
+		cmdArg = fmt.Sprintf(SecurityAdminBaseCmdTmpl, securityAdmin, clusterHostName, httpPort, securityConfigConnectWaitAttempts, securityConfigConnectWaitAttempts) +
 			fmt.Sprintf(ApplyAllYmlCmdTmpl, caCert, adminCert, adminKey, securityconfigPath, clusterHostName, securityConfigPort)
 	}
 
@@ -273,10 +314,10 @@ func (r *SecurityconfigReconciler) Reconcile() (ctrl.Result, error) {
 // securityconfig secret. yml files which are not present in the secret are not applied/updated
 func BuildCmdArg(instance *opensearchv1.OpenSearchCluster, secret *corev1.Secret, log logr.Logger) string {
 	clusterHostName := BuildClusterSvcHostName(instance)
-	opensearchHome := instance.Spec.General.GetOpenSearchHome()
+	securityAdmin := helpers.VendorForCluster(instance).SecurityAdminScript(helpers.OpenSearchHome(instance))
 	httpPort, securityConfigPort, securityconfigPath := helpers.VersionCheck(instance)
 
-	arg := fmt.Sprintf(SecurityAdminBaseCmdTmpl, opensearchHome, clusterHostName, httpPort, securityConfigConnectWaitAttempts, securityConfigConnectWaitAttempts)
+	arg := fmt.Sprintf(SecurityAdminBaseCmdTmpl, securityAdmin, clusterHostName, httpPort, securityConfigConnectWaitAttempts, securityConfigConnectWaitAttempts)
 
 	// Get the list of yml files and sort them
 	// This will ensure commands are always generated in the same order
@@ -0,0 +1,177 @@
+package reconcilers
+
+import (
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/opensearch-gateway/services"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	securityConfigFileApplied = "Applied"
+	securityConfigFileFailed  = "Failed"
+)
+
+// restAPIModeEnabled returns true if changes are applied through the REST API, which is always the case for a merged
+// securityconfig as securityadmin.sh would remove the resources managed by the management resources
+func (r *SecurityconfigReconciler) restAPIModeEnabled() bool {
+	if helpers.IsSecurityConfigMergeEnabled(r.instance) {
+		return true
+	}
+	return r.instance.Spec.Security != nil && r.instance.Spec.Security.Config != nil &&
+		r.instance.Spec.Security.Config.UpdateMode == opensearchv1.SecurityConfigUpdateModeRestAPI
+}
+
+// applyWithRestAPI returns true if changes to the securityconfig are applied through the REST API. The security index
+// is initialized by securityadmin.sh, so the Job is used until the first Job of the initialized cluster succeeded.
+// A merged securityconfig is never applied by the Job once the cluster is initialized.
+func (r *SecurityconfigReconciler) applyWithRestAPI() bool {
+	if !r.restAPIModeEnabled() {
+		return false
+	}
+	if !helpers.SecurityChangeVersion(r.instance) {
+		r.logger.Info("Applying the securityconfig through the REST API requires OpenSearch 2.x, using the update job")
+		return false
+	}
+	if !r.instance.Status.Initialized {
+		return false
+	}
+	if helpers.IsSecurityConfigMergeEnabled(r.instance) {
+		return true
+	}
+	if len(r.instance.Status.SecurityConfigFiles) > 0 {
+		return true
+	}
+	componentStatus, found := r.securityConfigComponentStatus()
+	return found && componentStatus.Status == securityConfigStatusReady
+}
+
+// reconcileWithRestAPI applies every file of the securityconfig whose checksum changed since it was last applied
+// and reports the result per file in the status
+func (r *SecurityconfigReconciler) reconcileWithRestAPI(configSecret *corev1.Secret, adminCertName string, annotations map[string]string) (ctrl.Result, error) {
+	osClient, err := r.createAdminClient(adminCertName)
+	if err != nil {
+		r.logger.Error(err, "Unable to create the admin client for the securityconfig")
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, err
+	}
+
+	previous := map[string]opensearchv1.SecurityConfigFileStatus{}
+	for _, fileStatus := range r.instance.Status.SecurityConfigFiles {
+		previous[fileStatus.File] = fileStatus
+	}
+
+	files := make([]string, 0, len(configSecret.Data))
+	for file := range configSecret.Data {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var fileStatuses []opensearchv1.SecurityConfigFileStatus
+	var failed []string
+	for _, file := range files {
+		data := configSecret.Data[file]
+		configType, ok := ymlToFileType[file]
+		if !ok || len(data) == 0 {
+			continue
+		}
+		checksumval, err := checksum(map[string][]byte{file: data})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if fileStatus, ok := previous[file]; ok && fileStatus.Checksum == checksumval && fileStatus.Status == securityConfigFileApplied {
+			fileStatuses = append(fileStatuses, fileStatus)
+			continue
+		}
+
+		fileStatus := opensearchv1.SecurityConfigFileStatus{
+			File:           file,
+			Checksum:       checksumval,
+			Status:         securityConfigFileApplied,
+			LastUpdateTime: metav1.Now(),
+		}
+		if err := services.ApplySecurityConfig(r.ctx, osClient, configType, data, !helpers.IsSecurityConfigMergeEnabled(r.instance)); err != nil {
+			r.logger.Error(err, "Failed to apply securityconfig file", "file", file)
+			fileStatus.Status = securityConfigFileFailed
+			fileStatus.Message = err.Error()
+			failed = append(failed, file)
+		} else {
+			r.logger.Info("Applied securityconfig file", "file", file)
+		}
+		fileStatuses = append(fileStatuses, fileStatus)
+	}
+
+	if !equality.Semantic.DeepEqual(fileStatuses, r.instance.Status.SecurityConfigFiles) {
+		if err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+			instance.Status.SecurityConfigFiles = fileStatuses
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if len(failed) > 0 {
+		description := fmt.Sprintf("failed to apply %s", strings.Join(failed, ", "))
+		r.recorder.AnnotatedEventf(r.instance, annotations, "Warning", "Security", "Securityconfig update through the REST API %s", description)
+		if err := r.updateSecurityConfigComponentStatus(securityConfigStatusFailed, description, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true, RequeueAfter: securityConfigInitialRetryDelay}, nil
+	}
+	if componentStatus, found := r.securityConfigComponentStatus(); found && componentStatus.Status == securityConfigStatusReady &&
+		componentStatus.Description == "" && len(componentStatus.Conditions) == 0 {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, r.updateSecurityConfigComponentStatus(securityConfigStatusReady, "", nil)
+}
+
+// createAdminClient creates a client that authenticates with the admin certificate, which is required to change
+// reserved resources and the config of the security plugin
+func (r *SecurityconfigReconciler) createAdminClient(adminCertName string) (*services.OsClusterClient, error) {
+	secret, err := r.client.GetSecret(adminCertName, r.instance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := cryptotls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin certificate in secret %s: %w", adminCertName, err)
+	}
+	tlsConfig := &cryptotls.Config{Certificates: []cryptotls.Certificate{cert}}
+
+	caData := secret.Data[CaCertKey]
+	if caSecretName := r.determineAdminCASecret(adminCertName); caSecretName != "" {
+		caSecret, err := r.client.GetSecret(caSecretName, r.instance.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		caData = caSecret.Data[CaCertKey]
+	}
+	if len(caData) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("invalid CA certificate for the admin certificate %s", adminCertName)
+		}
+		tlsConfig.RootCAs = pool
+	} else {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	if r.instance.Spec.Security.Config != nil && r.instance.Spec.Security.Config.OperatorClientServerName != "" {
+		tlsConfig.ServerName = r.instance.Spec.Security.Config.OperatorClientServerName
+	}
+
+	opts := []services.OsClusterClientOption{services.WithTLSConfig(tlsConfig)}
+	if r.osClientTransport != nil {
+		opts = append(opts, services.WithTransport(r.osClientTransport))
+	}
+	return services.NewOsClusterClient(util.OpensearchClusterURL(r.instance), "", "", opts...)
+}
@@ -0,0 +1,226 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/tls"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Securityconfig Reconciler", func() {
+	Context("When applying the securityconfig through the REST API", func() {
+		const (
+			clusterName = "securityconfig-rest"
+			rolesYAML   = `_meta:
+  type: "roles"
+  config_version: 2
+app_reader:
+  reserved: true
+  cluster_permissions:
+    - "cluster_composite_ops_ro"
+`
+			auditYAML = `_meta:
+  type: "audit"
+  config_version: 2
+config:
+  enabled: true
+  audit:
+    enable_rest: true
+`
+		)
+
+		var (
+			transport  *httpmock.MockTransport
+			mockClient *k8s.MockK8sClient
+			spec       *opensearchv1.OpenSearchCluster
+			updated    *opensearchv1.OpenSearchCluster
+			underTest  *SecurityconfigReconciler
+			clusterUrl string
+		)
+
+		configSecret := &corev1.Secret{Data: map[string][]byte{
+			"config.yml":         []byte("_meta:\n  type: config\n"),
+			"roles.yml":          []byte(rolesYAML),
+			"audit.yml":          []byte(auditYAML),
+			"internal_users.yml": {},
+		}}
+		configChecksum, _ := checksum(map[string][]byte{"config.yml": configSecret.Data["config.yml"]})
+		appliedAt := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		BeforeEach(func() {
+			ca, err := tls.NewPKI().GenerateCA("securityconfig", tls.WithKey(tls.KeyAlgorithmECDSA, 256))
+			Expect(err).ToNot(HaveOccurred())
+			adminCert, err := ca.CreateAndSignCertificate("admin", "securityconfig", nil, time.Hour, tls.WithKey(tls.KeyAlgorithmECDSA, 256))
+			Expect(err).ToNot(HaveOccurred())
+
+			spec = &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{ServiceName: clusterName, Version: "2.11.0", HttpPort: 9200},
+					Security: &opensearchv1.Security{
+						Config: &opensearchv1.SecurityConfig{UpdateMode: opensearchv1.SecurityConfigUpdateModeRestAPI},
+						Tls:    &opensearchv1.TlsConfig{Http: &opensearchv1.TlsConfigHttp{Generate: true}},
+					},
+				},
+				Status: opensearchv1.ClusterStatus{
+					Initialized: true,
+					SecurityConfigFiles: []opensearchv1.SecurityConfigFileStatus{
+						{File: "config.yml", Checksum: configChecksum, Status: securityConfigFileApplied, LastUpdateTime: appliedAt},
+						{File: "tenants.yml", Checksum: "removed", Status: securityConfigFileApplied, LastUpdateTime: appliedAt},
+					},
+				},
+			}
+			updated = spec.DeepCopy()
+
+			mockClient = k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{Data: adminCert.SecretData(ca)}, nil)
+			mockClient.EXPECT().UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(spec), mock.Anything).
+				RunAndReturn(func(_ client.ObjectKey, f func(*opensearchv1.OpenSearchCluster)) error {
+					f(updated)
+					return nil
+				})
+
+			transport = httpmock.NewMockTransport()
+			transport.RegisterNoResponder(httpmock.NewNotFoundResponder(failMessage))
+			clusterUrl = helpers.ClusterURL(spec)
+			transport.RegisterResponder(http.MethodGet, clusterUrl+"/", httpmock.NewStringResponder(200, "OK"))
+			transport.RegisterResponder(http.MethodHead, clusterUrl+"/", httpmock.NewStringResponder(200, "OK"))
+			transport.RegisterResponder(http.MethodGet, clusterUrl+"/_plugins/_security/api/roles",
+				httpmock.NewStringResponder(200, `{"all_access": {"static": true}, "old_role": {}}`))
+
+			reconcilerContext := NewReconcilerContext(&record.FakeRecorder{}, spec, spec.Spec.NodePools)
+			underTest = newSecurityconfigReconciler(mockClient, context.Background(), &reconcilerContext, spec)
+			underTest.osClientTransport = transport
+		})
+
+		It("should only apply files whose checksum changed", func() {
+			var patch []map[string]interface{}
+			transport.RegisterResponder(http.MethodPatch, clusterUrl+"/_plugins/_security/api/roles",
+				func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+					Expect(json.Unmarshal(body, &patch)).To(Succeed())
+					return httpmock.NewStringResponse(200, `{"status": "OK"}`), nil
+				})
+			var audit map[string]interface{}
+			transport.RegisterResponder(http.MethodPut, clusterUrl+"/_plugins/_security/api/audit/config",
+				func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+					Expect(json.Unmarshal(body, &audit)).To(Succeed())
+					return httpmock.NewStringResponse(200, `{"status": "OK"}`), nil
+				})
+
+			Expect(underTest.applyWithRestAPI()).To(BeTrue())
+			result, err := underTest.reconcileWithRestAPI(configSecret, clusterName+"-admin-cert", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.IsZero()).To(BeTrue())
+
+			Expect(patch).To(Equal([]map[string]interface{}{
+				{"op": "add", "path": "/app_reader", "value": map[string]interface{}{"cluster_permissions": []interface{}{"cluster_composite_ops_ro"}}},
+				{"op": "remove", "path": "/old_role"},
+			}))
+			Expect(audit).To(HaveKeyWithValue("enabled", true))
+			Expect(audit).To(HaveKey("audit"))
+			Expect(transport.GetCallCountInfo()[fmt.Sprintf("PUT %s/_plugins/_security/api/securityconfig/config", clusterUrl)]).To(BeZero())
+
+			files := updated.Status.SecurityConfigFiles
+			Expect(files).To(HaveLen(3))
+			Expect(files[0].File).To(Equal("audit.yml"))
+			Expect(files[0].Status).To(Equal(securityConfigFileApplied))
+			Expect(files[1]).To(Equal(spec.Status.SecurityConfigFiles[0]))
+			Expect(files[2].File).To(Equal("roles.yml"))
+			Expect(files[2].Status).To(Equal(securityConfigFileApplied))
+			Expect(updated.Status.ComponentsStatus).To(Equal([]opensearchv1.ComponentStatus{{Component: securityConfigComponentName, Status: securityConfigStatusReady}}))
+		})
+
+		It("should keep resources missing from a merged securityconfig", func() {
+			spec.Spec.Security.Config.MergeMode = opensearchv1.SecurityConfigMergeModeMerge
+			var patch []map[string]interface{}
+			transport.RegisterResponder(http.MethodPatch, clusterUrl+"/_plugins/_security/api/roles",
+				func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+					Expect(json.Unmarshal(body, &patch)).To(Succeed())
+					return httpmock.NewStringResponse(200, `{"status": "OK"}`), nil
+				})
+			transport.RegisterResponder(http.MethodPut, clusterUrl+"/_plugins/_security/api/audit/config",
+				httpmock.NewStringResponder(200, `{"status": "OK"}`))
+
+			_, err := underTest.reconcileWithRestAPI(configSecret, clusterName+"-admin-cert", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch).To(HaveLen(1))
+			Expect(patch[0]).To(HaveKeyWithValue("op", "add"))
+			Expect(transport.GetCallCountInfo()[fmt.Sprintf("GET %s/_plugins/_security/api/roles", clusterUrl)]).To(BeZero())
+		})
+
+		It("should report files that could not be applied", func() {
+			transport.RegisterResponder(http.MethodPatch, clusterUrl+"/_plugins/_security/api/roles",
+				httpmock.NewStringResponder(400, `{"status": "error", "reason": "Invalid configuration"}`))
+			transport.RegisterResponder(http.MethodPut, clusterUrl+"/_plugins/_security/api/audit/config",
+				httpmock.NewStringResponder(200, `{"status": "OK"}`))
+			underTest.recorder = record.NewFakeRecorder(1)
+
+			result, err := underTest.reconcileWithRestAPI(configSecret, clusterName+"-admin-cert", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(securityConfigInitialRetryDelay))
+
+			files := updated.Status.SecurityConfigFiles
+			Expect(files).To(HaveLen(3))
+			Expect(files[0].Status).To(Equal(securityConfigFileApplied))
+			Expect(files[2].Status).To(Equal(securityConfigFileFailed))
+			Expect(files[2].Message).To(ContainSubstring("Invalid configuration"))
+			Expect(updated.Status.ComponentsStatus).To(HaveLen(1))
+			Expect(updated.Status.ComponentsStatus[0].Status).To(Equal(securityConfigStatusFailed))
+			Expect(updated.Status.ComponentsStatus[0].Description).To(Equal("failed to apply roles.yml"))
+		})
+	})
+
+	Context("When deciding how to apply the securityconfig", func() {
+		newCluster := func(mode opensearchv1.SecurityConfigUpdateMode, initialized bool, status string) *opensearchv1.OpenSearchCluster {
+			return &opensearchv1.OpenSearchCluster{
+				Spec: opensearchv1.ClusterSpec{
+					General:  opensearchv1.GeneralConfig{Version: "2.11.0"},
+					Security: &opensearchv1.Security{Config: &opensearchv1.SecurityConfig{UpdateMode: mode}},
+				},
+				Status: opensearchv1.ClusterStatus{
+					Initialized:      initialized,
+					ComponentsStatus: []opensearchv1.ComponentStatus{{Component: securityConfigComponentName, Status: status}},
+				},
+			}
+		}
+
+		DescribeTable("should use the REST API only after the initial job succeeded",
+			func(cluster *opensearchv1.OpenSearchCluster, expected bool) {
+				underTest := newSecurityconfigReconciler(k8s.NewMockK8sClient(GinkgoT()), context.Background(), nil, cluster)
+				Expect(underTest.applyWithRestAPI()).To(Equal(expected))
+			},
+			Entry("job mode", newCluster(opensearchv1.SecurityConfigUpdateModeJob, true, securityConfigStatusReady), false),
+			Entry("not initialized", newCluster(opensearchv1.SecurityConfigUpdateModeRestAPI, false, securityConfigStatusReady), false),
+			Entry("initial job running", newCluster(opensearchv1.SecurityConfigUpdateModeRestAPI, true, securityConfigStatusRunning), false),
+			Entry("initial job succeeded", newCluster(opensearchv1.SecurityConfigUpdateModeRestAPI, true, securityConfigStatusReady), true),
+			Entry("merged securityconfig", func() *opensearchv1.OpenSearchCluster {
+				cluster := newCluster(opensearchv1.SecurityConfigUpdateModeJob, true, securityConfigStatusReady)
+				cluster.Spec.Security.Config.MergeMode = opensearchv1.SecurityConfigMergeModeMerge
+				return cluster
+			}(), true),
+			Entry("merged securityconfig after a failed job", func() *opensearchv1.OpenSearchCluster {
+				cluster := newCluster(opensearchv1.SecurityConfigUpdateModeJob, true, securityConfigStatusFailed)
+				cluster.Spec.Security.Config.MergeMode = opensearchv1.SecurityConfigMergeModeMerge
+				return cluster
+			}(), true),
+		)
+	})
+})
@@ -32,6 +32,7 @@ func newSecurityconfigReconciler(
 ) *SecurityconfigReconciler {
 	return &SecurityconfigReconciler{
 		client:            client,
+		ctx:               ctx,
 		reconcilerContext: reconcilerContext,
 		recorder:          &helpers.MockEventRecorder{},
 		instance:          instance,
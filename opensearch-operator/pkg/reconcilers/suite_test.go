@@ -26,10 +26,13 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/stretchr/testify/mock"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	//+kubebuilder:scaffold:imports
@@ -59,6 +62,24 @@ var _ = BeforeSuite(func() {
 var _ = AfterSuite(func() {
 })
 
+// expectStatusUpdate expects updates of the status of the cluster and applies them to target, either the cluster
+// itself or a copy to check the changes of a single update
+func expectStatusUpdate(mockClient *k8s.MockK8sClient, cluster, target *opensearchv1.OpenSearchCluster) *k8s.MockK8sClient_UpdateOpenSearchClusterStatus_Call {
+	return mockClient.EXPECT().UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(cluster), mock.Anything).
+		RunAndReturn(func(_ client.ObjectKey, f func(*opensearchv1.OpenSearchCluster)) error {
+			f(target)
+			return nil
+		})
+}
+
+// expectObjectStatusUpdate expects updates of the status of the object and applies them to the object
+func expectObjectStatusUpdate(mockClient *k8s.MockK8sClient, object client.Object) {
+	mockClient.EXPECT().UdateObjectStatus(object, mock.Anything).RunAndReturn(func(object client.Object, f func(client.Object)) error {
+		f(object)
+		return nil
+	})
+}
+
 func NotFoundError() error {
 	return &errors.StatusError{ErrStatus: metav1.Status{Reason: metav1.StatusReasonNotFound}}
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -53,6 +54,12 @@ type TLSReconciler struct {
 	logger            logr.Logger
 	pki               tls.PKI
 	recorder          record.EventRecorder
+	// names of cert-manager certificates that have not been issued yet
+	pendingCertificates []string
+	// CA certificates trusted in addition to the generated CA while it is rotated
+	additionalTrustedCa []byte
+	// reissue certificates not signed by the generated CA, set while a CA rotation is in progress
+	reissueCertificates bool
 }
 
 func NewTLSReconciler(
@@ -68,6 +75,7 @@ func NewTLSReconciler(
 		instance:          instance,
 		logger:            log.FromContext(ctx),
 		pki:               tls.NewPKI(),
+		recorder:          reconcilerContext.recorder,
 	}
 }
 
@@ -86,6 +94,10 @@ func (r *TLSReconciler) Reconcile() (ctrl.Result, error) {
 
 	tlsConfig := r.instance.Spec.Security.Tls
 
+	if err := r.reconcileCaRotation(); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Handle transport TLS
 	if r.isTransportTlsEnabled(tlsConfig) {
 		if err := r.handleTransport(); err != nil {
@@ -103,12 +115,26 @@ func (r *TLSReconciler) Reconcile() (ctrl.Result, error) {
 		r.reconcilerContext.AddConfig("plugins.security.ssl.http.enabled", "false")
 	}
 
+	result := ctrl.Result{}
 	if helpers.IsSecurityPluginEnabled(r.instance) {
 		res, err := r.handleAdminCertificate()
-		return lo.FromPtrOr(res, ctrl.Result{}), err
+		if err != nil {
+			return lo.FromPtrOr(res, ctrl.Result{}), err
+		}
+		result = lo.FromPtrOr(res, ctrl.Result{})
 	}
 
-	return ctrl.Result{}, nil
+	if err := r.reconcileCertificateInventory(); err != nil {
+		r.logger.Error(err, "Failed to update the certificate inventory")
+	}
+
+	// Nodes can not start without their certificates, so wait until cert-manager has issued them
+	if len(r.pendingCertificates) > 0 {
+		r.logger.Info("Waiting for cert-manager to issue certificates", "certificates", r.pendingCertificates)
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+	}
+
+	return result, nil
 }
 
 // isTransportTlsEnabled determines if transport TLS should be enabled.
@@ -150,7 +176,11 @@ func (r *TLSReconciler) isHttpTlsEnabled(config *opensearchv1.TlsConfig) bool {
 func (r *TLSReconciler) handleTransport() error {
 	config := r.instance.Spec.Security.Tls.Transport
 
-	if config.Generate {
+	if helpers.CertManagerEnabled(&config.TlsCertificateConfig) {
+		if err := r.handleTransportCertManager(); err != nil {
+			return err
+		}
+	} else if config.Generate {
 		if err := r.handleTransportGenerate(); err != nil {
 			return err
 		}
@@ -178,12 +208,12 @@ func (r *TLSReconciler) handleAdminCertificate() (*ctrl.Result, error) {
 	if helpers.SecurityChangeVersion(r.instance) {
 		tlsConfig := r.instance.Spec.Security.Tls.Http
 		if shouldGenerate {
-			ca, err := r.getReferencedCaCertOrDefault(r.adminCAConfig())
-			if err != nil {
-				return nil, err
+			var certConfig *opensearchv1.TlsCertificateConfig
+			if tlsConfig != nil {
+				certConfig = &tlsConfig.TlsCertificateConfig
 			}
-
-			res, err = r.createAdminSecret(ca)
+			var err error
+			res, err = r.generateAdminCert(certConfig)
 			if err != nil {
 				return nil, err
 			}
@@ -194,12 +224,12 @@ func (r *TLSReconciler) handleAdminCertificate() (*ctrl.Result, error) {
 	} else {
 		tlsConfig := r.instance.Spec.Security.Tls.Transport
 		if shouldGenerate {
-			ca, err := r.getReferencedCaCertOrDefault(r.adminCAConfig())
-			if err != nil {
-				return nil, err
+			var certConfig *opensearchv1.TlsCertificateConfig
+			if tlsConfig != nil {
+				certConfig = &tlsConfig.TlsCertificateConfig
 			}
-
-			res, err = r.createAdminSecret(ca)
+			var err error
+			res, err = r.generateAdminCert(certConfig)
 			if err != nil {
 				return nil, err
 			}
@@ -213,6 +243,32 @@ func (r *TLSReconciler) handleAdminCertificate() (*ctrl.Result, error) {
 	return res, nil
 }
 
+// generateAdminCert issues the admin certificate, either with cert-manager if it issues the certificates
+// of the interface used for security config updates, or signed by the CA of the operator
+func (r *TLSReconciler) generateAdminCert(config *opensearchv1.TlsCertificateConfig) (*ctrl.Result, error) {
+	if helpers.CertManagerEnabled(config) {
+		available, err := reconcileCertManagerCertificate(r.client, r.instance, config, builders.CertManagerCertificate{
+			Name:       r.adminSecretName(),
+			SecretName: r.adminSecretName(),
+			CommonName: "admin",
+			Usages:     certUsagesClient,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !available {
+			r.pendingCertificates = append(r.pendingCertificates, r.adminSecretName())
+		}
+		return nil, nil
+	}
+
+	ca, err := r.getReferencedCaCertOrDefault(r.adminCAConfig())
+	if err != nil {
+		return nil, err
+	}
+	return r.createAdminSecret(ca, config)
+}
+
 func (r *TLSReconciler) adminCAConfig() corev1.LocalObjectReference {
 	return helpers.TlsCASecretRef(r.instance)
 }
@@ -254,7 +310,7 @@ func (r *TLSReconciler) shouldCreateAdminCert(ca tls.Cert) (bool, error) {
 	return !verified, nil
 }
 
-func (r *TLSReconciler) createAdminSecret(ca tls.Cert) (*ctrl.Result, error) {
+func (r *TLSReconciler) createAdminSecret(ca tls.Cert, config *opensearchv1.TlsCertificateConfig) (*ctrl.Result, error) {
 	createCert, err := r.shouldCreateAdminCert(ca)
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine if admin cert should be created: %w", err)
@@ -263,7 +319,7 @@ func (r *TLSReconciler) createAdminSecret(ca tls.Cert) (*ctrl.Result, error) {
 		return nil, nil
 	}
 
-	adminCert, err := ca.CreateAndSignCertificate("admin", r.instance.Name, nil, r.resolveTransportCertDuration())
+	adminCert, err := ca.CreateAndSignCertificate("admin", r.instance.Name, nil, r.resolveTransportCertDuration(), helpers.CertOptions(config)...)
 	if err != nil {
 		r.logger.Error(err, "Failed to create admin certificate", "interface", "transport")
 		r.recorder.AnnotatedEventf(
@@ -283,6 +339,7 @@ func (r *TLSReconciler) createAdminSecret(ca tls.Cert) (*ctrl.Result, error) {
 		Type: corev1.SecretTypeTLS,
 		Data: adminCert.SecretData(ca),
 	}
+	adminSecret.Data[CaCertKey] = r.trustedCaData(ca, r.adminCAConfig())
 	if err := ctrl.SetControllerReference(r.instance, adminSecret, r.client.Scheme()); err != nil {
 		return nil, err
 	}
@@ -334,12 +391,7 @@ func (r *TLSReconciler) handleTransportGenerate() error {
 				loggingName: "global",
 				certContext: CertContextTransport,
 				commonName:  clusterName,
-				dnsNames: []string{
-					clusterName,
-					fmt.Sprintf("%s.%s", clusterName, namespace),
-					fmt.Sprintf("%s.%s.svc", clusterName, namespace),
-					fmt.Sprintf("%s.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
-				},
+				dnsNames:    r.clusterTransportDnsNames(),
 			},
 			nodeSecret.Data[corev1.TLSCertKey],
 		)
@@ -349,14 +401,16 @@ func (r *TLSReconciler) handleTransportGenerate() error {
 		if newCertData != nil {
 			nodeSecret.Data = newCertData.SecretData(ca)
 		}
-
+		if nodeSecret.Data != nil {
+			nodeSecret.Data[CaCertKey] = r.trustedCaData(ca, config.CaSecret)
+		}
 	} else {
 		if nodeSecret.Data == nil {
 			// covers both the case where nodeSecret is new, or nodeSecret existed
 			// but was nil for some unknown reason (maybe a past failure)
 			nodeSecret.Data = make(map[string][]byte)
 		}
-		nodeSecret.Data[CaCertKey] = ca.CertData()
+		nodeSecret.Data[CaCertKey] = r.trustedCaData(ca, config.CaSecret)
 
 		if err := r.generateBootstrapCertIfNeeded(ca, &nodeSecret); err != nil {
 			return err
@@ -369,8 +423,7 @@ func (r *TLSReconciler) handleTransportGenerate() error {
 
 		// Generate node cert and put it into secret
 		for _, nodePool := range r.instance.Spec.NodePools {
-			for i := 0; i < int(nodePool.Replicas); i++ {
-				podName := fmt.Sprintf("%s-%s-%d", clusterName, nodePool.Component, i)
+			for _, podName := range helpers.NodePoolPodNames(r.instance, &nodePool) {
 				certName := fmt.Sprintf("%s.crt", podName)
 				keyName := fmt.Sprintf("%s.key", podName)
 				secretMutex.Lock()
@@ -382,19 +435,7 @@ func (r *TLSReconciler) handleTransportGenerate() error {
 						"interface", "transport", "node", podName)
 					certData = nil
 				}
-				dnsNames := []string{
-					podName,
-					clusterName,
-					builders.DiscoveryServiceName(r.instance),
-					fmt.Sprintf("%s.%s", podName, clusterName),
-					fmt.Sprintf("%s.%s", clusterName, namespace),
-					fmt.Sprintf("%s.%s.%s", podName, clusterName, namespace),
-					fmt.Sprintf("%s.%s.svc", clusterName, namespace),
-					fmt.Sprintf("%s.%s.%s.svc", podName, clusterName, namespace),
-					fmt.Sprintf("%s.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
-					fmt.Sprintf("%s.%s.%s.svc.%s", podName, clusterName, namespace,
-						helpers.ClusterDnsBase()),
-				}
+				dnsNames := r.nodeTransportDnsNames(podName)
 
 				eg.Go(func() error {
 					newCertData, err := r.generateNewCertIfNeeded(
@@ -434,35 +475,162 @@ func (r *TLSReconciler) handleTransportGenerate() error {
 		return err
 	}
 
-	// Tell cluster controller to mount secrets
-	volume := corev1.Volume{Name: "transport-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: nodeSecretName}}}
+	r.mountTransportSecret(nodeSecretName, generatePerNode, nil)
+	return nil
+}
+
+// mountTransportSecret tells the cluster controller to mount the transport secret managed by the operator and
+// configures the certificate paths. Without explicit nodesDn the DNs of the certificates issued for the cluster are allowed.
+func (r *TLSReconciler) mountTransportSecret(secretName string, perNode bool, nodesDn []string) {
+	clusterName := r.instance.Name
+
+	volume := corev1.Volume{Name: "transport-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}}}
 	r.reconcilerContext.Volumes = append(r.reconcilerContext.Volumes, volume)
-	mount := corev1.VolumeMount{Name: "transport-cert", MountPath: r.instance.Spec.General.GetOpenSearchHome() + "/config/tls-transport"}
+	mount := corev1.VolumeMount{Name: "transport-cert", MountPath: helpers.OpenSearchHome(r.instance) + "/config/tls-transport"}
 	r.reconcilerContext.VolumeMounts = append(r.reconcilerContext.VolumeMounts, mount)
 
 	// Extend opensearch.yml
-	if generatePerNode {
-		r.reconcilerContext.AddConfig("plugins.security.nodes_dn", fmt.Sprintf("[\"CN=%s-*,OU=%s\"]", clusterName, clusterName))
+	if perNode {
+		if len(nodesDn) == 0 {
+			nodesDn = []string{fmt.Sprintf("CN=%s-*,OU=%s", clusterName, clusterName)}
+		}
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemcert_filepath", "tls-transport/${HOSTNAME}.crt")
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemkey_filepath", "tls-transport/${HOSTNAME}.key")
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.enforce_hostname_verification", "true")
 	} else {
-		r.reconcilerContext.AddConfig("plugins.security.nodes_dn", fmt.Sprintf("[\"CN=%s,OU=%s\"]", clusterName, clusterName))
+		if len(nodesDn) == 0 {
+			nodesDn = []string{fmt.Sprintf("CN=%s,OU=%s", clusterName, clusterName)}
+		}
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemcert_filepath", fmt.Sprintf("tls-transport/%s", corev1.TLSCertKey))
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemkey_filepath", fmt.Sprintf("tls-transport/%s", corev1.TLSPrivateKeyKey))
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.enforce_hostname_verification", "false")
 	}
-
+	r.reconcilerContext.AddConfig("plugins.security.nodes_dn", fmt.Sprintf("[\"%s\"]", strings.Join(nodesDn, "\",\"")))
 	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemtrustedcas_filepath", fmt.Sprintf("tls-transport/%s", CaCertKey))
+}
+
+// handleTransportCertManager lets cert-manager issue the transport certificates. Per node certificates are issued
+// into one secret per node and collected into the transport secret, so scaling does not change the pod template.
+func (r *TLSReconciler) handleTransportCertManager() error {
+	namespace := r.instance.Namespace
+	clusterName := r.instance.Name
+	nodeSecretName := clusterName + "-transport-cert"
+	config := r.instance.Spec.Security.Tls.Transport
+
+	r.logger.Info("Reconciling cert-manager certificates", "interface", "transport")
+
+	if !config.PerNode {
+		available, err := reconcileCertManagerCertificate(r.client, r.instance, &config.TlsCertificateConfig, builders.CertManagerCertificate{
+			Name:       nodeSecretName,
+			SecretName: nodeSecretName,
+			CommonName: clusterName,
+			DnsNames:   r.clusterTransportDnsNames(),
+			Usages:     certUsagesServer,
+		})
+		if err != nil {
+			return err
+		}
+		if !available {
+			r.pendingCertificates = append(r.pendingCertificates, nodeSecretName)
+		}
+	} else {
+		nodeSecret, err := r.client.GetSecret(nodeSecretName, namespace)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				r.logger.Error(err, "Failed to get secret for transport certificate(s)")
+				return err
+			}
+			nodeSecret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: nodeSecretName, Namespace: namespace}}
+			if err := ctrl.SetControllerReference(r.instance, &nodeSecret, r.client.Scheme()); err != nil {
+				return err
+			}
+		}
+		if nodeSecret.Data == nil {
+			nodeSecret.Data = make(map[string][]byte)
+		}
+
+		var podNames []string
+		if !r.instance.Status.Initialized {
+			podNames = append(podNames, builders.BootstrapPodName(r.instance))
+		}
+		for _, nodePool := range r.instance.Spec.NodePools {
+			podNames = append(podNames, helpers.NodePoolPodNames(r.instance, &nodePool)...)
+		}
 
+		for _, podName := range podNames {
+			certName := podName + "-transport-cert"
+			available, err := reconcileCertManagerCertificate(r.client, r.instance, &config.TlsCertificateConfig, builders.CertManagerCertificate{
+				Name:       certName,
+				SecretName: certName,
+				CommonName: podName,
+				DnsNames:   r.nodeTransportDnsNames(podName),
+				Usages:     certUsagesServer,
+			})
+			if err != nil {
+				return err
+			}
+			if !available {
+				r.pendingCertificates = append(r.pendingCertificates, certName)
+				continue
+			}
+			podSecret, err := r.client.GetSecret(certName, namespace)
+			if err != nil {
+				return err
+			}
+			nodeSecret.Data[fmt.Sprintf("%s.crt", podName)] = podSecret.Data[corev1.TLSCertKey]
+			nodeSecret.Data[fmt.Sprintf("%s.key", podName)] = podSecret.Data[corev1.TLSPrivateKeyKey]
+			if ca, ok := podSecret.Data[CaCertKey]; ok {
+				nodeSecret.Data[CaCertKey] = ca
+			}
+		}
+
+		if _, err := r.client.CreateSecret(&nodeSecret); err != nil {
+			r.logger.Error(err, "Failed to store node certificate(s) in secret", "interface", "transport")
+			return err
+		}
+	}
+
+	r.mountTransportSecret(nodeSecretName, config.PerNode, config.NodesDn)
+	if config.EnableHotReload && helpers.SupportsHotReload(r.instance) {
+		r.reconcilerContext.AddConfig("plugins.security.ssl.certificates_hot_reload.enabled", "true")
+	}
 	return nil
 }
 
+// clusterTransportDnsNames returns the DNS names of the transport certificate shared by all nodes
+func (r *TLSReconciler) clusterTransportDnsNames() []string {
+	namespace := r.instance.Namespace
+	clusterName := r.instance.Name
+	return []string{
+		clusterName,
+		fmt.Sprintf("%s.%s", clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc", clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
+	}
+}
+
+// nodeTransportDnsNames returns the DNS names of the transport certificate of a single node
+func (r *TLSReconciler) nodeTransportDnsNames(podName string) []string {
+	namespace := r.instance.Namespace
+	clusterName := r.instance.Name
+	return []string{
+		podName,
+		clusterName,
+		builders.DiscoveryServiceName(r.instance),
+		fmt.Sprintf("%s.%s", podName, clusterName),
+		fmt.Sprintf("%s.%s", clusterName, namespace),
+		fmt.Sprintf("%s.%s.%s", podName, clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc", clusterName, namespace),
+		fmt.Sprintf("%s.%s.%s.svc", podName, clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
+		fmt.Sprintf("%s.%s.%s.svc.%s", podName, clusterName, namespace, helpers.ClusterDnsBase()),
+	}
+}
+
 func (r *TLSReconciler) generateBootstrapCertIfNeeded(
 	ca tls.Cert,
 	nodeSecret *corev1.Secret,
 ) error {
-	namespace := r.instance.Namespace
 	clusterName := r.instance.Name
 
 	// Generate bootstrap pod cert
@@ -471,19 +639,9 @@ func (r *TLSReconciler) generateBootstrapCertIfNeeded(
 	_, bootstrapKeyExists := nodeSecret.Data[fmt.Sprintf("%s.key", bootstrapPodName)]
 
 	if !r.instance.Status.Initialized && (!bootstrapCertExists || !bootstrapKeyExists) {
-		dnsNames := []string{
-			bootstrapPodName,
-			clusterName,
-			builders.DiscoveryServiceName(r.instance),
-			fmt.Sprintf("%s.%s", bootstrapPodName, clusterName),
-			fmt.Sprintf("%s.%s", clusterName, namespace),
-			fmt.Sprintf("%s.%s.%s", bootstrapPodName, clusterName, namespace),
-			fmt.Sprintf("%s.%s.svc", clusterName, namespace),
-			fmt.Sprintf("%s.%s.%s.svc", bootstrapPodName, clusterName, namespace),
-			fmt.Sprintf("%s.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
-			fmt.Sprintf("%s.%s.%s.svc.%s", bootstrapPodName, clusterName, namespace, helpers.ClusterDnsBase()),
-		}
-		nodeCert, err := ca.CreateAndSignCertificate(bootstrapPodName, clusterName, dnsNames, r.resolveTransportCertDuration())
+		dnsNames := r.nodeTransportDnsNames(bootstrapPodName)
+		nodeCert, err := ca.CreateAndSignCertificate(bootstrapPodName, clusterName, dnsNames, r.resolveTransportCertDuration(),
+			helpers.CertOptions(&r.instance.Spec.Security.Tls.Transport.TlsCertificateConfig)...)
 		if err != nil {
 			r.logger.Error(err, "Failed to create node certificate", "interface", "transport", "node", bootstrapPodName)
 			//	r.recorder.Event(r.instance, "Normal", "Security", "Created transport certificates")
@@ -503,22 +661,26 @@ func (r *TLSReconciler) generateNewCertIfNeeded(
 ) (tls.Cert, error) {
 	clusterName := r.instance.Name
 
-	if existingCertData != nil && !r.certShouldBeRenewed(cd, existingCertData) {
+	if existingCertData != nil && !r.certShouldBeRenewed(cd, existingCertData) && !certMissingDnsNames(existingCertData, cd.dnsNames) &&
+		!(r.reissueCertificates && certSignedByOtherCa(existingCertData, ca)) {
 		return nil, nil
 	}
 
 	var certDuration time.Duration
+	var config *opensearchv1.TlsCertificateConfig
 	switch cd.certContext {
 	case CertContextHttp:
 		certDuration = r.resolveHttpCertDuration()
+		config = &r.instance.Spec.Security.Tls.Http.TlsCertificateConfig
 	case CertContextTransport:
 		certDuration = r.resolveTransportCertDuration()
+		config = &r.instance.Spec.Security.Tls.Transport.TlsCertificateConfig
 	default:
 		panic("unrecognized certDescription.certContext value")
 	}
 
 	nodeCert, err := ca.CreateAndSignCertificate(cd.commonName, clusterName,
-		cd.dnsNames, certDuration)
+		cd.dnsNames, certDuration, helpers.CertOptions(config)...)
 	if err != nil {
 		r.logger.Error(err, "Failed to create certificate", "interface",
 			cd.certContext, "node", cd.loggingName)
@@ -533,11 +695,14 @@ func (r *TLSReconciler) certShouldBeRenewed(cd certDescription, existingCertData
 	clusterName := r.instance.Name
 
 	var renewBeforeExpirationDays int
+	var config *opensearchv1.TlsCertificateConfig
 	switch cd.certContext {
 	case CertContextTransport:
 		renewBeforeExpirationDays = r.instance.Spec.Security.Tls.Transport.RotateDaysBeforeExpiry
+		config = &r.instance.Spec.Security.Tls.Transport.TlsCertificateConfig
 	case CertContextHttp:
 		renewBeforeExpirationDays = r.instance.Spec.Security.Tls.Http.RotateDaysBeforeExpiry
+		config = &r.instance.Spec.Security.Tls.Http.TlsCertificateConfig
 	default:
 		panic("unrecognized certDescription.certContext value")
 	}
@@ -552,6 +717,13 @@ func (r *TLSReconciler) certShouldBeRenewed(cd certDescription, existingCertData
 	helpers.TlsCertificateDaysRemaining.WithLabelValues(namespace,
 		clusterName, string(cd.certContext), cd.loggingName).Set(float64(daysRemaining))
 
+	// Reissue the certificate if keyAlgorithm or keySize were changed
+	if validator, err := tls.NewCertValidater(existingCertData); err == nil && !validator.HasKey(helpers.CertOptions(config)...) {
+		r.logger.Info("Key of certificate does not match the configured key, renewing", "interface",
+			cd.certContext, "node", cd.loggingName)
+		return true
+	}
+
 	return (renewBeforeExpirationDays > 0 && daysRemaining < renewBeforeExpirationDays)
 }
 
@@ -564,7 +736,7 @@ func (r *TLSReconciler) handleTransportExistingCerts() error {
 		return err
 	}
 
-	opensearchHome := r.instance.Spec.General.GetOpenSearchHome()
+	opensearchHome := helpers.OpenSearchHome(r.instance)
 	if tlsConfig.PerNode {
 		mountFolder("transport", "certs", tlsConfig.Secret.Name, opensearchHome, r.reconcilerContext)
 		// Extend opensearch.yml
@@ -618,7 +790,29 @@ func (r *TLSReconciler) handleHttp() error {
 	clusterName := r.instance.Name
 	nodeSecretName := clusterName + "-http-cert"
 
-	if tlsConfig.Generate {
+	if helpers.CertManagerEnabled(&tlsConfig.TlsCertificateConfig) {
+		r.logger.Info("Reconciling cert-manager certificates", "interface", "http")
+
+		available, err := reconcileCertManagerCertificate(r.client, r.instance, &tlsConfig.TlsCertificateConfig, builders.CertManagerCertificate{
+			Name:       nodeSecretName,
+			SecretName: nodeSecretName,
+			CommonName: clusterName,
+			DnsNames:   r.httpDnsNames(),
+			Usages:     certUsagesServer,
+		})
+		if err != nil {
+			return err
+		}
+		if !available {
+			r.pendingCertificates = append(r.pendingCertificates, nodeSecretName)
+		}
+
+		// Tell cluster controller to mount secrets
+		volume := corev1.Volume{Name: "http-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: nodeSecretName}}}
+		r.reconcilerContext.Volumes = append(r.reconcilerContext.Volumes, volume)
+		mount := corev1.VolumeMount{Name: "http-cert", MountPath: helpers.OpenSearchHome(r.instance) + "/config/tls-http"}
+		r.reconcilerContext.VolumeMounts = append(r.reconcilerContext.VolumeMounts, mount)
+	} else if tlsConfig.Generate {
 		r.logger.Info("Reconciling certificates", "interface", "http")
 
 		ca, err := r.getReferencedCaCertOrDefault(tlsConfig.CaSecret)
@@ -641,28 +835,13 @@ func (r *TLSReconciler) handleHttp() error {
 		}
 
 		// Generate node cert and put it into secret
-		// Build default DNS names
-		dnsNames := []string{
-			clusterName,
-			r.instance.Spec.General.ServiceName,
-			builders.DiscoveryServiceName(r.instance),
-			fmt.Sprintf("%s.%s", clusterName, namespace),
-			fmt.Sprintf("%s.%s.svc", clusterName, namespace),
-			fmt.Sprintf("%s.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
-		}
-
-		// Prepend custom FQDN if provided
-		if tlsConfig.CustomFQDN != nil && *tlsConfig.CustomFQDN != "" {
-			dnsNames = append([]string{*tlsConfig.CustomFQDN}, dnsNames...)
-		}
-
 		nodeCert, err := r.generateNewCertIfNeeded(
 			ca,
 			certDescription{
 				loggingName: "global",
 				certContext: CertContextHttp,
 				commonName:  clusterName,
-				dnsNames:    dnsNames,
+				dnsNames:    r.httpDnsNames(),
 			},
 			nodeSecret.Data[corev1.TLSCertKey],
 		)
@@ -673,6 +852,9 @@ func (r *TLSReconciler) handleHttp() error {
 		if nodeCert != nil {
 			nodeSecret.Data = nodeCert.SecretData(ca)
 		}
+		if nodeSecret.Data != nil {
+			nodeSecret.Data[CaCertKey] = r.trustedCaData(ca, tlsConfig.CaSecret)
+		}
 
 		_, err = r.client.CreateSecret(&nodeSecret)
 		if err != nil {
@@ -684,7 +866,7 @@ func (r *TLSReconciler) handleHttp() error {
 		// Tell cluster controller to mount secrets
 		volume := corev1.Volume{Name: "http-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: nodeSecretName}}}
 		r.reconcilerContext.Volumes = append(r.reconcilerContext.Volumes, volume)
-		mount := corev1.VolumeMount{Name: "http-cert", MountPath: r.instance.Spec.General.GetOpenSearchHome() + "/config/tls-http"}
+		mount := corev1.VolumeMount{Name: "http-cert", MountPath: helpers.OpenSearchHome(r.instance) + "/config/tls-http"}
 		r.reconcilerContext.VolumeMounts = append(r.reconcilerContext.VolumeMounts, mount)
 	} else {
 		if tlsConfig.Secret.Name == "" {
@@ -695,7 +877,7 @@ func (r *TLSReconciler) handleHttp() error {
 		}
 
 		// Implement new mounting logic based on CaSecret.Name configuration
-		opensearchHome := r.instance.Spec.General.GetOpenSearchHome()
+		opensearchHome := helpers.OpenSearchHome(r.instance)
 		switch name := tlsConfig.CaSecret.Name; name {
 		case "":
 			// If CaSecret.Name is empty, mount Secret.Name as a directory
@@ -717,7 +899,7 @@ func (r *TLSReconciler) handleHttp() error {
 	// Set certificate file paths based on mounting configuration
 	// When generate is true, the CA cert is included in the generated secret mounted at tls-http/
 	// Only when generate is false AND CaSecret differs from Secret do we use the separate tls-http-ca/ mount
-	if tlsConfig.Generate || tlsConfig.CaSecret.Name == "" || tlsConfig.CaSecret.Name == tlsConfig.Secret.Name {
+	if tlsConfig.Generate || helpers.CertManagerEnabled(&tlsConfig.TlsCertificateConfig) || tlsConfig.CaSecret.Name == "" || tlsConfig.CaSecret.Name == tlsConfig.Secret.Name {
 		// Single secret mounted as directory
 		r.reconcilerContext.AddConfig("plugins.security.ssl.http.pemcert_filepath", fmt.Sprintf("tls-http/%s", corev1.TLSCertKey))
 		r.reconcilerContext.AddConfig("plugins.security.ssl.http.pemkey_filepath", fmt.Sprintf("tls-http/%s", corev1.TLSPrivateKeyKey))
@@ -736,6 +918,39 @@ func (r *TLSReconciler) handleHttp() error {
 	return nil
 }
 
+// httpDnsNames returns the DNS names of the http certificate, covering the cluster services and ingress hosts
+func (r *TLSReconciler) httpDnsNames() []string {
+	namespace := r.instance.Namespace
+	clusterName := r.instance.Name
+	tlsConfig := r.instance.Spec.Security.Tls.Http
+
+	// Build default DNS names
+	dnsNames := []string{
+		clusterName,
+		r.instance.Spec.General.ServiceName,
+		builders.DiscoveryServiceName(r.instance),
+		fmt.Sprintf("%s.%s", clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc", clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc.%s", clusterName, namespace, helpers.ClusterDnsBase()),
+	}
+
+	for _, service := range r.instance.Spec.General.AdditionalServices {
+		dnsNames = append(dnsNames,
+			service.Name,
+			fmt.Sprintf("%s.%s.svc.%s", service.Name, namespace, helpers.ClusterDnsBase()),
+		)
+	}
+
+	// Prepend the ingress hosts and custom FQDN if provided
+	if ingress := r.instance.Spec.General.Ingress; ingress != nil && ingress.Enable {
+		dnsNames = append(append([]string{}, ingress.Hosts...), dnsNames...)
+	}
+	if tlsConfig.CustomFQDN != nil && *tlsConfig.CustomFQDN != "" {
+		dnsNames = append([]string{*tlsConfig.CustomFQDN}, dnsNames...)
+	}
+	return dnsNames
+}
+
 func (r *TLSReconciler) getReferencedCaCertOrDefault(
 	secretReference corev1.LocalObjectReference,
 ) (tls.Cert, error) {
@@ -792,6 +1007,25 @@ func getDaysRemainingFromCertificate(data []byte) (int, error) {
 	return daysRemaining, nil
 }
 
+// certMissingDnsNames reports whether the certificate lacks any of the given DNS names,
+// e.g. after an additional service or ingress host was added
+func certMissingDnsNames(data []byte, dnsNames []string) bool {
+	der, _ := pem.Decode(data)
+	if der == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(der.Bytes)
+	if err != nil {
+		return false
+	}
+	for _, name := range dnsNames {
+		if !slices.Contains(cert.DNSNames, name) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *TLSReconciler) resolveTransportCertDuration() time.Duration {
 	if r.instance.Spec.Security != nil && r.instance.Spec.Security.Tls != nil && r.instance.Spec.Security.Tls.Transport != nil {
 		if r.instance.Spec.Security.Tls.Transport.Duration != nil {
@@ -8,10 +8,15 @@ import (
 	. "github.com/onsi/gomega"
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -25,6 +30,7 @@ func newTLSReconciler(k8sClient *k8s.MockK8sClient, spec *opensearchv1.OpenSearc
 		instance:          spec,
 		logger:            log.FromContext(context.Background()),
 		pki:               helpers.NewMockPKI(),
+		recorder:          reconcilerContext.recorder,
 	}
 	underTest.pki = helpers.NewMockPKI()
 	return &reconcilerContext, underTest
@@ -192,6 +198,8 @@ var _ = Describe("TLS Controller", func() {
 			mockClient := k8s.NewMockK8sClient(GinkgoT())
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
 			mockClient.EXPECT().GetSecret("casecret-http", clusterName).Return(caSecret, nil)
+			mockClient.EXPECT().GetSecret("cert-transport", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret("cert-http", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool { return secret.ObjectMeta.Name == clusterName+"-admin-cert" })).Return(&ctrl.Result{}, nil)
 			reconcilerContext, underTest := newTLSReconciler(mockClient, &spec)
@@ -250,6 +258,8 @@ var _ = Describe("TLS Controller", func() {
 			mockClient.EXPECT().Context().Return(context.Background())
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
 			mockClient.EXPECT().GetSecret(caSecretName, clusterName).Return(caSecret, nil)
+			mockClient.EXPECT().GetSecret("my-transport-certs", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret("my-http-certs", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool { return secret.ObjectMeta.Name == clusterName+"-admin-cert" })).Return(&ctrl.Result{}, nil)
 			reconcilerContext, underTest := newTLSReconciler(mockClient, &spec)
@@ -420,6 +430,8 @@ var _ = Describe("TLS Controller", func() {
 			mockClient := k8s.NewMockK8sClient(GinkgoT())
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
 			mockClient.EXPECT().GetSecret(caSecretName, clusterName).Return(caSecret, nil)
+			mockClient.EXPECT().GetSecret("cert-transport", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret("cert-http", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool { return secret.ObjectMeta.Name == clusterName+"-admin-cert" })).Return(&ctrl.Result{}, nil)
 			reconcilerContext, underTest := newTLSReconciler(mockClient, &spec)
@@ -470,6 +482,8 @@ var _ = Describe("TLS Controller", func() {
 			mockClient := k8s.NewMockK8sClient(GinkgoT())
 			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
 			mockClient.EXPECT().GetSecret(caSecretName, clusterName).Return(caSecret, nil)
+			mockClient.EXPECT().GetSecret("cert-transport", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret("cert-http", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
 			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool { return secret.ObjectMeta.Name == clusterName+"-admin-cert" })).Return(&ctrl.Result{}, nil)
 			reconcilerContext, underTest := newTLSReconciler(mockClient, &spec)
@@ -589,4 +603,118 @@ var _ = Describe("TLS Controller", func() {
 			Expect(value).To(Equal("[\"CN=admin,OU=tls-empty-fqdn\"]"))
 		})
 	})
+
+	Context("When Reconciling the TLS configuration with cert-manager", func() {
+		readyCertificate := func(ready bool) *unstructured.Unstructured {
+			status := "False"
+			if ready {
+				status = "True"
+			}
+			return &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": status}},
+				},
+			}}
+		}
+		certManager := &opensearchv1.CertManagerConfig{
+			Enable:    true,
+			IssuerRef: opensearchv1.CertManagerIssuerRef{Name: "opensearch-ca", Kind: "ClusterIssuer"},
+		}
+
+		It("should create certificates and derive the DNs", func() {
+			clusterName := "tls-cert-manager"
+			spec := opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{ServiceName: clusterName},
+					Security: &opensearchv1.Security{Tls: &opensearchv1.TlsConfig{
+						Transport: &opensearchv1.TlsConfigTransport{TlsCertificateConfig: opensearchv1.TlsCertificateConfig{CertManager: certManager}},
+						Http:      &opensearchv1.TlsConfigHttp{TlsCertificateConfig: opensearchv1.TlsCertificateConfig{CertManager: certManager}},
+					}},
+				},
+			}
+
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			var certificates []*unstructured.Unstructured
+			mockClient.On("ReconcileResource", mock.AnythingOfType("*unstructured.Unstructured"), reconciler.StatePresent).
+				Run(func(args mock.Arguments) {
+					certificates = append(certificates, args.Get(0).(*unstructured.Unstructured))
+				}).Return(&ctrl.Result{}, nil)
+			mockClient.EXPECT().GetUnstructured(builders.CertificateGVK, mock.Anything, clusterName).Return(readyCertificate(true), nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-transport-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret(clusterName+"-http-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+
+			reconcilerContext, underTest := newTLSReconciler(mockClient, &spec)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			Expect(certificates).To(HaveLen(3))
+			Expect(lo.Map(certificates, func(c *unstructured.Unstructured, _ int) string { return c.GetName() })).To(ConsistOf(
+				clusterName+"-transport-cert", clusterName+"-http-cert", clusterName+"-admin-cert",
+			))
+			issuerName, _, _ := unstructured.NestedString(certificates[0].Object, "spec", "issuerRef", "name")
+			Expect(issuerName).To(Equal("opensearch-ca"))
+			encoding, _, _ := unstructured.NestedString(certificates[0].Object, "spec", "privateKey", "encoding")
+			Expect(encoding).To(Equal("PKCS8"))
+
+			Expect(helpers.CheckVolumeExists(reconcilerContext.Volumes, reconcilerContext.VolumeMounts, clusterName+"-transport-cert", "transport-cert")).Should((BeTrue()))
+			Expect(helpers.CheckVolumeExists(reconcilerContext.Volumes, reconcilerContext.VolumeMounts, clusterName+"-http-cert", "http-cert")).Should((BeTrue()))
+			Expect(reconcilerContext.OpenSearchConfig).To(HaveKeyWithValue("plugins.security.nodes_dn", "[\"CN=tls-cert-manager,OU=tls-cert-manager\"]"))
+			Expect(reconcilerContext.OpenSearchConfig).To(HaveKeyWithValue("plugins.security.authcz.admin_dn", "[\"CN=admin,OU=tls-cert-manager\"]"))
+		})
+
+		It("should collect per node certificates and wait for the missing ones", func() {
+			clusterName := "tls-cert-manager-pernode"
+			transportSecretName := clusterName + "-transport-cert"
+			spec := opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{ServiceName: clusterName},
+					Security: &opensearchv1.Security{Tls: &opensearchv1.TlsConfig{
+						Transport: &opensearchv1.TlsConfigTransport{PerNode: true, TlsCertificateConfig: opensearchv1.TlsCertificateConfig{CertManager: certManager}},
+						Http:      &opensearchv1.TlsConfigHttp{TlsCertificateConfig: opensearchv1.TlsCertificateConfig{CertManager: certManager}},
+					}},
+					NodePools: []opensearchv1.NodePool{{Component: "masters", Replicas: 2}},
+				},
+				Status: opensearchv1.ClusterStatus{Initialized: true},
+			}
+
+			mockClient := k8s.NewMockK8sClient(GinkgoT())
+			mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+			mockClient.On("ReconcileResource", mock.AnythingOfType("*unstructured.Unstructured"), reconciler.StatePresent).Return(&ctrl.Result{}, nil)
+			mockClient.EXPECT().GetUnstructured(builders.CertificateGVK, mock.Anything, clusterName).
+				RunAndReturn(func(_ schema.GroupVersionKind, name string, _ string) (*unstructured.Unstructured, error) {
+					return readyCertificate(name != clusterName+"-masters-1-transport-cert"), nil
+				})
+			mockClient.EXPECT().GetSecret(transportSecretName, clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret(clusterName+"-masters-0-transport-cert", clusterName).Return(corev1.Secret{Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+				CaCertKey:               []byte("ca"),
+			}}, nil)
+			mockClient.EXPECT().GetSecret(clusterName+"-masters-1-transport-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret(clusterName+"-http-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			mockClient.EXPECT().GetSecret(clusterName+"-admin-cert", clusterName).Return(corev1.Secret{}, NotFoundError())
+			var transportSecret *corev1.Secret
+			mockClient.On("CreateSecret", mock.MatchedBy(func(secret *corev1.Secret) bool { return secret.Name == transportSecretName })).
+				Run(func(args mock.Arguments) {
+					transportSecret = args.Get(0).(*corev1.Secret)
+				}).Return(&ctrl.Result{}, nil)
+
+			reconcilerContext, underTest := newTLSReconciler(mockClient, &spec)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			Expect(transportSecret.Data).To(Equal(map[string][]byte{
+				clusterName + "-masters-0.crt": []byte("cert"),
+				clusterName + "-masters-0.key": []byte("key"),
+				CaCertKey:                      []byte("ca"),
+			}))
+			Expect(reconcilerContext.OpenSearchConfig).To(HaveKeyWithValue("plugins.security.nodes_dn", "[\"CN=tls-cert-manager-pernode-*,OU=tls-cert-manager-pernode\"]"))
+		})
+	})
 })
@@ -101,7 +101,15 @@ func (r *UpgradeReconciler) Reconcile() (ctrl.Result, error) {
 
 	// Set phase to UPGRADING if not already set
 	if r.instance.Status.Phase != opensearchv1.PhaseUpgrading {
-		err := r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
+		open, err := maintenanceWindowOpen(r.client, r.instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !open {
+			r.logger.Info("Waiting for the next maintenance window to start the upgrade", "requestedVersion", r.instance.Spec.General.Version)
+			return ctrl.Result{}, nil
+		}
+		err = r.client.UpdateOpenSearchClusterStatus(client.ObjectKeyFromObject(r.instance), func(instance *opensearchv1.OpenSearchCluster) {
 			instance.Status.Phase = opensearchv1.PhaseUpgrading
 		})
 		if err != nil {
@@ -176,6 +184,14 @@ func (r *UpgradeReconciler) Reconcile() (ctrl.Result, error) {
 	}
 }
 
+// upgradePending returns true if a new version was requested but the upgrade has not started yet, e.g. because it
+// waits for a maintenance window
+func upgradePending(instance *opensearchv1.OpenSearchCluster) bool {
+	return instance.Status.Version != "" &&
+		instance.Status.Version != instance.Spec.General.Version &&
+		instance.Status.Phase != opensearchv1.PhaseUpgrading
+}
+
 // Currently provides basic validation on versions.
 // TODO Improve the validation (maybe allow patch version downgrades)
 func (r *UpgradeReconciler) validateUpgrade() error {
@@ -339,6 +355,14 @@ func (r *UpgradeReconciler) doNodePoolUpgrade(pool opensearchv1.NodePool) error
 		r.logger.Info("Only 2 data nodes and drain is set, some shards may not drain")
 	}
 
+	// The new image is only written to the statefulset once the upgrade started
+	if sts.Status.ObservedGeneration < sts.Generation {
+		r.logger.Info("Waiting for the statefulset to be updated")
+		conditions = append(conditions, "Waiting for the statefulset to be updated")
+		r.setComponentConditions(conditions, pool.Component)
+		return nil
+	}
+
 	if sts.Status.ReadyReplicas < lo.FromPtrOr(sts.Spec.Replicas, 1) {
 		r.logger.Info("Waiting for all pods to be ready")
 		conditions = append(conditions, "Waiting for all pods to be ready")
@@ -358,7 +382,7 @@ func (r *UpgradeReconciler) doNodePoolUpgrade(pool opensearchv1.NodePool) error
 		return err
 	}
 
-	ready, condition, err := services.CheckClusterStatusForRestart(r.osClient, r.instance.Spec.General.DrainDataNodes)
+	ready, condition, err := services.CheckClusterStatusForRestart(r.osClient, r.instance.Spec.General.DrainDataNodes, false, nil)
 	if err != nil {
 		r.logger.Error(err, "Could not check opensearch cluster status")
 		conditions = append(conditions, "Could not check opensearch cluster status")
@@ -406,6 +430,13 @@ func (r *UpgradeReconciler) doNodePoolUpgrade(pool opensearchv1.NodePool) error
 		return err
 	}
 
+	tracker := newDrainTracker(r.client, r.osClient, r.instance, r.recorder, r.logger)
+	if r.instance.Spec.General.DrainDataNodes && tracker.aborted(workingPod) {
+		conditions = append(conditions, "Drain aborted, waiting to retry")
+		r.setComponentConditions(conditions, pool.Component)
+		return nil
+	}
+
 	ready, err = services.PreparePodForDelete(r.osClient, r.logger, workingPod, r.instance.Spec.General.DrainDataNodes, dataCount)
 	if err != nil {
 		r.logger.Error(err, "Could not prepare pod for delete")
@@ -413,6 +444,15 @@ func (r *UpgradeReconciler) doNodePoolUpgrade(pool opensearchv1.NodePool) error
 		r.setComponentConditions(conditions, pool.Component)
 		return err
 	}
+	if r.instance.Spec.General.DrainDataNodes {
+		ready, err = tracker.track(workingPod, ready)
+		if err != nil {
+			r.logger.Error(err, "Could not track the drain of the node")
+			conditions = append(conditions, "Could not track the drain of the node")
+			r.setComponentConditions(conditions, pool.Component)
+			return err
+		}
+	}
 	if !ready {
 		conditions = append(conditions, "Waiting for node to drain")
 		r.setComponentConditions(conditions, pool.Component)
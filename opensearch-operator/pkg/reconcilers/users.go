@@ -136,6 +136,18 @@ func (r *UserReconciler) Reconcile() (retResult ctrl.Result, retErr error) {
 		return
 	}
 
+	// Check cluster is not paused
+	if helpers.ClusterPaused(r.cluster) {
+		r.logger.Info("opensearch cluster is paused, requeueing")
+		reason = "waiting for opensearch cluster to be resumed"
+		r.recorder.Event(r.instance, "Normal", opensearchPending, reason)
+		retResult = ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: 10 * time.Second,
+		}
+		return
+	}
+
 	r.osClient, retErr = util.CreateClientForCluster(r.client, r.ctx, r.cluster, r.osClientTransport)
 	if retErr != nil {
 		reason = "error creating opensearch client"
@@ -143,6 +155,12 @@ func (r *UserReconciler) Reconcile() (retResult ctrl.Result, retErr error) {
 		return
 	}
 
+	retErr = r.reconcileGeneratedPassword()
+	if retErr != nil {
+		reason = "failed to generate password"
+		return
+	}
+
 	userSecret, retErr := r.managePasswordSecret(r.instance.Name, r.instance.Namespace)
 
 	if retErr != nil {
@@ -182,6 +200,12 @@ func (r *UserReconciler) Reconcile() (retResult ctrl.Result, retErr error) {
 	}
 	if !update {
 		r.logger.V(1).Info(fmt.Sprintf("user %s is in sync", r.instance.Name))
+		retErr = r.reconcileConnectionSecret(userPassword)
+		if retErr != nil {
+			reason = "failed to write connection secret"
+			r.logger.Error(retErr, reason)
+			r.recorder.Event(r.instance, "Warning", passwordError, reason)
+		}
 		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, retErr
 	}
 
@@ -193,10 +217,26 @@ func (r *UserReconciler) Reconcile() (retResult ctrl.Result, retErr error) {
 	}
 
 	r.recorder.Event(r.instance, "Normal", opensearchAPIUpdated, "user updated in opensearch")
+	if retErr == nil {
+		// Publish the password only once OpenSearch accepts it
+		retErr = r.reconcileConnectionSecret(userPassword)
+		if retErr != nil {
+			reason = "failed to write connection secret"
+			r.logger.Error(retErr, reason)
+			r.recorder.Event(r.instance, "Warning", passwordError, reason)
+		}
+	}
 	return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, retErr
 }
 
 func (r *UserReconciler) Delete() error {
+	// Connection secrets in other namespaces are not garbage collected
+	if ref := r.instance.Status.ConnectionSecret; ref != "" {
+		if err := r.deleteConnectionSecret(ref); err != nil {
+			return err
+		}
+	}
+
 	var err error
 	r.cluster, err = util.FetchOpensearchCluster(r.client, r.ctx, types.NamespacedName{
 		Name:      r.instance.Spec.OpensearchRef.Name,
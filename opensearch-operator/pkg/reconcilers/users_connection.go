@@ -0,0 +1,138 @@
+package reconcilers
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/builders"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// connectionSecretRef returns the namespace and name of the connection secret of the user, nil if none is configured
+func (r *UserReconciler) connectionSecretRef() *client.ObjectKey {
+	spec := r.instance.Spec.ConnectionSecret
+	if spec == nil {
+		return nil
+	}
+	key := client.ObjectKey{Name: spec.Name, Namespace: spec.Namespace}
+	if key.Name == "" {
+		key.Name = fmt.Sprintf("%s-connection", r.instance.Name)
+	}
+	if key.Namespace == "" {
+		key.Namespace = r.instance.Namespace
+	}
+	return &key
+}
+
+// reconcileConnectionSecret writes the username, password, URL and CA certificate of the cluster to the connection
+// secret and removes a previously written secret that is no longer configured. An existing secret is only updated if
+// it was written for this user.
+func (r *UserReconciler) reconcileConnectionSecret(password []byte) error {
+	ref := r.connectionSecretRef()
+	if ref != nil && ref.Namespace != r.instance.Namespace && !helpers.CrossNamespaceConnectionSecrets() {
+		return fmt.Errorf("connection secret %s is in another namespace, which requires %s=true for the operator", ref, helpers.CrossNamespaceConnectionSecretsEnvVariable)
+	}
+	if previous := r.instance.Status.ConnectionSecret; previous != "" && (ref == nil || previous != ref.String()) {
+		if err := r.deleteConnectionSecret(previous); err != nil {
+			return err
+		}
+	}
+	if ref == nil {
+		return r.updateConnectionSecretStatus("")
+	}
+
+	existing, err := r.client.GetSecret(ref.Name, ref.Namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && !r.ownsConnectionSecret(existing) {
+		return fmt.Errorf("secret %s already exists and is not a connection secret of this user", ref)
+	}
+
+	clusterURL, err := url.Parse(builders.URLForCluster(r.cluster))
+	if err != nil {
+		return err
+	}
+	uri := *clusterURL
+	uri.User = url.UserPassword(r.instance.Name, string(password))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+			// Changes of the secret trigger a reconcile of the user, which restores it
+			Annotations: map[string]string{
+				helpers.OsUserNameAnnotation:      r.instance.Name,
+				helpers.OsUserNamespaceAnnotation: r.instance.Namespace,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"username": []byte(r.instance.Name),
+			"password": password,
+			"url":      []byte(clusterURL.String()),
+			"host":     []byte(clusterURL.Hostname()),
+			"port":     []byte(clusterURL.Port()),
+			"uri":      []byte(uri.String()),
+		},
+	}
+	if caSecretName := helpers.HttpCASecretName(r.cluster); caSecretName != "" {
+		caSecret, err := r.client.GetSecret(caSecretName, r.cluster.Namespace)
+		if err != nil {
+			return err
+		}
+		secret.Data[CaCertKey] = caSecret.Data[CaCertKey]
+	}
+	if ref.Namespace == r.instance.Namespace {
+		if err := ctrl.SetControllerReference(r.instance, secret, r.client.Scheme()); err != nil {
+			return err
+		}
+	}
+	if _, err := r.client.CreateSecret(secret); err != nil {
+		return err
+	}
+	return r.updateConnectionSecretStatus(ref.String())
+}
+
+// deleteConnectionSecret deletes the connection secret stored as namespace/name in the status
+func (r *UserReconciler) deleteConnectionSecret(ref string) error {
+	namespace, name, _ := strings.Cut(ref, "/")
+	secret, err := r.client.GetSecret(name, namespace)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !r.ownsConnectionSecret(secret) {
+		r.logger.Info("Not deleting connection secret that is not managed for this user", "secret", ref)
+		return nil
+	}
+	r.logger.Info("Deleting connection secret", "secret", ref)
+	_, err = r.client.ReconcileResource(&secret, reconciler.StateAbsent)
+	return err
+}
+
+// ownsConnectionSecret returns true if the secret was written as connection secret of this user
+func (r *UserReconciler) ownsConnectionSecret(secret corev1.Secret) bool {
+	return secret.Annotations[helpers.OsUserNameAnnotation] == r.instance.Name &&
+		secret.Annotations[helpers.OsUserNamespaceAnnotation] == r.instance.Namespace
+}
+
+func (r *UserReconciler) updateConnectionSecretStatus(ref string) error {
+	if r.instance.Status.ConnectionSecret == ref || !ptr.Deref(r.updateStatus, true) {
+		return nil
+	}
+	return r.client.UdateObjectStatus(r.instance, func(object client.Object) {
+		instance := object.(*opensearchv1.OpensearchUser)
+		instance.Status.ConnectionSecret = ref
+	})
+}
@@ -0,0 +1,98 @@
+package reconcilers
+
+import (
+	"fmt"
+	"time"
+
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const passwordRotated = "PasswordRotated"
+
+// reconcileGeneratedPassword creates the password secret of a user with passwordGeneration and rotates the password
+// when it is due
+func (r *UserReconciler) reconcileGeneratedPassword() error {
+	generation := r.instance.Spec.PasswordGeneration
+	if generation == nil {
+		return nil
+	}
+	status := lo.FromPtr(r.instance.Status.Password)
+	key := r.instance.Spec.PasswordFrom.Key
+	now := time.Now()
+
+	secret, err := r.client.GetSecret(r.instance.Spec.PasswordFrom.Name, r.instance.Namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		r.recorder.Event(r.instance, "Warning", passwordError, "error fetching password secret")
+		return err
+	}
+	if k8serrors.IsNotFound(err) {
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: r.instance.Spec.PasswordFrom.Name, Namespace: r.instance.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+		}
+		if err := ctrl.SetControllerReference(r.instance, &secret, r.client.Scheme()); err != nil {
+			return err
+		}
+	}
+
+	secretChanged := false
+	if reason := r.passwordRotationReason(status, secret, now); reason != "" {
+		password, err := helpers.GeneratePassword(generation.Length, generation.CharacterClasses)
+		if err != nil {
+			r.recorder.Event(r.instance, "Warning", passwordError, fmt.Sprintf("failed to generate password: %s", err))
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[key] = []byte(password)
+		status.LastRotationTime = ptr.To(metav1.NewTime(now))
+		status.LastRequest = r.instance.Annotations[helpers.RotatePasswordAnnotation]
+		secretChanged = true
+		r.logger.Info("Generating password", "reason", reason)
+		r.recorder.Event(r.instance, "Normal", passwordRotated, fmt.Sprintf("Generated password: %s", reason))
+	} else if status.LastRotationTime == nil {
+		// Password of an existing secret that was not generated by the operator, rotate it after the interval
+		status.LastRotationTime = ptr.To(metav1.NewTime(now))
+	}
+
+	if secretChanged {
+		if _, err := r.client.CreateSecret(&secret); err != nil {
+			r.recorder.Event(r.instance, "Warning", passwordError, "error updating password secret")
+			return err
+		}
+	}
+	if !ptr.Deref(r.updateStatus, true) || equality.Semantic.DeepEqual(&status, r.instance.Status.Password) {
+		return nil
+	}
+	return r.client.UdateObjectStatus(r.instance, func(object client.Object) {
+		instance := object.(*opensearchv1.OpensearchUser)
+		instance.Status.Password = &status
+	})
+}
+
+// passwordRotationReason returns why a new password has to be generated, or an empty string if the current one is kept
+func (r *UserReconciler) passwordRotationReason(status opensearchv1.UserPasswordStatus, secret corev1.Secret, now time.Time) string {
+	key := r.instance.Spec.PasswordFrom.Key
+	if _, ok := secret.Data[key]; !ok {
+		return fmt.Sprintf("secret %s has no key %s", secret.Name, key)
+	}
+	if request := r.instance.Annotations[helpers.RotatePasswordAnnotation]; request != "" && request != status.LastRequest {
+		return fmt.Sprintf("requested with annotation %s=%s", helpers.RotatePasswordAnnotation, request)
+	}
+	interval := r.instance.Spec.PasswordGeneration.RotationInterval
+	if interval != nil && interval.Duration > 0 && status.LastRotationTime != nil &&
+		!now.Before(status.LastRotationTime.Add(interval.Duration)) {
+		return fmt.Sprintf("rotation interval of %s passed", interval.Duration)
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package reconcilers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/mocks/github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/reconcilers/k8s"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("users reconciler password generation", func() {
+	var (
+		mockClient *k8s.MockK8sClient
+		instance   *opensearchv1.OpensearchUser
+		reconciler *UserReconciler
+		saved      *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		mockClient = k8s.NewMockK8sClient(GinkgoT())
+		instance = &opensearchv1.OpensearchUser{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps", UID: "appuid"},
+			Spec: opensearchv1.OpensearchUserSpec{
+				OpensearchRef: corev1.LocalObjectReference{Name: "cluster"},
+				PasswordFrom: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "app-password"},
+					Key:                  "password",
+				},
+				PasswordGeneration: &opensearchv1.PasswordGenerationSpec{
+					Length: 24,
+				},
+			},
+		}
+		saved = nil
+	})
+
+	JustBeforeEach(func() {
+		reconciler = &UserReconciler{
+			client:   mockClient,
+			ctx:      context.Background(),
+			recorder: record.NewFakeRecorder(10),
+			instance: instance,
+			logger:   log.FromContext(context.Background()),
+		}
+	})
+
+	expectSecretSaved := func() {
+		mockClient.EXPECT().CreateSecret(mock.Anything).RunAndReturn(func(secret *corev1.Secret) (*ctrl.Result, error) {
+			saved = secret
+			return &ctrl.Result{}, nil
+		})
+	}
+	It("should create the secret with a generated password", func() {
+		mockClient.EXPECT().GetSecret("app-password", "apps").Return(corev1.Secret{}, NotFoundError())
+		mockClient.EXPECT().Scheme().Return(scheme.Scheme)
+		expectSecretSaved()
+		expectObjectStatusUpdate(mockClient, instance)
+
+		Expect(reconciler.reconcileGeneratedPassword()).To(Succeed())
+		Expect(saved.Data["password"]).To(HaveLen(24))
+		Expect(saved.OwnerReferences).To(HaveLen(1))
+		Expect(saved.OwnerReferences[0].UID).To(BeEquivalentTo("appuid"))
+		Expect(instance.Status.Password.LastRotationTime).ToNot(BeNil())
+	})
+
+	It("should rotate the password when requested", func() {
+		instance.Annotations = map[string]string{helpers.RotatePasswordAnnotation: "1"}
+		instance.Status.Password = &opensearchv1.UserPasswordStatus{LastRotationTime: ptr.To(metav1.Now())}
+		mockClient.EXPECT().GetSecret("app-password", "apps").Return(corev1.Secret{Data: map[string][]byte{"password": []byte("old")}}, nil)
+		expectSecretSaved()
+		expectObjectStatusUpdate(mockClient, instance)
+
+		Expect(reconciler.reconcileGeneratedPassword()).To(Succeed())
+		Expect(saved.Data).To(HaveLen(1))
+		Expect(saved.Data["password"]).ToNot(Equal([]byte("old")))
+		Expect(instance.Status.Password.LastRequest).To(Equal("1"))
+	})
+
+	It("should rotate the password after the rotation interval", func() {
+		instance.Spec.PasswordGeneration.RotationInterval = &metav1.Duration{Duration: 24 * time.Hour}
+		instance.Status.Password = &opensearchv1.UserPasswordStatus{LastRotationTime: ptr.To(metav1.NewTime(time.Now().Add(-25 * time.Hour)))}
+		mockClient.EXPECT().GetSecret("app-password", "apps").Return(corev1.Secret{Data: map[string][]byte{"password": []byte("old")}}, nil)
+		expectSecretSaved()
+		expectObjectStatusUpdate(mockClient, instance)
+
+		Expect(reconciler.reconcileGeneratedPassword()).To(Succeed())
+		Expect(saved.Data).To(HaveLen(1))
+		Expect(saved.Data["password"]).ToNot(Equal([]byte("old")))
+	})
+
+	It("should keep an existing password", func() {
+		instance.Status.Password = &opensearchv1.UserPasswordStatus{LastRotationTime: ptr.To(metav1.Now())}
+		mockClient.EXPECT().GetSecret("app-password", "apps").Return(corev1.Secret{Data: map[string][]byte{"password": []byte("current")}}, nil)
+
+		Expect(reconciler.reconcileGeneratedPassword()).To(Succeed())
+	})
+})
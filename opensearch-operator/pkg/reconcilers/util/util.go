@@ -73,7 +73,7 @@ func ReadOrGenerateCaCert(pki tls.PKI, k8sClient k8s.K8sClient, instance *opense
 	caSecret, err := k8sClient.GetSecret(secretName, namespace)
 	if err != nil {
 		// Generate CA cert and put it into secret
-		ca, err = pki.GenerateCA(clusterName)
+		ca, err = pki.GenerateCA(clusterName, helpers.CertOptions(helpers.GeneratedCaCertConfig(instance))...)
 		if err != nil {
 			logger.Error(err, "Failed to create CA")
 			return ca, err
@@ -404,9 +404,6 @@ func GetClusterHealth(k8sClient k8s.K8sClient, ctx context.Context, cluster *ope
 
 // GetAvailableOpenSearchNodes returns the sum of ready pods for all node pools
 func GetAvailableOpenSearchNodes(k8sClient k8s.K8sClient, ctx context.Context, cluster *opensearchv1.OpenSearchCluster, lg logr.Logger) int32 {
-	clusterName := cluster.Name
-	clusterNamespace := cluster.Namespace
-
 	previousAvailableNodes := cluster.Status.AvailableNodes
 	var availableNodes int32
 
@@ -414,7 +411,7 @@ func GetAvailableOpenSearchNodes(k8sClient k8s.K8sClient, ctx context.Context, c
 		var sts *appsv1.StatefulSet
 		var err error
 
-		sts, err = helpers.GetSTSForNodePool(k8sClient, nodePool, clusterName, clusterNamespace)
+		sts, err = helpers.GetSTSForNodePool(k8sClient, nodePool, cluster)
 		if err != nil {
 			lg.V(1).Info(fmt.Sprintf("Failed to get statefulsets for nodepool %s: %v", nodePool.Component, err))
 			return previousAvailableNodes
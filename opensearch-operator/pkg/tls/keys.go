@@ -0,0 +1,145 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+const (
+	KeyAlgorithmRSA   = "RSA"
+	KeyAlgorithmECDSA = "ECDSA"
+
+	DefaultRSAKeySize   = 4096
+	DefaultECDSAKeySize = 256
+	DefaultCAValidity   = 10 * 365 * 24 * time.Hour
+)
+
+type certOptions struct {
+	keyAlgorithm  string
+	keySize       int
+	signatureHash string
+	caValidity    time.Duration
+}
+
+type CertOption func(*certOptions)
+
+func (o *certOptions) apply(opts ...CertOption) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// WithKey sets the algorithm and size of generated keys. The size is the modulus size for RSA and the curve size
+// for ECDSA (256, 384 or 521). A size of 0 selects the default size of the algorithm.
+func WithKey(algorithm string, size int) CertOption {
+	return func(o *certOptions) {
+		o.keyAlgorithm = algorithm
+		o.keySize = size
+	}
+}
+
+// WithSignatureHash sets the hash of the signature algorithm (SHA256, SHA384 or SHA512), which is combined with
+// the key algorithm of the signing CA
+func WithSignatureHash(hash string) CertOption {
+	return func(o *certOptions) {
+		o.signatureHash = hash
+	}
+}
+
+// WithCAValidity sets how long a generated CA is valid
+func WithCAValidity(validity time.Duration) CertOption {
+	return func(o *certOptions) {
+		o.caValidity = validity
+	}
+}
+
+func (o *certOptions) generateKey() (crypto.Signer, error) {
+	switch o.keyAlgorithm {
+	case KeyAlgorithmECDSA:
+		var curve elliptic.Curve
+		switch o.keySize {
+		case 0, 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA key size %d", o.keySize)
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case "", KeyAlgorithmRSA:
+		size := o.keySize
+		if size == 0 {
+			size = DefaultRSAKeySize
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %s", o.keyAlgorithm)
+	}
+}
+
+// matchesKey reports whether the public key has the configured algorithm and size
+func (o *certOptions) matchesKey(key crypto.PublicKey) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		size := o.keySize
+		if size == 0 {
+			size = DefaultECDSAKeySize
+		}
+		return o.keyAlgorithm == KeyAlgorithmECDSA && k.Curve.Params().BitSize == size
+	case *rsa.PublicKey:
+		size := o.keySize
+		if size == 0 {
+			size = DefaultRSAKeySize
+		}
+		return (o.keyAlgorithm == "" || o.keyAlgorithm == KeyAlgorithmRSA) && k.N.BitLen() == size
+	default:
+		return false
+	}
+}
+
+// encodeKey returns the PEM block of the key. Keys are always PKCS8 encoded, as preferred by the OpenSearch
+// security plugin, which does not read SEC1 encoded ECDSA keys.
+func encodeKey(key crypto.Signer) (*pem.Block, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &pem.Block{Type: "PRIVATE KEY", Bytes: der}, nil
+}
+
+// signatureAlgorithm combines the configured hash with the key algorithm of the signer. Without a hash the
+// default of the x509 package is used.
+func (o *certOptions) signatureAlgorithm(signer crypto.PublicKey) (x509.SignatureAlgorithm, error) {
+	if o.signatureHash == "" {
+		return x509.UnknownSignatureAlgorithm, nil
+	}
+	algorithms := map[string]x509.SignatureAlgorithm{}
+	switch signer.(type) {
+	case *rsa.PublicKey:
+		algorithms = map[string]x509.SignatureAlgorithm{
+			"SHA256": x509.SHA256WithRSA,
+			"SHA384": x509.SHA384WithRSA,
+			"SHA512": x509.SHA512WithRSA,
+		}
+	case *ecdsa.PublicKey:
+		algorithms = map[string]x509.SignatureAlgorithm{
+			"SHA256": x509.ECDSAWithSHA256,
+			"SHA384": x509.ECDSAWithSHA384,
+			"SHA512": x509.ECDSAWithSHA512,
+		}
+	}
+	algorithm, ok := algorithms[o.signatureHash]
+	if !ok {
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported signature hash %s for key type %T", o.signatureHash, signer)
+	}
+	return algorithm, nil
+}
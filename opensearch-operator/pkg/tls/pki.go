@@ -2,13 +2,14 @@ package tls
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/rand"
-	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/pem"
+	"errors"
 	"math/big"
 	"time"
 )
@@ -17,7 +18,7 @@ import (
 //  and https://github.com/rancher-sandbox/opni-opensearch-operator/blob/main/pkg/pki/pki.go
 
 type PKI interface {
-	GenerateCA(name string) (ca Cert, err error)
+	GenerateCA(name string, opts ...CertOption) (ca Cert, err error)
 	CAFromSecret(data map[string][]byte) Cert
 }
 
@@ -26,12 +27,13 @@ type Cert interface {
 	SecretData(ca Cert) map[string][]byte
 	KeyData() []byte
 	CertData() []byte
-	CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string, validity time.Duration) (cert Cert, err error)
+	CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string, validity time.Duration, opts ...CertOption) (cert Cert, err error)
 }
 
 type CertValidater interface {
 	IsExpiringSoon() bool
 	IsSignedByCA(ca Cert) (bool, error)
+	HasKey(opts ...CertOption) bool
 }
 
 // Dummy struct so that PKI interface can be implemented for easier mocking in tests
@@ -48,7 +50,14 @@ type PEMCert struct {
 	keyBytes  []byte
 }
 
-func (pki *PkiImpl) GenerateCA(name string) (ca Cert, err error) {
+func (pki *PkiImpl) GenerateCA(name string, opts ...CertOption) (ca Cert, err error) {
+	var o certOptions
+	o.apply(opts...)
+	validity := o.caValidity
+	if validity <= 0 {
+		validity = DefaultCAValidity
+	}
+
 	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
 	if err != nil {
 		return
@@ -59,18 +68,22 @@ func (pki *PkiImpl) GenerateCA(name string) (ca Cert, err error) {
 			CommonName: name,
 		},
 		NotBefore:             time.Now(),
-		NotAfter:              time.Now().AddDate(10, 0, 0),
+		NotAfter:              time.Now().Add(validity),
 		IsCA:                  true,
 		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 		BasicConstraintsValid: true,
 	}
 
-	caPrivateKey, err := rsa.GenerateKey(rand.Reader, 4096)
+	caPrivateKey, err := o.generateKey()
+	if err != nil {
+		return
+	}
+	caCertTemplate.SignatureAlgorithm, err = o.signatureAlgorithm(caPrivateKey.Public())
 	if err != nil {
 		return
 	}
 
-	caBytes, err := x509.CreateCertificate(rand.Reader, caCertTemplate, caCertTemplate, &caPrivateKey.PublicKey, caPrivateKey)
+	caBytes, err := x509.CreateCertificate(rand.Reader, caCertTemplate, caCertTemplate, caPrivateKey.Public(), caPrivateKey)
 	if err != nil {
 		return
 	}
@@ -83,11 +96,12 @@ func (pki *PkiImpl) GenerateCA(name string) (ca Cert, err error) {
 		return
 	}
 
+	caKeyBlock, err := encodeKey(caPrivateKey)
+	if err != nil {
+		return
+	}
 	caKeyPEM := new(bytes.Buffer)
-	err = pem.Encode(caKeyPEM, &pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(caPrivateKey),
-	})
+	err = pem.Encode(caKeyPEM, caKeyBlock)
 	if err != nil {
 		return
 	}
@@ -122,7 +136,10 @@ func (cert *PEMCert) CertData() []byte {
 	return cert.certBytes
 }
 
-func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string, validity time.Duration) (cert Cert, err error) {
+func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string, validity time.Duration, opts ...CertOption) (cert Cert, err error) {
+	var o certOptions
+	o.apply(opts...)
+
 	tlscacert, err := ca.cert()
 	if err != nil {
 		return
@@ -132,7 +149,15 @@ func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, d
 		return
 	}
 
-	keypair, err := rsa.GenerateKey(rand.Reader, 4096)
+	keypair, err := o.generateKey()
+	if err != nil {
+		return
+	}
+	caSigner, ok := tlscacert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("CA private key can not be used for signing")
+	}
+	signatureAlgorithm, err := o.signatureAlgorithm(caSigner.Public())
 	if err != nil {
 		return
 	}
@@ -153,10 +178,11 @@ func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, d
 			CommonName:         commonName,
 			OrganizationalUnit: []string{orgUnit},
 		},
-		NotBefore:   time.Now(),
-		NotAfter:    notAfter,
-		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		KeyUsage:    x509.KeyUsageDigitalSignature,
+		NotBefore:          time.Now(),
+		NotAfter:           notAfter,
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:           x509.KeyUsageDigitalSignature,
+		SignatureAlgorithm: signatureAlgorithm,
 	}
 	if len(dnsnames) > 0 {
 		san, err := calculateExtension(commonName, dnsnames)
@@ -166,7 +192,7 @@ func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, d
 		x509cert.ExtraExtensions = []pkix.Extension{san}
 	}
 
-	signed, err := x509.CreateCertificate(rand.Reader, x509cert, cacert, &keypair.PublicKey, tlscacert.PrivateKey)
+	signed, err := x509.CreateCertificate(rand.Reader, x509cert, cacert, keypair.Public(), tlscacert.PrivateKey)
 	if err != nil {
 		return
 	}
@@ -181,16 +207,13 @@ func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, d
 	}
 	certBytes := certPEMBuffer.Bytes()
 
-	pkcs8key, err := x509.MarshalPKCS8PrivateKey(keypair)
+	keyBlock, err := encodeKey(keypair)
 	if err != nil {
 		return
 	}
 
 	keyPEM := new(bytes.Buffer)
-	err = pem.Encode(keyPEM, &pem.Block{
-		Type:  "PRIVATE KEY",
-		Bytes: pkcs8key,
-	})
+	err = pem.Encode(keyPEM, keyBlock)
 	if err != nil {
 		return
 	}
@@ -250,6 +273,9 @@ func NewCertValidater(pemData []byte, opts ...ImplCertValidaterOption) (CertVali
 	o.apply(opts...)
 
 	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM certificate")
+	}
 
 	cert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
@@ -267,10 +293,24 @@ func (i *implCertValidater) IsExpiringSoon() bool {
 
 func (i *implCertValidater) IsSignedByCA(ca Cert) (bool, error) {
 	block, _ := pem.Decode(ca.CertData())
+	if block == nil {
+		return false, errors.New("failed to decode PEM CA certificate")
+	}
 	caCert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
 		return false, err
 	}
 
-	return bytes.Equal(i.cert.RawIssuer, caCert.RawSubject), nil
+	if !bytes.Equal(i.cert.RawIssuer, caCert.RawSubject) {
+		return false, nil
+	}
+	// CAs generated for the same cluster share their subject, e.g. after a CA rotation
+	return i.cert.CheckSignatureFrom(caCert) == nil, nil
+}
+
+// HasKey reports whether the key of the certificate has the algorithm and size set by the options
+func (i *implCertValidater) HasKey(opts ...CertOption) bool {
+	var o certOptions
+	o.apply(opts...)
+	return o.matchesKey(i.cert.PublicKey)
 }
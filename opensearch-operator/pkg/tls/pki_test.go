@@ -0,0 +1,113 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+)
+
+func parseCert(t *testing.T, data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatal("failed to decode certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestGenerateECDSACertificates(t *testing.T) {
+	pki := NewPKI()
+	ca, err := pki.GenerateCA("ecdsa-cluster", WithKey(KeyAlgorithmECDSA, 384), WithSignatureHash("SHA384"), WithCAValidity(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert := parseCert(t, ca.CertData())
+	if caCert.SignatureAlgorithm != x509.ECDSAWithSHA384 {
+		t.Errorf("expected CA signature algorithm ECDSAWithSHA384, got %s", caCert.SignatureAlgorithm)
+	}
+	if time.Until(caCert.NotAfter) > 24*time.Hour {
+		t.Errorf("expected CA to expire within a day, got %s", caCert.NotAfter)
+	}
+	if block, _ := pem.Decode(ca.SecretDataCA()["ca.key"]); block.Type != "PRIVATE KEY" {
+		t.Errorf("expected PKCS8 encoded ECDSA CA key, got %s", block.Type)
+	}
+
+	cert, err := ca.CreateAndSignCertificate("node", "ecdsa-cluster", []string{"node"}, time.Hour, WithKey(KeyAlgorithmECDSA, 256), WithSignatureHash("SHA384"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf := parseCert(t, cert.CertData())
+	if leaf.SignatureAlgorithm != x509.ECDSAWithSHA384 {
+		t.Errorf("expected certificate signature algorithm ECDSAWithSHA384, got %s", leaf.SignatureAlgorithm)
+	}
+	if key, ok := leaf.PublicKey.(*ecdsa.PublicKey); !ok || key.Curve != elliptic.P256() {
+		t.Errorf("expected P-256 certificate key, got %T", leaf.PublicKey)
+	}
+	if err := leaf.CheckSignatureFrom(caCert); err != nil {
+		t.Errorf("certificate not signed by CA: %s", err)
+	}
+	block, _ := pem.Decode(cert.KeyData())
+	if block.Type != "PRIVATE KEY" {
+		t.Errorf("expected PKCS8 encoded certificate key, got %s", block.Type)
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+		t.Errorf("failed to parse PKCS8 key: %s", err)
+	}
+}
+
+func TestGenerateRSACAWithPKCS8Key(t *testing.T) {
+	ca, err := NewPKI().GenerateCA("rsa-cluster", WithKey(KeyAlgorithmRSA, 2048))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block, _ := pem.Decode(ca.SecretDataCA()["ca.key"]); block.Type != "PRIVATE KEY" {
+		t.Errorf("expected PKCS8 encoded CA key, got %s", block.Type)
+	}
+	if _, err := ca.CreateAndSignCertificate("node", "rsa-cluster", nil, time.Hour, WithKey(KeyAlgorithmRSA, 2048), WithSignatureHash("SHA512")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCertValidaterHasKey(t *testing.T) {
+	ca, err := NewPKI().GenerateCA("key-cluster", WithKey(KeyAlgorithmECDSA, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ca.CreateAndSignCertificate("node", "key-cluster", nil, time.Hour, WithKey(KeyAlgorithmECDSA, 384))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator, err := NewCertValidater(cert.CertData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		opts     []CertOption
+		expected bool
+	}{
+		{name: "same algorithm and size", opts: []CertOption{WithKey(KeyAlgorithmECDSA, 384)}, expected: true},
+		{name: "other size", opts: []CertOption{WithKey(KeyAlgorithmECDSA, 0)}, expected: false},
+		{name: "other algorithm", opts: []CertOption{WithKey(KeyAlgorithmRSA, 0)}, expected: false},
+		{name: "default key", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validator.HasKey(tt.opts...); got != tt.expected {
+				t.Errorf("HasKey() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRejectSignatureHashOfOtherKeyAlgorithm(t *testing.T) {
+	if _, err := NewPKI().GenerateCA("invalid", WithKey(KeyAlgorithmECDSA, 0), WithSignatureHash("MD5")); err == nil {
+		t.Error("expected unsupported signature hash to be rejected")
+	}
+}
@@ -19,10 +19,13 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
 	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -49,6 +52,18 @@ func (v *OpenSearchClusterValidator) ValidateCreate(ctx context.Context, obj run
 	if err := validateNodePoolComponentUniqueness(cluster); err != nil {
 		return nil, err
 	}
+	if err := validateVendor(cluster); err != nil {
+		return nil, err
+	}
+	if err := validateServices(cluster); err != nil {
+		return nil, err
+	}
+	if err := validateIngress(cluster); err != nil {
+		return nil, err
+	}
+	if err := validateSecurityConfig(cluster); err != nil {
+		return nil, err
+	}
 	return v.validateTlsConfig(cluster)
 }
 
@@ -65,7 +80,23 @@ func (v *OpenSearchClusterValidator) ValidateUpdate(ctx context.Context, oldObj,
 		return nil, err
 	}
 
-	// Validate storage class changes - storage class is immutable in StatefulSets
+	if err := validateVendor(newCluster); err != nil {
+		return nil, err
+	}
+
+	if err := validateServices(newCluster); err != nil {
+		return nil, err
+	}
+
+	if err := validateIngress(newCluster); err != nil {
+		return nil, err
+	}
+
+	if err := validateSecurityConfig(newCluster); err != nil {
+		return nil, err
+	}
+
+	// Validate storage class changes - a change triggers a node pool migration
 	if err := v.validateStorageClassChanges(oldCluster, newCluster); err != nil {
 		return nil, err
 	}
@@ -73,8 +104,86 @@ func (v *OpenSearchClusterValidator) ValidateUpdate(ctx context.Context, oldObj,
 	return v.validateTlsConfig(newCluster)
 }
 
+// validateVendor ensures the cluster references a vendor the operator knows how to deploy
+func validateVendor(cluster *opensearchv1.OpenSearchCluster) error {
+	if _, err := helpers.LookupVendor(cluster.Spec.General.Vendor); err != nil {
+		return fmt.Errorf("spec.general.vendor: %w", err)
+	}
+	return nil
+}
+
+// validateServices ensures the cluster service keeps selecting at least one nodepool and that
+// additional services neither collide with each other nor with the services created by the operator
+func validateServices(cluster *opensearchv1.OpenSearchCluster) error {
+	if len(cluster.Spec.NodePools) > 0 && !slices.ContainsFunc(cluster.Spec.NodePools, func(nodePool opensearchv1.NodePool) bool {
+		return helpers.IsInClientService(&nodePool)
+	}) {
+		return fmt.Errorf("at least one node pool must be part of the cluster service '%s'", cluster.Spec.General.ServiceName)
+	}
+
+	reserved := map[string]struct{}{
+		cluster.Spec.General.ServiceName:                 {},
+		cluster.Spec.General.ServiceName + "-exposed":    {},
+		cluster.Spec.General.ServiceName + "-dashboards": {},
+		cluster.Name + "-discovery":                      {},
+	}
+	for _, nodePool := range cluster.Spec.NodePools {
+		reserved[fmt.Sprintf("%s-%s", cluster.Spec.General.ServiceName, nodePool.Component)] = struct{}{}
+	}
+	seen := make(map[string]struct{})
+	for _, service := range cluster.Spec.General.AdditionalServices {
+		if errs := validation.IsDNS1035Label(service.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid additional service name '%s': %s", service.Name, strings.Join(errs, ", "))
+		}
+		if _, exists := reserved[service.Name]; exists {
+			return fmt.Errorf("additional service name '%s' conflicts with a service created by the operator", service.Name)
+		}
+		if _, exists := seen[service.Name]; exists {
+			return fmt.Errorf("duplicate additional service name '%s'", service.Name)
+		}
+		seen[service.Name] = struct{}{}
+	}
+
+	if name := cluster.Spec.Dashboards.OpensearchService; name != "" {
+		if _, exists := seen[name]; !exists {
+			return fmt.Errorf("dashboards.opensearchService '%s' does not reference an additional service", name)
+		}
+	}
+	return nil
+}
+
+// validateSecurityConfig ensures a merged securityconfig can be applied through the REST API, securityadmin.sh would
+// remove the resources managed by the OpensearchUser and OpensearchRole resources
+func validateSecurityConfig(cluster *opensearchv1.OpenSearchCluster) error {
+	if helpers.IsSecurityConfigMergeEnabled(cluster) && !helpers.SecurityChangeVersion(cluster) {
+		return fmt.Errorf("spec.security.config.mergeMode: Merge requires OpenSearch 2.x or later")
+	}
+	return nil
+}
+
+// validateIngress ensures Gateway API routes reference the gateways they attach to
+func validateIngress(cluster *opensearchv1.OpenSearchCluster) error {
+	configs := map[string]*opensearchv1.IngressConfig{
+		"general.ingress":    cluster.Spec.General.Ingress,
+		"dashboards.ingress": cluster.Spec.Dashboards.Ingress,
+	}
+	for path, config := range configs {
+		if config == nil || !config.Enable {
+			continue
+		}
+		if (config.Kind == "HTTPRoute" || config.Kind == "TLSRoute") && len(config.ParentRefs) == 0 {
+			return fmt.Errorf("%s: parentRefs are required for kind %s", path, config.Kind)
+		}
+		if config.Kind == "TLSRoute" && len(config.Hosts) == 0 {
+			return fmt.Errorf("%s: hosts are required for kind TLSRoute", path)
+		}
+	}
+	return nil
+}
+
 // validateNodePoolComponentUniqueness ensures no two node pools share the same component name,
 // since component is used to name K8s resources (StatefulSets, Services, ConfigMaps, Secrets) per node pool.
+// A node pool can only be renamed from a component that is no longer part of the cluster.
 func validateNodePoolComponentUniqueness(cluster *opensearchv1.OpenSearchCluster) error {
 	seen := make(map[string]struct{})
 	for i := range cluster.Spec.NodePools {
@@ -87,6 +196,11 @@ func validateNodePoolComponentUniqueness(cluster *opensearchv1.OpenSearchCluster
 		}
 		seen[component] = struct{}{}
 	}
+	for _, nodePool := range cluster.Spec.NodePools {
+		if _, exists := seen[nodePool.RenamedFrom]; exists {
+			return fmt.Errorf("node pool '%s' is renamed from '%s', which is still a node pool of the cluster", nodePool.Component, nodePool.RenamedFrom)
+		}
+	}
 	return nil
 }
 
@@ -128,15 +242,33 @@ func (v *OpenSearchClusterValidator) validateStorageClassChanges(oldCluster, new
 			newSC = *newStorageClass
 		}
 
-		// Reject if storage class has changed
-		if oldSC != newSC {
-			return fmt.Errorf("storage class cannot be changed for node pool '%s' (was '%s', attempting to change to '%s'). Storage class is immutable in StatefulSets. Please delete the cluster and recreate it with the new storage class", newNodePool.Component, oldSC, newSC)
+		// A storage class change replaces the node pool with a new statefulset. Only one
+		// replacement per node pool can run at a time, so reject changes while one is in progress.
+		if oldSC != newSC && helpers.IsNodePoolMigrationInProgress(oldCluster.Status, newNodePool.Component) {
+			return fmt.Errorf("storage class cannot be changed for node pool '%s' (was '%s', attempting to change to '%s') while a node pool migration is in progress. Wait for the current migration to complete", newNodePool.Component, oldSC, newSC)
+		}
+		// The same applies to changes of the storage type
+		if oldType, newType := storageType(oldNodePool), storageType(&newNodePool); oldType != newType && helpers.IsNodePoolMigrationInProgress(oldCluster.Status, newNodePool.Component) {
+			return fmt.Errorf("storage type cannot be changed for node pool '%s' (was '%s', attempting to change to '%s') while a node pool migration is in progress. Wait for the current migration to complete", newNodePool.Component, oldType, newType)
 		}
 	}
 
 	return nil
 }
 
+// storageType returns where the nodes of the node pool store their data
+func storageType(nodePool *opensearchv1.NodePool) string {
+	switch {
+	case nodePool.Persistence == nil || nodePool.Persistence.PVC != nil:
+		return helpers.StorageTypePVC
+	case nodePool.Persistence.HostPath != nil:
+		return helpers.StorageTypeHostPath
+	case nodePool.Persistence.EmptyDir != nil:
+		return helpers.StorageTypeEmptyDir
+	}
+	return ""
+}
+
 func (v *OpenSearchClusterValidator) validateTlsConfig(cluster *opensearchv1.OpenSearchCluster) (admission.Warnings, error) {
 	if cluster.Spec.Security == nil || cluster.Spec.Security.Tls == nil {
 		return nil, nil
@@ -147,8 +279,8 @@ func (v *OpenSearchClusterValidator) validateTlsConfig(cluster *opensearchv1.Ope
 	// Validate transport TLS: if enabled=true, transport config must be provided
 	if tlsConfig.Transport != nil && tlsConfig.Transport.Enabled != nil && *tlsConfig.Transport.Enabled {
 		// Transport TLS is explicitly enabled, config is already provided (Transport != nil)
-		// Validation: if enabled=true, we need either Generate=true or existing certs via Secret
-		if !tlsConfig.Transport.Generate && tlsConfig.Transport.Secret.Name == "" {
+		// Validation: if enabled=true, we need either Generate=true, cert-manager or existing certs via Secret
+		if !tlsConfig.Transport.Generate && !helpers.CertManagerEnabled(&tlsConfig.Transport.TlsCertificateConfig) && tlsConfig.Transport.Secret.Name == "" {
 			return nil, fmt.Errorf("transport TLS is enabled but neither generate nor secret is provided")
 		}
 	}
@@ -156,25 +288,29 @@ func (v *OpenSearchClusterValidator) validateTlsConfig(cluster *opensearchv1.Ope
 	// Validate HTTP TLS: if enabled=true, HTTP config must be provided
 	if tlsConfig.Http != nil && tlsConfig.Http.Enabled != nil && *tlsConfig.Http.Enabled {
 		// HTTP TLS is explicitly enabled, config is already provided (Http != nil)
-		// Validation: if enabled=true, we need either Generate=true or existing certs via Secret
-		if !tlsConfig.Http.Generate && tlsConfig.Http.Secret.Name == "" {
+		// Validation: if enabled=true, we need either Generate=true, cert-manager or existing certs via Secret
+		if !tlsConfig.Http.Generate && !helpers.CertManagerEnabled(&tlsConfig.Http.TlsCertificateConfig) && tlsConfig.Http.Secret.Name == "" {
 			return nil, fmt.Errorf("HTTP TLS is enabled but neither generate nor secret is provided")
 		}
 	}
 
+	if err := validateTlsCertificateConfigs(cluster); err != nil {
+		return nil, err
+	}
+
 	// Validate admin secret name: if AdminSecret is empty, tls generate should be true.
 	if helpers.IsSecurityPluginEnabled(cluster) {
 		if cluster.Spec.Security.Config != nil && cluster.Spec.Security.Config.AdminSecret.Name != "" {
 			return nil, nil
 		} else {
 			if helpers.SecurityChangeVersion(cluster) {
-				if tlsConfig.Http != nil && tlsConfig.Http.Generate {
+				if tlsConfig.Http != nil && (tlsConfig.Http.Generate || helpers.CertManagerEnabled(&tlsConfig.Http.TlsCertificateConfig)) {
 					return nil, nil
 				} else {
 					return nil, fmt.Errorf("admin secret name is not provided but http.tls generate is not true")
 				}
 			} else {
-				if tlsConfig.Transport != nil && tlsConfig.Transport.Generate {
+				if tlsConfig.Transport != nil && (tlsConfig.Transport.Generate || helpers.CertManagerEnabled(&tlsConfig.Transport.TlsCertificateConfig)) {
 					return nil, nil
 				} else {
 					return nil, fmt.Errorf("admin secret name is not provided but transport.tls generate is not true")
@@ -186,6 +322,39 @@ func (v *OpenSearchClusterValidator) validateTlsConfig(cluster *opensearchv1.Ope
 	return nil, nil
 }
 
+// validateTlsCertificateConfigs ensures every enabled cert-manager config references an issuer
+// and the key sizes are supported by the key algorithm
+func validateTlsCertificateConfigs(cluster *opensearchv1.OpenSearchCluster) error {
+	configs := map[string]*opensearchv1.TlsCertificateConfig{}
+	if cluster.Spec.Security != nil && cluster.Spec.Security.Tls != nil {
+		if cluster.Spec.Security.Tls.Transport != nil {
+			configs["security.tls.transport"] = &cluster.Spec.Security.Tls.Transport.TlsCertificateConfig
+		}
+		if cluster.Spec.Security.Tls.Http != nil {
+			configs["security.tls.http"] = &cluster.Spec.Security.Tls.Http.TlsCertificateConfig
+		}
+	}
+	if cluster.Spec.Dashboards.Tls != nil {
+		configs["dashboards.tls"] = &cluster.Spec.Dashboards.Tls.TlsCertificateConfig
+	}
+	for path, config := range configs {
+		if helpers.CertManagerEnabled(config) && config.CertManager.IssuerRef.Name == "" {
+			return fmt.Errorf("%s.certManager.issuerRef.name is required", path)
+		}
+		if config.KeySize == 0 {
+			continue
+		}
+		if config.KeyAlgorithm == "ECDSA" {
+			if !slices.Contains([]int{256, 384, 521}, config.KeySize) {
+				return fmt.Errorf("%s.keySize must be 256, 384 or 521 for ECDSA keys", path)
+			}
+		} else if config.KeySize < 2048 {
+			return fmt.Errorf("%s.keySize must be at least 2048 for RSA keys", path)
+		}
+	}
+	return nil
+}
+
 func (v *OpenSearchClusterValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
@@ -22,9 +22,11 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	opensearchv1 "github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/api/opensearch.org/v1"
+	"github.com/opensearch-project/opensearch-k8s-operator/opensearch-operator/pkg/helpers"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -106,6 +108,235 @@ var _ = Describe("OpenSearchClusterValidator", func() {
 			Expect(warnings).To(BeEmpty())
 		})
 
+		It("should reject an unknown vendor", func() {
+			cluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "default",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{
+						Version: "2.19.4",
+						Vendor:  "elasticsearch",
+					},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown vendor 'elasticsearch'"))
+		})
+
+		It("should reject merging the securityconfig before OpenSearch 2.x", func() {
+			cluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "default",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{
+						Version: "1.3.20",
+					},
+					Security: &opensearchv1.Security{
+						Config: &opensearchv1.SecurityConfig{MergeMode: opensearchv1.SecurityConfigMergeModeMerge},
+					},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("mergeMode: Merge requires OpenSearch 2.x"))
+
+			cluster.Spec.General.Version = "2.19.4"
+			_, err = validator.ValidateCreate(ctx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should reject a cluster without nodepools in the cluster service", func() {
+			cluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "default",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{
+						Version:     "2.19.4",
+						ServiceName: "test-cluster",
+					},
+					NodePools: []opensearchv1.NodePool{
+						{
+							Component:     "masters",
+							Replicas:      3,
+							Roles:         []string{"cluster_manager"},
+							ClientService: ptr.To(false),
+						},
+					},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("at least one node pool must be part of the cluster service"))
+		})
+
+		It("should reject additional services conflicting with operator managed services", func() {
+			cluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "default",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{
+						Version:     "2.19.4",
+						ServiceName: "test-cluster",
+						AdditionalServices: []opensearchv1.AdditionalServiceConfig{
+							{Name: "test-cluster-coordinators", Roles: []string{helpers.CoordinatingRole}},
+						},
+					},
+					NodePools: []opensearchv1.NodePool{
+						{
+							Component: "coordinators",
+							Replicas:  2,
+							Roles:     []string{},
+						},
+					},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("conflicts with a service created by the operator"))
+
+			cluster.Spec.General.AdditionalServices[0].Name = "test-cluster-coordinating"
+			cluster.Spec.Dashboards.OpensearchService = "test-cluster-coordinating"
+			_, err = validator.ValidateCreate(ctx, cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject Gateway API routes without parentRefs", func() {
+			cluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "default",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{
+						Version:     "2.19.4",
+						ServiceName: "test-cluster",
+						Ingress: &opensearchv1.IngressConfig{
+							Enable: true,
+							Kind:   "HTTPRoute",
+							Hosts:  []string{"opensearch.example.com"},
+						},
+					},
+					NodePools: []opensearchv1.NodePool{
+						{
+							Component: "masters",
+							Replicas:  3,
+							Roles:     []string{"cluster_manager", "data"},
+						},
+					},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("general.ingress: parentRefs are required for kind HTTPRoute"))
+
+			cluster.Spec.General.Ingress.ParentRefs = []opensearchv1.GatewayParentRef{{Name: "gateway"}}
+			_, err = validator.ValidateCreate(ctx, cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should accept cert-manager issued certificates instead of generate", func() {
+			cluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "default",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{
+						Version:     "2.19.4",
+						ServiceName: "test-cluster",
+					},
+					Security: &opensearchv1.Security{
+						Tls: &opensearchv1.TlsConfig{
+							Transport: &opensearchv1.TlsConfigTransport{
+								Enabled:              ptr.To(true),
+								TlsCertificateConfig: opensearchv1.TlsCertificateConfig{CertManager: &opensearchv1.CertManagerConfig{Enable: true}},
+							},
+							Http: &opensearchv1.TlsConfigHttp{
+								Enabled:              ptr.To(true),
+								TlsCertificateConfig: opensearchv1.TlsCertificateConfig{CertManager: &opensearchv1.CertManagerConfig{Enable: true}},
+							},
+						},
+					},
+					NodePools: []opensearchv1.NodePool{
+						{
+							Component: "masters",
+							Replicas:  3,
+							Roles:     []string{"cluster_manager", "data"},
+						},
+					},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("certManager.issuerRef.name is required"))
+
+			cluster.Spec.Security.Tls.Transport.CertManager.IssuerRef.Name = "opensearch-ca"
+			cluster.Spec.Security.Tls.Http.CertManager.IssuerRef.Name = "opensearch-ca"
+			_, err = validator.ValidateCreate(ctx, cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject key sizes not supported by the key algorithm", func() {
+			cluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "default",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					General: opensearchv1.GeneralConfig{
+						Version:     "2.19.4",
+						ServiceName: "test-cluster",
+					},
+					Security: &opensearchv1.Security{
+						Tls: &opensearchv1.TlsConfig{
+							Transport: &opensearchv1.TlsConfigTransport{
+								Generate:             true,
+								TlsCertificateConfig: opensearchv1.TlsCertificateConfig{KeyAlgorithm: "ECDSA", KeySize: 3072},
+							},
+							Http: &opensearchv1.TlsConfigHttp{
+								Generate:             true,
+								TlsCertificateConfig: opensearchv1.TlsCertificateConfig{KeySize: 1024},
+							},
+						},
+					},
+					NodePools: []opensearchv1.NodePool{
+						{
+							Component: "masters",
+							Replicas:  3,
+							Roles:     []string{"cluster_manager", "data"},
+						},
+					},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+
+			cluster.Spec.Security.Tls.Transport.KeySize = 384
+			_, err = validator.ValidateCreate(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("security.tls.http.keySize must be at least 2048 for RSA keys"))
+
+			cluster.Spec.Security.Tls.Http.KeySize = 3072
+			_, err = validator.ValidateCreate(ctx, cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("should reject transport TLS enabled without generate or secret", func() {
 			enabled := true
 			cluster := &opensearchv1.OpenSearchCluster{
@@ -246,7 +477,7 @@ var _ = Describe("OpenSearchClusterValidator", func() {
 			Expect(warnings).To(BeEmpty())
 		})
 
-		It("should reject storage class changes", func() {
+		It("should allow storage class changes", func() {
 			oldStorageClass := "old-storage-class"
 			newStorageClass := "new-storage-class"
 			oldCluster := &opensearchv1.OpenSearchCluster{
@@ -288,9 +519,65 @@ var _ = Describe("OpenSearchClusterValidator", func() {
 				},
 			}
 
+			warnings, err := validator.ValidateUpdate(ctx, oldCluster, newCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should reject storage class changes while a node pool migration is in progress", func() {
+			oldStorageClass := "old-storage-class"
+			newStorageClass := "new-storage-class"
+			oldCluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					NodePools: []opensearchv1.NodePool{
+						{
+							Component: "masters",
+							Persistence: &opensearchv1.PersistenceConfig{
+								PersistenceSource: opensearchv1.PersistenceSource{
+									PVC: &opensearchv1.PVCSource{
+										StorageClassName: &oldStorageClass,
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: opensearchv1.ClusterStatus{
+					ComponentsStatus: []opensearchv1.ComponentStatus{
+						{
+							Component:   helpers.NodePoolMigrationComponent,
+							Status:      helpers.NodePoolMigrationDraining,
+							Description: "masters",
+						},
+					},
+				},
+			}
+			newCluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					NodePools: []opensearchv1.NodePool{
+						{
+							Component: "masters",
+							Persistence: &opensearchv1.PersistenceConfig{
+								PersistenceSource: opensearchv1.PersistenceSource{
+									PVC: &opensearchv1.PVCSource{
+										StorageClassName: &newStorageClass,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
 			warnings, err := validator.ValidateUpdate(ctx, oldCluster, newCluster)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("storage class cannot be changed"))
+			Expect(err.Error()).To(ContainSubstring("node pool migration is in progress"))
 			Expect(warnings).To(BeEmpty())
 		})
 
@@ -403,6 +690,29 @@ var _ = Describe("OpenSearchClusterValidator", func() {
 			Expect(err.Error()).To(ContainSubstring("duplicate node pool component name 'masters'"))
 			Expect(warnings).To(BeEmpty())
 		})
+
+		It("should reject renaming a node pool from a component that is still part of the cluster", func() {
+			oldCluster := &opensearchv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				Spec: opensearchv1.ClusterSpec{
+					NodePools: []opensearchv1.NodePool{
+						{Component: "masters"},
+						{Component: "data"},
+					},
+				},
+			}
+			newCluster := oldCluster.DeepCopy()
+			newCluster.Spec.NodePools = append(newCluster.Spec.NodePools, opensearchv1.NodePool{Component: "hot", RenamedFrom: "data"})
+
+			_, err := validator.ValidateUpdate(ctx, oldCluster, newCluster)
+			Expect(err).To(MatchError(ContainSubstring("renamed from 'data'")))
+
+			newCluster.Spec.NodePools = []opensearchv1.NodePool{{Component: "masters"}, {Component: "hot", RenamedFrom: "data"}}
+			_, err = validator.ValidateUpdate(ctx, oldCluster, newCluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
 	})
 
 	Describe("ValidateDelete", func() {